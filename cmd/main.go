@@ -11,6 +11,8 @@ import (
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/logger"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/redact"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/tracing"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"github.com/pkg/errors"
@@ -43,13 +45,36 @@ func startServer() error {
 		runtime.SetMutexProfileFraction(1)
 		runtime.SetBlockProfileRate(1)
 	}
+	redactor, err := redact.New(cfg)
+	if err != nil {
+		return err
+	}
+
 	log := logger.New(os.Stdout, logger.LogOptions{
 		Enable:               cfg.Logging.Enable,
 		TimestampFieldName:   cfg.Logging.TimestampFieldName,
 		TimestampFieldFormat: cfg.Logging.TimestampFieldFormat,
 		Level:                cfg.Logging.Level,
 		Format:               cfg.Logging.Format,
+		Redactor:             redactor,
 	})
+	//// =========================================================================
+	//// Tracing
+	var traceExporter *tracing.Exporter
+	if cfg.Tracing.Enable {
+		log.Info("initializing tracing", "endpoint", cfg.Tracing.Endpoint)
+		traceExporter = tracing.NewExporter(log, tracing.ExporterOptions{
+			Endpoint:      cfg.Tracing.Endpoint,
+			ServiceName:   cfg.Tracing.ServiceName,
+			Headers:       cfg.Tracing.Headers,
+			BatchSize:     cfg.Tracing.BatchSize,
+			QueueSize:     cfg.Tracing.QueueSize,
+			Interval:      cfg.Tracing.ExportInterval,
+			ExportTimeout: cfg.Tracing.ExportTimeout,
+		})
+		tracing.SetTracer(tracing.NewTracer(traceExporter, cfg.Tracing.SampleRatio))
+	}
+
 	//// =========================================================================
 	//// Start API
 	log.Info("initializing api")
@@ -58,7 +83,7 @@ func startServer() error {
 	signal.Notify(shutdownSignal, os.Interrupt, syscall.SIGTERM)
 
 	serverCfg := cfg.HTTP.Server
-	apiHandler, err := web.API(log, cfg)
+	apiHandler, readiness, err := web.API(log, cfg, redactor)
 	if err != nil {
 		return err
 	}
@@ -73,7 +98,7 @@ func startServer() error {
 	}
 	health := &fasthttp.Server{
 		Name:                          "health",
-		Handler:                       web.Health(log, cfg),
+		Handler:                       web.Health(log, cfg, readiness),
 		TCPKeepalive:                  true,
 		IdleTimeout:                   serverCfg.IdleTimeout,
 		ReadTimeout:                   serverCfg.ReadTimeout,
@@ -115,6 +140,12 @@ func startServer() error {
 		defer cancel()
 		err := shutdown(timeout, log, api, health)
 
+		if traceExporter != nil {
+			if traceErr := traceExporter.Shutdown(timeout); traceErr != nil {
+				log.Error("failed to export remaining spans", traceErr)
+			}
+		}
+
 		switch {
 		case sig == syscall.SIGSTOP:
 			return errors.New("integrity issue caused shutdown")
@@ -3,22 +3,26 @@ module github.com/b2wdigital/restQL-golang/v6
 go 1.16
 
 require (
+	github.com/andybalholm/brotli v1.0.1
 	github.com/bluele/gcache v0.0.0-20190518031135-bc40bd653833
 	github.com/caarlos0/env/v6 v6.3.0
-	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/fasthttp/router v1.3.2
-	github.com/google/go-cmp v0.5.2
+	github.com/google/go-cmp v0.5.5
 	github.com/google/uuid v1.1.2
+	github.com/graphql-go/graphql v0.8.0
 	github.com/imdario/mergo v0.3.11
-	github.com/klauspost/compress v1.11.9 // indirect
+	github.com/klauspost/compress v1.11.9
 	github.com/pkg/errors v0.9.1
+	github.com/prometheus/client_golang v1.11.0
 	github.com/rs/dnscache v0.0.0-20190621150935-06bb5526f76b
 	github.com/rs/zerolog v1.20.0
 	github.com/valyala/fasthttp v1.22.0
 	go.uber.org/automaxprocs v1.3.0
 	golang.org/x/lint v0.0.0-20200302205851-738671d3881b // indirect
-	golang.org/x/sync v0.0.0-20200625203802-6e8e738ad208
+	golang.org/x/sync v0.0.0-20201207232520-09787c993a3a
 	golang.org/x/tools v0.0.0-20200923182640-463111b69878 // indirect
+	google.golang.org/grpc v1.43.0
+	google.golang.org/protobuf v1.27.1
 	gopkg.in/yaml.v2 v2.3.0
 	honnef.co/go/tools v0.0.1-2020.1.5 // indirect
 )
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// valueContext exposes the values of the parent
+// context while ignoring its cancellation.
+type valueContext struct {
+	context.Context
+}
+
+func (valueContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (valueContext) Done() <-chan struct{}       { return nil }
+func (valueContext) Err() error                  { return nil }
+
+// DetachContext returns a context carrying the values of the
+// given one, like the logger, the client identity and the tracing
+// span, that is never cancelled. It is used by work that outlives
+// the request that started it.
+func DetachContext(ctx context.Context) context.Context {
+	return valueContext{ctx}
+}
@@ -353,3 +353,74 @@ func (f AsQuery) Arguments() []Arg {
 func (f AsQuery) Map(fn func(target interface{}) interface{}) Function {
 	return AsQuery{Value: fn(f.Value)}
 }
+
+// AsForm is a Function that encode the target value
+// as an application/x-www-form-urlencoded request body.
+type AsForm struct {
+	Value interface{}
+}
+
+// Argument fetches a AsForm argument by name
+func (f AsForm) Argument(name string) Arg {
+	return Arg{}
+}
+
+// SetArgument immutably updates the value of an argument by name
+func (f AsForm) SetArgument(name string, value interface{}) Function {
+	return f
+}
+
+// Target return the value upon which AsForm will be applied.
+func (f AsForm) Target() interface{} {
+	return f.Value
+}
+
+// Arguments return the arguments provided to AsForm function
+func (f AsForm) Arguments() []Arg {
+	return nil
+}
+
+// Map apply the given function to the Target value
+// preserving the AsForm as a wrapper.
+func (f AsForm) Map(fn func(target interface{}) interface{}) Function {
+	return AsForm{Value: fn(f.Value)}
+}
+
+// AsXML is a Function that encode the target value
+// as an application/xml request body.
+type AsXML struct {
+	Value interface{}
+}
+
+// Argument fetches a AsXML argument by name
+func (f AsXML) Argument(name string) Arg {
+	return Arg{}
+}
+
+// SetArgument immutably updates the value of an argument by name
+func (f AsXML) SetArgument(name string, value interface{}) Function {
+	return f
+}
+
+// Target return the value upon which AsXML will be applied.
+func (f AsXML) Target() interface{} {
+	return f.Value
+}
+
+// Arguments return the arguments provided to AsXML function
+func (f AsXML) Arguments() []Arg {
+	return nil
+}
+
+// Map apply the given function to the Target value
+// preserving the AsXML as a wrapper.
+func (f AsXML) Map(fn func(target interface{}) interface{}) Function {
+	return AsXML{Value: fn(f.Value)}
+}
+
+// EncodedBody is the result of a body encoder that
+// requires a specific content type to be sent upstream.
+type EncodedBody struct {
+	ContentType string
+	Data        string
+}
@@ -11,6 +11,11 @@ import (
 // the timeout defined in HTTPRequest.
 var ErrRequestTimeout = errors.New("request timed out")
 
+// ErrEgressDenied is the error returned by HTTPClient
+// when the upstream address of a HTTP call is not
+// allowed by the egress policy.
+var ErrEgressDenied = errors.New("upstream address denied by egress policy")
+
 // EnvSource expose access to environment variables.
 type EnvSource interface {
 	GetString(key string) string
@@ -24,3 +29,24 @@ type EnvSource interface {
 type HTTPClient interface {
 	Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error)
 }
+
+// Upstream identifies the resource mapping a HTTPRequest targets.
+type Upstream struct {
+	Tenant   string
+	Resource string
+}
+
+type upstreamCtxKey struct{}
+
+// WithUpstream returns a copy of the given context
+// carrying the identity of the targeted upstream.
+func WithUpstream(ctx context.Context, upstream Upstream) context.Context {
+	return context.WithValue(ctx, upstreamCtxKey{}, upstream)
+}
+
+// GetUpstream extracts the identity of the targeted
+// upstream from the given context, if present.
+func GetUpstream(ctx context.Context) (Upstream, bool) {
+	upstream, ok := ctx.Value(upstreamCtxKey{}).(Upstream)
+	return upstream, ok
+}
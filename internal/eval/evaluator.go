@@ -3,10 +3,15 @@ package eval
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/accesslog"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/auth"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/metrics"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/tracing"
 	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"github.com/pkg/errors"
@@ -29,10 +34,13 @@ type Evaluator struct {
 	queryReader    QueryReader
 	runner         runner.Runner
 	lifecycle      plugins.Lifecycle
+	authRequired   bool
 }
 
 // NewEvaluator constructs an instance of the restQL interpreter.
-func NewEvaluator(log restql.Logger, mr MappingsReader, qr QueryReader, r runner.Runner, p parser.Parser, l plugins.Lifecycle) Evaluator {
+// When authRequired is set, queries are only evaluated on contexts
+// carrying an authenticated client.
+func NewEvaluator(log restql.Logger, mr MappingsReader, qr QueryReader, r runner.Runner, p parser.Parser, l plugins.Lifecycle, authRequired bool) Evaluator {
 	return Evaluator{
 		log:            log,
 		mappingsReader: mr,
@@ -40,6 +48,7 @@ func NewEvaluator(log restql.Logger, mr MappingsReader, qr QueryReader, r runner
 		runner:         r,
 		parser:         p,
 		lifecycle:      l,
+		authRequired:   authRequired,
 	}
 }
 
@@ -50,6 +59,11 @@ func (e Evaluator) AdHocQuery(ctx context.Context, queryTxt string, queryOpts re
 		return nil, fmt.Errorf("%w: %s", ErrValidation, errInvalidTenant)
 	}
 
+	err := e.Authorize(ctx, queryOpts)
+	if err != nil {
+		return nil, err
+	}
+
 	return e.evaluateQuery(ctx, queryTxt, queryOpts, queryInput)
 }
 
@@ -62,6 +76,13 @@ func (e Evaluator) SavedQuery(ctx context.Context, queryOpts restql.QueryOptions
 		return nil, err
 	}
 
+	// authorized before being read, so clients cannot tell
+	// which of the queries they cannot run exist
+	err = e.Authorize(ctx, queryOpts)
+	if err != nil {
+		return nil, err
+	}
+
 	savedQuery, err := e.queryReader.Get(ctx, queryOpts.Namespace, queryOpts.Id, queryOpts.Revision)
 	if err != nil {
 		return nil, err
@@ -73,10 +94,35 @@ func (e Evaluator) SavedQuery(ctx context.Context, queryOpts restql.QueryOptions
 	return e.evaluateQuery(ctx, savedQuery.Text, queryOpts, queryInput)
 }
 
-func (e Evaluator) evaluateQuery(ctx context.Context, queryTxt string, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (domain.Resources, error) {
+// Authorize checks whether the client authenticated on the context
+// can run the query identified by the options, or ad-hoc queries when
+// they identify none.
+func (e Evaluator) Authorize(ctx context.Context, queryOpts restql.QueryOptions) error {
+	err := auth.Authorize(ctx, queryOpts, e.authRequired)
+	if err != nil {
+		restql.GetLogger(ctx).Debug("query not allowed for client", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (e Evaluator) evaluateQuery(ctx context.Context, queryTxt string, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (resources domain.Resources, err error) {
 	log := restql.GetLogger(ctx)
 
+	accesslog.FromContext(ctx).SetQuery(queryOpts)
+
+	start := time.Now()
+	defer func() {
+		metrics.ObserveQuery(queryOpts.Namespace, queryOpts.Id, err, time.Since(start))
+	}()
+
+	queryInput = auth.WithClaimVariables(ctx, queryInput)
+
+	_, parseSpan := tracing.Start(ctx, "parse", tracing.SpanKindInternal)
 	query, err := e.parser.Parse(queryTxt)
+	parseSpan.SetError(err)
+	parseSpan.End()
 	if err != nil {
 		log.Debug("failed to parse query", "error", err)
 		return nil, fmt.Errorf("%w: invalid query syntax %s", ErrParser, err)
@@ -104,7 +150,9 @@ func (e Evaluator) evaluateQuery(ctx context.Context, queryTxt string, queryOpts
 
 	query = ResolveVariables(query, queryContext.Input)
 
-	resources, err := e.runner.ExecuteQuery(queryCtx, query, queryContext)
+	queryCtx = withFilteredListener(queryCtx, log, query)
+
+	resources, err = e.runner.ExecuteQuery(queryCtx, query, queryContext)
 	switch {
 	case err == runner.ErrQueryTimedOut:
 		return nil, fmt.Errorf("%w: %s", ErrTimeout, err)
@@ -0,0 +1,46 @@
+package eval
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/auth"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestEvaluatorSavedQueryAuthorization(t *testing.T) {
+	authenticator, err := auth.NewAuthenticator(auth.Options{
+		APIKeys: []auth.APIKey{{Name: "dc", Key: "secret", Queries: []string{"dc/*"}}},
+	})
+	test.VerifyError(t, err)
+
+	identity, err := authenticator.Authenticate(auth.Credentials{APIKey: "secret"})
+	test.VerifyError(t, err)
+
+	queries := &stubQueryReader{}
+	evaluator := NewEvaluator(test.NoOpLogger, nil, queries, runner.Runner{}, nil, plugins.NoOpLifecycle, true)
+
+	ctx := auth.WithIdentity(context.Background(), identity)
+	options := restql.QueryOptions{Namespace: "marvel", Id: "heroes", Revision: 1, Tenant: "MARVEL"}
+
+	_, err = evaluator.SavedQuery(ctx, options, restql.QueryInput{})
+	test.Equal(t, errors.Is(err, auth.ErrForbidden), true)
+	test.Equal(t, queries.calls, 0)
+
+	_, err = evaluator.SavedQuery(context.Background(), options, restql.QueryInput{})
+	test.Equal(t, errors.Is(err, auth.ErrUnauthenticated), true)
+	test.Equal(t, queries.calls, 0)
+}
+
+type stubQueryReader struct {
+	calls int
+}
+
+func (s *stubQueryReader) Get(ctx context.Context, namespace, id string, revision int) (restql.SavedQueryRevision, error) {
+	s.calls++
+	return restql.SavedQueryRevision{}, restql.ErrQueryNotFound
+}
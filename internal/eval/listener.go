@@ -0,0 +1,91 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+)
+
+type statementListenerKey struct{}
+
+// WithStatementListener returns a copy of the context that makes
+// the Evaluator notify the listener of each statement result as
+// soon as it is done, with the statement filters applied.
+// Hidden statements are not notified.
+func WithStatementListener(ctx context.Context, listener runner.StatementListener) context.Context {
+	return context.WithValue(ctx, statementListenerKey{}, listener)
+}
+
+// withFilteredListener wraps the listener in the context, if any,
+// into one applying the statement filters before notifying it.
+func withFilteredListener(ctx context.Context, log restql.Logger, query domain.Query) context.Context {
+	listener, ok := ctx.Value(statementListenerKey{}).(runner.StatementListener)
+	if !ok {
+		return ctx
+	}
+
+	return runner.WithStatementListener(ctx, filteredListener(log, query, listener))
+}
+
+// filteredListener applies the filters to a copy of the result,
+// since the original one may still be used to resolve chained values.
+func filteredListener(log restql.Logger, query domain.Query, listener runner.StatementListener) runner.StatementListener {
+	statements := make(map[domain.ResourceID]domain.Statement, len(query.Statements))
+	for _, stmt := range query.Statements {
+		statements[domain.NewResourceID(stmt)] = stmt
+	}
+
+	return func(resourceID domain.ResourceID, response interface{}) {
+		stmt, found := statements[resourceID]
+		if !found || stmt.Hidden {
+			return
+		}
+
+		copied, err := copyResource(log, response)
+		if err != nil {
+			log.Error("failed to copy statement result", err, "statement", fmt.Sprintf("%+#v", stmt))
+			return
+		}
+
+		filtered, err := applyOnlyFilters(stmt.Only, copied)
+		if err != nil {
+			log.Error("failed to apply filter on statement", err, "statement", fmt.Sprintf("%+#v", stmt))
+			return
+		}
+
+		listener(resourceID, filtered)
+	}
+}
+
+func copyResource(log restql.Logger, response interface{}) (interface{}, error) {
+	switch response := response.(type) {
+	case restql.DoneResource:
+		if response.ResponseBody == nil {
+			return response, nil
+		}
+
+		data, err := json.Marshal(response.ResponseBody.Unmarshal())
+		if err != nil {
+			return nil, err
+		}
+
+		response.ResponseBody = restql.NewResponseBodyFromBytes(log, data)
+		return response, nil
+	case restql.DoneResources:
+		list := make(restql.DoneResources, len(response))
+		for i, r := range response {
+			c, err := copyResource(log, r)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = c
+		}
+		return list, nil
+	default:
+		return response, nil
+	}
+}
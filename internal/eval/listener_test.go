@@ -0,0 +1,51 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestFilteredListener(t *testing.T) {
+	query := domain.Query{Statements: []domain.Statement{
+		{Resource: "hero", Only: []interface{}{[]string{"name"}}},
+		{Resource: "sidekick", Hidden: true},
+	}}
+
+	notified := make(map[domain.ResourceID]interface{})
+	listener := filteredListener(test.NoOpLogger, query, func(resourceID domain.ResourceID, response interface{}) {
+		notified[resourceID] = response
+	})
+
+	hero := restql.DoneResource{
+		Status:       200,
+		ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, map[string]interface{}{"id": "1", "name": "Batman"}),
+	}
+	sidekick := restql.DoneResource{
+		Status:       200,
+		ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, map[string]interface{}{"name": "Robin"}),
+	}
+
+	listener("hero", hero)
+	listener("sidekick", sidekick)
+	listener("villain", hero)
+
+	t.Run("should notify statement result with filters applied", func(t *testing.T) {
+		got, found := notified["hero"]
+		if !found {
+			t.Fatalf("expected hero to be notified")
+		}
+
+		test.Equal(t, got.(restql.DoneResource).ResponseBody.Unmarshal(), map[string]interface{}{"name": "Batman"})
+	})
+
+	t.Run("should not change the original result", func(t *testing.T) {
+		test.Equal(t, hero.ResponseBody.Unmarshal(), map[string]interface{}{"id": "1", "name": "Batman"})
+	})
+
+	t.Run("should not notify hidden or unknown statements", func(t *testing.T) {
+		test.Equal(t, len(notified), 1)
+	})
+}
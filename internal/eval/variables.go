@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"net/http"
+	"sort"
 	"strconv"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
@@ -29,6 +30,54 @@ func ResolveVariables(query domain.Query, input restql.QueryInput) domain.Query
 	return domain.Query{Use: query.Use, Statements: result}
 }
 
+// QueryVariables returns the names of all variables
+// used in the query, in alphabetical order.
+func QueryVariables(query domain.Query) []string {
+	var names []string
+	seen := make(map[string]bool)
+
+	var collect func(value interface{})
+	collect = func(value interface{}) {
+		switch value := value.(type) {
+		case domain.Variable:
+			if !seen[value.Target] {
+				seen[value.Target] = true
+				names = append(names, value.Target)
+			}
+		case domain.Function:
+			collect(value.Target())
+			for _, arg := range value.Arguments() {
+				collect(arg.Value)
+			}
+		case domain.Chain:
+			for _, v := range value {
+				collect(v)
+			}
+		case []interface{}:
+			for _, v := range value {
+				collect(v)
+			}
+		case map[string]interface{}:
+			for _, v := range value {
+				collect(v)
+			}
+		}
+	}
+
+	for _, stmt := range query.Statements {
+		collect(stmt.With.Body)
+		collect(stmt.With.Values)
+		collect(stmt.Timeout)
+		collect(stmt.Headers)
+		collect(stmt.CacheControl.MaxAge)
+		collect(stmt.CacheControl.SMaxAge)
+		collect(stmt.Only)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
 func resolveWith(with domain.Params, input restql.QueryInput) domain.Params {
 	if with.Values == nil && with.Body == nil {
 		return with
@@ -282,3 +282,32 @@ func TestResolveVariables(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryVariables(t *testing.T) {
+	query := domain.Query{Statements: []domain.Statement{
+		{
+			Resource: "hero",
+			With: domain.Params{
+				Body: domain.Variable{Target: "body"},
+				Values: map[string]interface{}{
+					"id":       domain.Variable{Target: "id"},
+					"universe": []interface{}{domain.Variable{Target: "universe"}, "dc"},
+					"weapons":  map[string]interface{}{"name": domain.Variable{Target: "weapon"}},
+				},
+			},
+			Headers: map[string]interface{}{"Authorization": domain.Variable{Target: "auth"}},
+		},
+		{
+			Resource: "sidekick",
+			Timeout:  domain.Variable{Target: "timeout"},
+			With: domain.Params{
+				Values: map[string]interface{}{
+					"hero": domain.Chain{"hero", domain.Variable{Target: "id"}},
+				},
+			},
+		},
+	}}
+
+	got := eval.QueryVariables(query)
+	test.Equal(t, got, []string{"auth", "body", "id", "timeout", "universe", "weapon"})
+}
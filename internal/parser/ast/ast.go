@@ -23,6 +23,8 @@ const (
 	Flatten             = "flatten"
 	NoExplode           = "no-explode"
 	AsQuery             = "as-query"
+	AsForm              = "as-form"
+	AsXML               = "as-xml"
 )
 
 // Query is the root of the restQL AST.
@@ -40,8 +42,9 @@ type Use struct {
 // UseValue is the syntax node representing
 // the `use` clause possible values.
 type UseValue struct {
-	Int    *int
-	String *string
+	Int     *int
+	String  *string
+	Boolean *bool
 }
 
 // Block is the syntax node representing a statement.
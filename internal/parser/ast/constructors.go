@@ -71,6 +71,11 @@ func newUseValue(value interface{}) (UseValue, error) {
 		return UseValue{String: &sInt}, nil
 	}
 
+	sBool, ok := value.(bool)
+	if ok {
+		return UseValue{Boolean: &sBool}, nil
+	}
+
 	return UseValue{}, errors.Errorf("unknown use value type : %T", value)
 }
 
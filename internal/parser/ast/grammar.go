@@ -171,27 +171,45 @@ var g = &grammar{
 							pos:  position{line: 21, col: 37, offset: 339},
 							name: "WS",
 						},
+						&zeroOrOneExpr{
+							pos: position{line: 21, col: 40, offset: 342},
+							expr: &seqExpr{
+								pos: position{line: 21, col: 41, offset: 343},
+								exprs: []interface{}{
+									&litMatcher{
+										pos:        position{line: 21, col: 41, offset: 343},
+										val:        "=",
+										ignoreCase: false,
+										want:       "\"=\"",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 21, col: 45, offset: 347},
+										name: "WS",
+									},
+								},
+							},
+						},
 						&labeledExpr{
-							pos:   position{line: 21, col: 40, offset: 342},
+							pos:   position{line: 21, col: 50, offset: 352},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 21, col: 43, offset: 345},
+								pos:  position{line: 21, col: 53, offset: 355},
 								name: "USE_VALUE",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 21, col: 54, offset: 356},
+							pos:  position{line: 21, col: 64, offset: 366},
 							name: "WS",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 21, col: 57, offset: 359},
+							pos: position{line: 21, col: 67, offset: 369},
 							expr: &ruleRefExpr{
-								pos:  position{line: 21, col: 57, offset: 359},
+								pos:  position{line: 21, col: 67, offset: 369},
 								name: "LS",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 21, col: 61, offset: 363},
+							pos:  position{line: 21, col: 71, offset: 373},
 							name: "WS",
 						},
 					},
@@ -200,55 +218,65 @@ var g = &grammar{
 		},
 		{
 			name: "USE_ACTION",
-			pos:  position{line: 25, col: 1, offset: 392},
+			pos:  position{line: 25, col: 1, offset: 402},
 			expr: &actionExpr{
-				pos: position{line: 25, col: 15, offset: 406},
+				pos: position{line: 25, col: 15, offset: 416},
 				run: (*parser).callonUSE_ACTION1,
 				expr: &choiceExpr{
-					pos: position{line: 25, col: 16, offset: 407},
+					pos: position{line: 25, col: 16, offset: 417},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 25, col: 16, offset: 407},
+							pos:        position{line: 25, col: 16, offset: 417},
 							val:        "timeout",
 							ignoreCase: false,
 							want:       "\"timeout\"",
 						},
 						&litMatcher{
-							pos:        position{line: 25, col: 28, offset: 419},
+							pos:        position{line: 25, col: 28, offset: 429},
 							val:        "max-age",
 							ignoreCase: false,
 							want:       "\"max-age\"",
 						},
 						&litMatcher{
-							pos:        position{line: 25, col: 40, offset: 431},
+							pos:        position{line: 25, col: 40, offset: 441},
 							val:        "s-max-age",
 							ignoreCase: false,
 							want:       "\"s-max-age\"",
 						},
+						&litMatcher{
+							pos:        position{line: 25, col: 54, offset: 455},
+							val:        "partial-on-timeout",
+							ignoreCase: false,
+							want:       "\"partial-on-timeout\"",
+						},
 					},
 				},
 			},
 		},
 		{
 			name: "USE_VALUE",
-			pos:  position{line: 29, col: 1, offset: 475},
+			pos:  position{line: 29, col: 1, offset: 508},
 			expr: &actionExpr{
-				pos: position{line: 29, col: 14, offset: 488},
+				pos: position{line: 29, col: 14, offset: 521},
 				run: (*parser).callonUSE_VALUE1,
 				expr: &labeledExpr{
-					pos:   position{line: 29, col: 14, offset: 488},
+					pos:   position{line: 29, col: 14, offset: 521},
 					label: "v",
 					expr: &choiceExpr{
-						pos: position{line: 29, col: 17, offset: 491},
+						pos: position{line: 29, col: 17, offset: 524},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 29, col: 17, offset: 491},
+								pos:  position{line: 29, col: 17, offset: 524},
 								name: "String",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 29, col: 26, offset: 500},
+								pos:  position{line: 29, col: 26, offset: 533},
 								name: "Integer",
 							},
+							&ruleRefExpr{
+								pos:  position{line: 29, col: 36, offset: 543},
+								name: "Boolean",
+							},
 						},
 					},
 				},
@@ -256,57 +284,57 @@ var g = &grammar{
 		},
 		{
 			name: "BLOCK",
-			pos:  position{line: 33, col: 1, offset: 537},
+			pos:  position{line: 33, col: 1, offset: 580},
 			expr: &actionExpr{
-				pos: position{line: 33, col: 10, offset: 546},
+				pos: position{line: 33, col: 10, offset: 589},
 				run: (*parser).callonBLOCK1,
 				expr: &seqExpr{
-					pos: position{line: 33, col: 10, offset: 546},
+					pos: position{line: 33, col: 10, offset: 589},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 33, col: 10, offset: 546},
+							pos:   position{line: 33, col: 10, offset: 589},
 							label: "action",
 							expr: &ruleRefExpr{
-								pos:  position{line: 33, col: 18, offset: 554},
+								pos:  position{line: 33, col: 18, offset: 597},
 								name: "ACTION_RULE",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 33, col: 31, offset: 567},
+							pos:   position{line: 33, col: 31, offset: 610},
 							label: "m",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 33, col: 34, offset: 570},
+								pos: position{line: 33, col: 34, offset: 613},
 								expr: &ruleRefExpr{
-									pos:  position{line: 33, col: 34, offset: 570},
+									pos:  position{line: 33, col: 34, offset: 613},
 									name: "MODIFIER_RULE",
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 33, col: 50, offset: 586},
+							pos:   position{line: 33, col: 50, offset: 629},
 							label: "w",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 33, col: 53, offset: 589},
+								pos: position{line: 33, col: 53, offset: 632},
 								expr: &ruleRefExpr{
-									pos:  position{line: 33, col: 53, offset: 589},
+									pos:  position{line: 33, col: 53, offset: 632},
 									name: "WITH_RULE",
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 33, col: 65, offset: 601},
+							pos:   position{line: 33, col: 65, offset: 644},
 							label: "f",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 33, col: 67, offset: 603},
+								pos: position{line: 33, col: 67, offset: 646},
 								expr: &choiceExpr{
-									pos: position{line: 33, col: 68, offset: 604},
+									pos: position{line: 33, col: 68, offset: 647},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 33, col: 68, offset: 604},
+											pos:  position{line: 33, col: 68, offset: 647},
 											name: "HIDDEN_RULE",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 33, col: 82, offset: 618},
+											pos:  position{line: 33, col: 82, offset: 661},
 											name: "ONLY_RULE",
 										},
 									},
@@ -314,18 +342,18 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 33, col: 94, offset: 630},
+							pos:   position{line: 33, col: 94, offset: 673},
 							label: "fl",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 33, col: 98, offset: 634},
+								pos: position{line: 33, col: 98, offset: 677},
 								expr: &ruleRefExpr{
-									pos:  position{line: 33, col: 98, offset: 634},
+									pos:  position{line: 33, col: 98, offset: 677},
 									name: "FLAGS_RULE",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 33, col: 111, offset: 647},
+							pos:  position{line: 33, col: 111, offset: 690},
 							name: "WS",
 						},
 					},
@@ -334,51 +362,51 @@ var g = &grammar{
 		},
 		{
 			name: "ACTION_RULE",
-			pos:  position{line: 37, col: 1, offset: 693},
+			pos:  position{line: 37, col: 1, offset: 736},
 			expr: &actionExpr{
-				pos: position{line: 37, col: 16, offset: 708},
+				pos: position{line: 37, col: 16, offset: 751},
 				run: (*parser).callonACTION_RULE1,
 				expr: &seqExpr{
-					pos: position{line: 37, col: 16, offset: 708},
+					pos: position{line: 37, col: 16, offset: 751},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 37, col: 16, offset: 708},
+							pos:   position{line: 37, col: 16, offset: 751},
 							label: "m",
 							expr: &ruleRefExpr{
-								pos:  position{line: 37, col: 19, offset: 711},
+								pos:  position{line: 37, col: 19, offset: 754},
 								name: "METHOD",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 37, col: 27, offset: 719},
+							pos:  position{line: 37, col: 27, offset: 762},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 37, col: 35, offset: 727},
+							pos:   position{line: 37, col: 35, offset: 770},
 							label: "r",
 							expr: &ruleRefExpr{
-								pos:  position{line: 37, col: 38, offset: 730},
+								pos:  position{line: 37, col: 38, offset: 773},
 								name: "IDENT",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 37, col: 45, offset: 737},
+							pos:   position{line: 37, col: 45, offset: 780},
 							label: "a",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 37, col: 48, offset: 740},
+								pos: position{line: 37, col: 48, offset: 783},
 								expr: &ruleRefExpr{
-									pos:  position{line: 37, col: 48, offset: 740},
+									pos:  position{line: 37, col: 48, offset: 783},
 									name: "ALIAS",
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 37, col: 56, offset: 748},
+							pos:   position{line: 37, col: 56, offset: 791},
 							label: "i",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 37, col: 59, offset: 751},
+								pos: position{line: 37, col: 59, offset: 794},
 								expr: &ruleRefExpr{
-									pos:  position{line: 37, col: 59, offset: 751},
+									pos:  position{line: 37, col: 59, offset: 794},
 									name: "IN",
 								},
 							},
@@ -389,39 +417,39 @@ var g = &grammar{
 		},
 		{
 			name: "METHOD",
-			pos:  position{line: 41, col: 1, offset: 795},
+			pos:  position{line: 41, col: 1, offset: 838},
 			expr: &actionExpr{
-				pos: position{line: 41, col: 11, offset: 805},
+				pos: position{line: 41, col: 11, offset: 848},
 				run: (*parser).callonMETHOD1,
 				expr: &choiceExpr{
-					pos: position{line: 41, col: 12, offset: 806},
+					pos: position{line: 41, col: 12, offset: 849},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 41, col: 12, offset: 806},
+							pos:        position{line: 41, col: 12, offset: 849},
 							val:        "from",
 							ignoreCase: false,
 							want:       "\"from\"",
 						},
 						&litMatcher{
-							pos:        position{line: 41, col: 21, offset: 815},
+							pos:        position{line: 41, col: 21, offset: 858},
 							val:        "to",
 							ignoreCase: false,
 							want:       "\"to\"",
 						},
 						&litMatcher{
-							pos:        position{line: 41, col: 28, offset: 822},
+							pos:        position{line: 41, col: 28, offset: 865},
 							val:        "into",
 							ignoreCase: false,
 							want:       "\"into\"",
 						},
 						&litMatcher{
-							pos:        position{line: 41, col: 36, offset: 830},
+							pos:        position{line: 41, col: 36, offset: 873},
 							val:        "update",
 							ignoreCase: false,
 							want:       "\"update\"",
 						},
 						&litMatcher{
-							pos:        position{line: 41, col: 47, offset: 841},
+							pos:        position{line: 41, col: 47, offset: 884},
 							val:        "delete",
 							ignoreCase: false,
 							want:       "\"delete\"",
@@ -432,32 +460,32 @@ var g = &grammar{
 		},
 		{
 			name: "ALIAS",
-			pos:  position{line: 45, col: 1, offset: 882},
+			pos:  position{line: 45, col: 1, offset: 925},
 			expr: &actionExpr{
-				pos: position{line: 45, col: 10, offset: 891},
+				pos: position{line: 45, col: 10, offset: 934},
 				run: (*parser).callonALIAS1,
 				expr: &seqExpr{
-					pos: position{line: 45, col: 10, offset: 891},
+					pos: position{line: 45, col: 10, offset: 934},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 45, col: 10, offset: 891},
+							pos:  position{line: 45, col: 10, offset: 934},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 45, col: 18, offset: 899},
+							pos:        position{line: 45, col: 18, offset: 942},
 							val:        "as",
 							ignoreCase: false,
 							want:       "\"as\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 45, col: 23, offset: 904},
+							pos:  position{line: 45, col: 23, offset: 947},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 45, col: 31, offset: 912},
+							pos:   position{line: 45, col: 31, offset: 955},
 							label: "a",
 							expr: &ruleRefExpr{
-								pos:  position{line: 45, col: 34, offset: 915},
+								pos:  position{line: 45, col: 34, offset: 958},
 								name: "IDENT",
 							},
 						},
@@ -467,32 +495,32 @@ var g = &grammar{
 		},
 		{
 			name: "IN",
-			pos:  position{line: 49, col: 1, offset: 942},
+			pos:  position{line: 49, col: 1, offset: 985},
 			expr: &actionExpr{
-				pos: position{line: 49, col: 7, offset: 948},
+				pos: position{line: 49, col: 7, offset: 991},
 				run: (*parser).callonIN1,
 				expr: &seqExpr{
-					pos: position{line: 49, col: 7, offset: 948},
+					pos: position{line: 49, col: 7, offset: 991},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 49, col: 7, offset: 948},
+							pos:  position{line: 49, col: 7, offset: 991},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 49, col: 15, offset: 956},
+							pos:        position{line: 49, col: 15, offset: 999},
 							val:        "in",
 							ignoreCase: false,
 							want:       "\"in\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 49, col: 20, offset: 961},
+							pos:  position{line: 49, col: 20, offset: 1004},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 49, col: 28, offset: 969},
+							pos:   position{line: 49, col: 28, offset: 1012},
 							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 49, col: 31, offset: 972},
+								pos:  position{line: 49, col: 31, offset: 1015},
 								name: "IDENT_WITH_DOT",
 							},
 						},
@@ -502,36 +530,36 @@ var g = &grammar{
 		},
 		{
 			name: "MODIFIER_RULE",
-			pos:  position{line: 53, col: 1, offset: 1010},
+			pos:  position{line: 53, col: 1, offset: 1053},
 			expr: &actionExpr{
-				pos: position{line: 53, col: 18, offset: 1027},
+				pos: position{line: 53, col: 18, offset: 1070},
 				run: (*parser).callonMODIFIER_RULE1,
 				expr: &labeledExpr{
-					pos:   position{line: 53, col: 18, offset: 1027},
+					pos:   position{line: 53, col: 18, offset: 1070},
 					label: "m",
 					expr: &oneOrMoreExpr{
-						pos: position{line: 53, col: 20, offset: 1029},
+						pos: position{line: 53, col: 20, offset: 1072},
 						expr: &choiceExpr{
-							pos: position{line: 53, col: 21, offset: 1030},
+							pos: position{line: 53, col: 21, offset: 1073},
 							alternatives: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 53, col: 21, offset: 1030},
+									pos:  position{line: 53, col: 21, offset: 1073},
 									name: "HEADERS",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 53, col: 31, offset: 1040},
+									pos:  position{line: 53, col: 31, offset: 1083},
 									name: "TIMEOUT",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 53, col: 41, offset: 1050},
+									pos:  position{line: 53, col: 41, offset: 1093},
 									name: "MAX_AGE",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 53, col: 51, offset: 1060},
+									pos:  position{line: 53, col: 51, offset: 1103},
 									name: "S_MAX_AGE",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 53, col: 63, offset: 1072},
+									pos:  position{line: 53, col: 63, offset: 1115},
 									name: "DEPENDS_ON",
 								},
 							},
@@ -542,45 +570,45 @@ var g = &grammar{
 		},
 		{
 			name: "WITH_RULE",
-			pos:  position{line: 57, col: 1, offset: 1105},
+			pos:  position{line: 57, col: 1, offset: 1148},
 			expr: &actionExpr{
-				pos: position{line: 57, col: 14, offset: 1118},
+				pos: position{line: 57, col: 14, offset: 1161},
 				run: (*parser).callonWITH_RULE1,
 				expr: &seqExpr{
-					pos: position{line: 57, col: 14, offset: 1118},
+					pos: position{line: 57, col: 14, offset: 1161},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 57, col: 14, offset: 1118},
+							pos:  position{line: 57, col: 14, offset: 1161},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 57, col: 22, offset: 1126},
+							pos:        position{line: 57, col: 22, offset: 1169},
 							val:        "with",
 							ignoreCase: false,
 							want:       "\"with\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 57, col: 29, offset: 1133},
+							pos:  position{line: 57, col: 29, offset: 1176},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 57, col: 37, offset: 1141},
+							pos:   position{line: 57, col: 37, offset: 1184},
 							label: "pb",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 57, col: 40, offset: 1144},
+								pos: position{line: 57, col: 40, offset: 1187},
 								expr: &ruleRefExpr{
-									pos:  position{line: 57, col: 40, offset: 1144},
+									pos:  position{line: 57, col: 40, offset: 1187},
 									name: "PARAMETER_BODY",
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 57, col: 56, offset: 1160},
+							pos:   position{line: 57, col: 56, offset: 1203},
 							label: "kvs",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 57, col: 60, offset: 1164},
+								pos: position{line: 57, col: 60, offset: 1207},
 								expr: &ruleRefExpr{
-									pos:  position{line: 57, col: 60, offset: 1164},
+									pos:  position{line: 57, col: 60, offset: 1207},
 									name: "KEY_VALUE_LIST",
 								},
 							},
@@ -591,51 +619,51 @@ var g = &grammar{
 		},
 		{
 			name: "PARAMETER_BODY",
-			pos:  position{line: 61, col: 1, offset: 1210},
+			pos:  position{line: 61, col: 1, offset: 1253},
 			expr: &actionExpr{
-				pos: position{line: 61, col: 19, offset: 1228},
+				pos: position{line: 61, col: 19, offset: 1271},
 				run: (*parser).callonPARAMETER_BODY1,
 				expr: &seqExpr{
-					pos: position{line: 61, col: 19, offset: 1228},
+					pos: position{line: 61, col: 19, offset: 1271},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 61, col: 19, offset: 1228},
+							pos:        position{line: 61, col: 19, offset: 1271},
 							val:        "$",
 							ignoreCase: false,
 							want:       "\"$\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 61, col: 23, offset: 1232},
+							pos:   position{line: 61, col: 23, offset: 1275},
 							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 61, col: 26, offset: 1235},
+								pos:  position{line: 61, col: 26, offset: 1278},
 								name: "IDENT",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 61, col: 33, offset: 1242},
+							pos:   position{line: 61, col: 33, offset: 1285},
 							label: "fn",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 61, col: 36, offset: 1245},
+								pos: position{line: 61, col: 36, offset: 1288},
 								expr: &ruleRefExpr{
-									pos:  position{line: 61, col: 37, offset: 1246},
+									pos:  position{line: 61, col: 37, offset: 1289},
 									name: "APPLY_FN",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 61, col: 48, offset: 1257},
+							pos:  position{line: 61, col: 48, offset: 1300},
 							name: "WS",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 61, col: 51, offset: 1260},
+							pos: position{line: 61, col: 51, offset: 1303},
 							expr: &ruleRefExpr{
-								pos:  position{line: 61, col: 51, offset: 1260},
+								pos:  position{line: 61, col: 51, offset: 1303},
 								name: "LS",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 61, col: 55, offset: 1264},
+							pos:  position{line: 61, col: 55, offset: 1307},
 							name: "WS",
 						},
 					},
@@ -644,58 +672,58 @@ var g = &grammar{
 		},
 		{
 			name: "KEY_VALUE_LIST",
-			pos:  position{line: 65, col: 1, offset: 1304},
+			pos:  position{line: 65, col: 1, offset: 1347},
 			expr: &actionExpr{
-				pos: position{line: 65, col: 19, offset: 1322},
+				pos: position{line: 65, col: 19, offset: 1365},
 				run: (*parser).callonKEY_VALUE_LIST1,
 				expr: &seqExpr{
-					pos: position{line: 65, col: 19, offset: 1322},
+					pos: position{line: 65, col: 19, offset: 1365},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 65, col: 19, offset: 1322},
+							pos:   position{line: 65, col: 19, offset: 1365},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 65, col: 25, offset: 1328},
+								pos:  position{line: 65, col: 25, offset: 1371},
 								name: "KEY_VALUE",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 65, col: 35, offset: 1338},
+							pos:   position{line: 65, col: 35, offset: 1381},
 							label: "others",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 65, col: 42, offset: 1345},
+								pos: position{line: 65, col: 42, offset: 1388},
 								expr: &seqExpr{
-									pos: position{line: 65, col: 43, offset: 1346},
+									pos: position{line: 65, col: 43, offset: 1389},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 65, col: 43, offset: 1346},
+											pos:  position{line: 65, col: 43, offset: 1389},
 											name: "WS",
 										},
 										&choiceExpr{
-											pos: position{line: 65, col: 47, offset: 1350},
+											pos: position{line: 65, col: 47, offset: 1393},
 											alternatives: []interface{}{
 												&seqExpr{
-													pos: position{line: 65, col: 47, offset: 1350},
+													pos: position{line: 65, col: 47, offset: 1393},
 													exprs: []interface{}{
 														&ruleRefExpr{
-															pos:  position{line: 65, col: 47, offset: 1350},
+															pos:  position{line: 65, col: 47, offset: 1393},
 															name: "LS",
 														},
 														&zeroOrMoreExpr{
-															pos: position{line: 65, col: 50, offset: 1353},
+															pos: position{line: 65, col: 50, offset: 1396},
 															expr: &seqExpr{
-																pos: position{line: 65, col: 51, offset: 1354},
+																pos: position{line: 65, col: 51, offset: 1397},
 																exprs: []interface{}{
 																	&ruleRefExpr{
-																		pos:  position{line: 65, col: 51, offset: 1354},
+																		pos:  position{line: 65, col: 51, offset: 1397},
 																		name: "WS",
 																	},
 																	&ruleRefExpr{
-																		pos:  position{line: 65, col: 54, offset: 1357},
+																		pos:  position{line: 65, col: 54, offset: 1400},
 																		name: "NL",
 																	},
 																	&ruleRefExpr{
-																		pos:  position{line: 65, col: 57, offset: 1360},
+																		pos:  position{line: 65, col: 57, offset: 1403},
 																		name: "WS",
 																	},
 																},
@@ -704,17 +732,17 @@ var g = &grammar{
 													},
 												},
 												&ruleRefExpr{
-													pos:  position{line: 65, col: 64, offset: 1367},
+													pos:  position{line: 65, col: 64, offset: 1410},
 													name: "LS",
 												},
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 65, col: 68, offset: 1371},
+											pos:  position{line: 65, col: 68, offset: 1414},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 65, col: 71, offset: 1374},
+											pos:  position{line: 65, col: 71, offset: 1417},
 											name: "KEY_VALUE",
 										},
 									},
@@ -727,50 +755,50 @@ var g = &grammar{
 		},
 		{
 			name: "KEY_VALUE",
-			pos:  position{line: 69, col: 1, offset: 1430},
+			pos:  position{line: 69, col: 1, offset: 1473},
 			expr: &actionExpr{
-				pos: position{line: 69, col: 14, offset: 1443},
+				pos: position{line: 69, col: 14, offset: 1486},
 				run: (*parser).callonKEY_VALUE1,
 				expr: &seqExpr{
-					pos: position{line: 69, col: 14, offset: 1443},
+					pos: position{line: 69, col: 14, offset: 1486},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 69, col: 14, offset: 1443},
+							pos:   position{line: 69, col: 14, offset: 1486},
 							label: "k",
 							expr: &ruleRefExpr{
-								pos:  position{line: 69, col: 17, offset: 1446},
+								pos:  position{line: 69, col: 17, offset: 1489},
 								name: "IDENT_WITH_DOT",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 69, col: 33, offset: 1462},
+							pos:  position{line: 69, col: 33, offset: 1505},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 69, col: 36, offset: 1465},
+							pos:        position{line: 69, col: 36, offset: 1508},
 							val:        "=",
 							ignoreCase: false,
 							want:       "\"=\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 69, col: 40, offset: 1469},
+							pos:  position{line: 69, col: 40, offset: 1512},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 69, col: 43, offset: 1472},
+							pos:   position{line: 69, col: 43, offset: 1515},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 69, col: 46, offset: 1475},
+								pos:  position{line: 69, col: 46, offset: 1518},
 								name: "VALUE",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 69, col: 53, offset: 1482},
+							pos:   position{line: 69, col: 53, offset: 1525},
 							label: "fn",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 69, col: 56, offset: 1485},
+								pos: position{line: 69, col: 56, offset: 1528},
 								expr: &ruleRefExpr{
-									pos:  position{line: 69, col: 57, offset: 1486},
+									pos:  position{line: 69, col: 57, offset: 1529},
 									name: "APPLY_FN",
 								},
 							},
@@ -781,35 +809,35 @@ var g = &grammar{
 		},
 		{
 			name: "APPLY_FN",
-			pos:  position{line: 73, col: 1, offset: 1532},
+			pos:  position{line: 73, col: 1, offset: 1575},
 			expr: &actionExpr{
-				pos: position{line: 73, col: 13, offset: 1544},
+				pos: position{line: 73, col: 13, offset: 1587},
 				run: (*parser).callonAPPLY_FN1,
 				expr: &seqExpr{
-					pos: position{line: 73, col: 13, offset: 1544},
+					pos: position{line: 73, col: 13, offset: 1587},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 73, col: 13, offset: 1544},
+							pos:  position{line: 73, col: 13, offset: 1587},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 73, col: 16, offset: 1547},
+							pos:        position{line: 73, col: 16, offset: 1590},
 							val:        "->",
 							ignoreCase: false,
 							want:       "\"->\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 73, col: 21, offset: 1552},
+							pos: position{line: 73, col: 21, offset: 1595},
 							expr: &ruleRefExpr{
-								pos:  position{line: 73, col: 21, offset: 1552},
+								pos:  position{line: 73, col: 21, offset: 1595},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 73, col: 25, offset: 1556},
+							pos:   position{line: 73, col: 25, offset: 1599},
 							label: "fn",
 							expr: &ruleRefExpr{
-								pos:  position{line: 73, col: 29, offset: 1560},
+								pos:  position{line: 73, col: 29, offset: 1603},
 								name: "FUNCTION",
 							},
 						},
@@ -819,51 +847,63 @@ var g = &grammar{
 		},
 		{
 			name: "FUNCTION",
-			pos:  position{line: 77, col: 1, offset: 1591},
+			pos:  position{line: 77, col: 1, offset: 1634},
 			expr: &actionExpr{
-				pos: position{line: 77, col: 13, offset: 1603},
+				pos: position{line: 77, col: 13, offset: 1646},
 				run: (*parser).callonFUNCTION1,
 				expr: &choiceExpr{
-					pos: position{line: 77, col: 14, offset: 1604},
+					pos: position{line: 77, col: 14, offset: 1647},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 77, col: 14, offset: 1604},
+							pos:        position{line: 77, col: 14, offset: 1647},
 							val:        "no-multiplex",
 							ignoreCase: false,
 							want:       "\"no-multiplex\"",
 						},
 						&litMatcher{
-							pos:        position{line: 77, col: 31, offset: 1621},
+							pos:        position{line: 77, col: 31, offset: 1664},
 							val:        "no-explode",
 							ignoreCase: false,
 							want:       "\"no-explode\"",
 						},
 						&litMatcher{
-							pos:        position{line: 77, col: 46, offset: 1636},
+							pos:        position{line: 77, col: 46, offset: 1679},
 							val:        "base64",
 							ignoreCase: false,
 							want:       "\"base64\"",
 						},
 						&litMatcher{
-							pos:        position{line: 77, col: 57, offset: 1647},
+							pos:        position{line: 77, col: 57, offset: 1690},
 							val:        "json",
 							ignoreCase: false,
 							want:       "\"json\"",
 						},
 						&litMatcher{
-							pos:        position{line: 77, col: 65, offset: 1655},
+							pos:        position{line: 77, col: 65, offset: 1698},
 							val:        "as-body",
 							ignoreCase: false,
 							want:       "\"as-body\"",
 						},
 						&litMatcher{
-							pos:        position{line: 77, col: 77, offset: 1667},
+							pos:        position{line: 77, col: 77, offset: 1710},
 							val:        "as-query",
 							ignoreCase: false,
 							want:       "\"as-query\"",
 						},
 						&litMatcher{
-							pos:        position{line: 77, col: 90, offset: 1680},
+							pos:        position{line: 77, col: 90, offset: 1723},
+							val:        "as-form",
+							ignoreCase: false,
+							want:       "\"as-form\"",
+						},
+						&litMatcher{
+							pos:        position{line: 77, col: 102, offset: 1735},
+							val:        "as-xml",
+							ignoreCase: false,
+							want:       "\"as-xml\"",
+						},
+						&litMatcher{
+							pos:        position{line: 77, col: 113, offset: 1746},
 							val:        "flatten",
 							ignoreCase: false,
 							want:       "\"flatten\"",
@@ -874,30 +914,30 @@ var g = &grammar{
 		},
 		{
 			name: "VALUE",
-			pos:  position{line: 81, col: 1, offset: 1722},
+			pos:  position{line: 81, col: 1, offset: 1788},
 			expr: &actionExpr{
-				pos: position{line: 81, col: 10, offset: 1731},
+				pos: position{line: 81, col: 10, offset: 1797},
 				run: (*parser).callonVALUE1,
 				expr: &labeledExpr{
-					pos:   position{line: 81, col: 10, offset: 1731},
+					pos:   position{line: 81, col: 10, offset: 1797},
 					label: "v",
 					expr: &choiceExpr{
-						pos: position{line: 81, col: 13, offset: 1734},
+						pos: position{line: 81, col: 13, offset: 1800},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 81, col: 13, offset: 1734},
+								pos:  position{line: 81, col: 13, offset: 1800},
 								name: "LIST",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 81, col: 20, offset: 1741},
+								pos:  position{line: 81, col: 20, offset: 1807},
 								name: "OBJECT",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 81, col: 29, offset: 1750},
+								pos:  position{line: 81, col: 29, offset: 1816},
 								name: "VARIABLE",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 81, col: 40, offset: 1761},
+								pos:  position{line: 81, col: 40, offset: 1827},
 								name: "PRIMITIVE",
 							},
 						},
@@ -907,22 +947,22 @@ var g = &grammar{
 		},
 		{
 			name: "LIST",
-			pos:  position{line: 85, col: 1, offset: 1797},
+			pos:  position{line: 85, col: 1, offset: 1863},
 			expr: &actionExpr{
-				pos: position{line: 85, col: 9, offset: 1805},
+				pos: position{line: 85, col: 9, offset: 1871},
 				run: (*parser).callonLIST1,
 				expr: &labeledExpr{
-					pos:   position{line: 85, col: 9, offset: 1805},
+					pos:   position{line: 85, col: 9, offset: 1871},
 					label: "l",
 					expr: &choiceExpr{
-						pos: position{line: 85, col: 12, offset: 1808},
+						pos: position{line: 85, col: 12, offset: 1874},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 85, col: 12, offset: 1808},
+								pos:  position{line: 85, col: 12, offset: 1874},
 								name: "EMPTY_LIST",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 85, col: 25, offset: 1821},
+								pos:  position{line: 85, col: 25, offset: 1887},
 								name: "POPULATED_LIST",
 							},
 						},
@@ -932,25 +972,25 @@ var g = &grammar{
 		},
 		{
 			name: "EMPTY_LIST",
-			pos:  position{line: 89, col: 1, offset: 1857},
+			pos:  position{line: 89, col: 1, offset: 1923},
 			expr: &actionExpr{
-				pos: position{line: 89, col: 15, offset: 1871},
+				pos: position{line: 89, col: 15, offset: 1937},
 				run: (*parser).callonEMPTY_LIST1,
 				expr: &seqExpr{
-					pos: position{line: 89, col: 15, offset: 1871},
+					pos: position{line: 89, col: 15, offset: 1937},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 89, col: 15, offset: 1871},
+							pos:        position{line: 89, col: 15, offset: 1937},
 							val:        "[",
 							ignoreCase: false,
 							want:       "\"[\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 89, col: 19, offset: 1875},
+							pos:  position{line: 89, col: 19, offset: 1941},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 89, col: 22, offset: 1878},
+							pos:        position{line: 89, col: 22, offset: 1944},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
@@ -961,56 +1001,56 @@ var g = &grammar{
 		},
 		{
 			name: "POPULATED_LIST",
-			pos:  position{line: 93, col: 1, offset: 1910},
+			pos:  position{line: 93, col: 1, offset: 1976},
 			expr: &actionExpr{
-				pos: position{line: 93, col: 19, offset: 1928},
+				pos: position{line: 93, col: 19, offset: 1994},
 				run: (*parser).callonPOPULATED_LIST1,
 				expr: &seqExpr{
-					pos: position{line: 93, col: 19, offset: 1928},
+					pos: position{line: 93, col: 19, offset: 1994},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 93, col: 19, offset: 1928},
+							pos:        position{line: 93, col: 19, offset: 1994},
 							val:        "[",
 							ignoreCase: false,
 							want:       "\"[\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 93, col: 23, offset: 1932},
+							pos:  position{line: 93, col: 23, offset: 1998},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 93, col: 26, offset: 1935},
+							pos:   position{line: 93, col: 26, offset: 2001},
 							label: "i",
 							expr: &ruleRefExpr{
-								pos:  position{line: 93, col: 28, offset: 1937},
+								pos:  position{line: 93, col: 28, offset: 2003},
 								name: "VALUE",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 93, col: 34, offset: 1943},
+							pos:   position{line: 93, col: 34, offset: 2009},
 							label: "ii",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 93, col: 37, offset: 1946},
+								pos: position{line: 93, col: 37, offset: 2012},
 								expr: &seqExpr{
-									pos: position{line: 93, col: 38, offset: 1947},
+									pos: position{line: 93, col: 38, offset: 2013},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 93, col: 38, offset: 1947},
+											pos:  position{line: 93, col: 38, offset: 2013},
 											name: "WS",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 93, col: 41, offset: 1950},
+											pos: position{line: 93, col: 41, offset: 2016},
 											expr: &ruleRefExpr{
-												pos:  position{line: 93, col: 41, offset: 1950},
+												pos:  position{line: 93, col: 41, offset: 2016},
 												name: "LS",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 93, col: 45, offset: 1954},
+											pos:  position{line: 93, col: 45, offset: 2020},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 93, col: 48, offset: 1957},
+											pos:  position{line: 93, col: 48, offset: 2023},
 											name: "VALUE",
 										},
 									},
@@ -1018,11 +1058,11 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 93, col: 56, offset: 1965},
+							pos:  position{line: 93, col: 56, offset: 2031},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 93, col: 59, offset: 1968},
+							pos:        position{line: 93, col: 59, offset: 2034},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
@@ -1033,22 +1073,22 @@ var g = &grammar{
 		},
 		{
 			name: "OBJECT",
-			pos:  position{line: 97, col: 1, offset: 2000},
+			pos:  position{line: 97, col: 1, offset: 2066},
 			expr: &actionExpr{
-				pos: position{line: 97, col: 11, offset: 2010},
+				pos: position{line: 97, col: 11, offset: 2076},
 				run: (*parser).callonOBJECT1,
 				expr: &labeledExpr{
-					pos:   position{line: 97, col: 11, offset: 2010},
+					pos:   position{line: 97, col: 11, offset: 2076},
 					label: "o",
 					expr: &choiceExpr{
-						pos: position{line: 97, col: 14, offset: 2013},
+						pos: position{line: 97, col: 14, offset: 2079},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 97, col: 14, offset: 2013},
+								pos:  position{line: 97, col: 14, offset: 2079},
 								name: "EMPTY_OBJ",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 97, col: 26, offset: 2025},
+								pos:  position{line: 97, col: 26, offset: 2091},
 								name: "POPULATED_OBJ",
 							},
 						},
@@ -1058,36 +1098,36 @@ var g = &grammar{
 		},
 		{
 			name: "EMPTY_OBJ",
-			pos:  position{line: 101, col: 1, offset: 2060},
+			pos:  position{line: 101, col: 1, offset: 2126},
 			expr: &actionExpr{
-				pos: position{line: 101, col: 14, offset: 2073},
+				pos: position{line: 101, col: 14, offset: 2139},
 				run: (*parser).callonEMPTY_OBJ1,
 				expr: &seqExpr{
-					pos: position{line: 101, col: 14, offset: 2073},
+					pos: position{line: 101, col: 14, offset: 2139},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 101, col: 14, offset: 2073},
+							pos:        position{line: 101, col: 14, offset: 2139},
 							val:        "{",
 							ignoreCase: false,
 							want:       "\"{\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 101, col: 18, offset: 2077},
+							pos:  position{line: 101, col: 18, offset: 2143},
 							name: "WS",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 101, col: 21, offset: 2080},
+							pos: position{line: 101, col: 21, offset: 2146},
 							expr: &ruleRefExpr{
-								pos:  position{line: 101, col: 21, offset: 2080},
+								pos:  position{line: 101, col: 21, offset: 2146},
 								name: "NL",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 101, col: 25, offset: 2084},
+							pos:  position{line: 101, col: 25, offset: 2150},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 101, col: 28, offset: 2087},
+							pos:        position{line: 101, col: 28, offset: 2153},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
@@ -1098,77 +1138,77 @@ var g = &grammar{
 		},
 		{
 			name: "POPULATED_OBJ",
-			pos:  position{line: 105, col: 1, offset: 2121},
+			pos:  position{line: 105, col: 1, offset: 2187},
 			expr: &actionExpr{
-				pos: position{line: 105, col: 18, offset: 2138},
+				pos: position{line: 105, col: 18, offset: 2204},
 				run: (*parser).callonPOPULATED_OBJ1,
 				expr: &seqExpr{
-					pos: position{line: 105, col: 18, offset: 2138},
+					pos: position{line: 105, col: 18, offset: 2204},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 105, col: 18, offset: 2138},
+							pos:        position{line: 105, col: 18, offset: 2204},
 							val:        "{",
 							ignoreCase: false,
 							want:       "\"{\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 105, col: 22, offset: 2142},
+							pos:  position{line: 105, col: 22, offset: 2208},
 							name: "WS",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 105, col: 25, offset: 2145},
+							pos: position{line: 105, col: 25, offset: 2211},
 							expr: &ruleRefExpr{
-								pos:  position{line: 105, col: 25, offset: 2145},
+								pos:  position{line: 105, col: 25, offset: 2211},
 								name: "NL",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 105, col: 29, offset: 2149},
+							pos:  position{line: 105, col: 29, offset: 2215},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 105, col: 32, offset: 2152},
+							pos:   position{line: 105, col: 32, offset: 2218},
 							label: "oe",
 							expr: &ruleRefExpr{
-								pos:  position{line: 105, col: 36, offset: 2156},
+								pos:  position{line: 105, col: 36, offset: 2222},
 								name: "OBJ_ENTRY",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 105, col: 47, offset: 2167},
+							pos:   position{line: 105, col: 47, offset: 2233},
 							label: "oes",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 105, col: 51, offset: 2171},
+								pos: position{line: 105, col: 51, offset: 2237},
 								expr: &seqExpr{
-									pos: position{line: 105, col: 52, offset: 2172},
+									pos: position{line: 105, col: 52, offset: 2238},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 105, col: 52, offset: 2172},
+											pos:  position{line: 105, col: 52, offset: 2238},
 											name: "WS",
 										},
 										&litMatcher{
-											pos:        position{line: 105, col: 55, offset: 2175},
+											pos:        position{line: 105, col: 55, offset: 2241},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 105, col: 59, offset: 2179},
+											pos:  position{line: 105, col: 59, offset: 2245},
 											name: "WS",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 105, col: 62, offset: 2182},
+											pos: position{line: 105, col: 62, offset: 2248},
 											expr: &ruleRefExpr{
-												pos:  position{line: 105, col: 62, offset: 2182},
+												pos:  position{line: 105, col: 62, offset: 2248},
 												name: "NL",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 105, col: 66, offset: 2186},
+											pos:  position{line: 105, col: 66, offset: 2252},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 105, col: 69, offset: 2189},
+											pos:  position{line: 105, col: 69, offset: 2255},
 											name: "OBJ_ENTRY",
 										},
 									},
@@ -1176,22 +1216,22 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 105, col: 81, offset: 2201},
+							pos:  position{line: 105, col: 81, offset: 2267},
 							name: "WS",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 105, col: 84, offset: 2204},
+							pos: position{line: 105, col: 84, offset: 2270},
 							expr: &ruleRefExpr{
-								pos:  position{line: 105, col: 84, offset: 2204},
+								pos:  position{line: 105, col: 84, offset: 2270},
 								name: "NL",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 105, col: 88, offset: 2208},
+							pos:  position{line: 105, col: 88, offset: 2274},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 105, col: 91, offset: 2211},
+							pos:        position{line: 105, col: 91, offset: 2277},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
@@ -1202,49 +1242,49 @@ var g = &grammar{
 		},
 		{
 			name: "OBJ_ENTRY",
-			pos:  position{line: 109, col: 1, offset: 2256},
+			pos:  position{line: 109, col: 1, offset: 2322},
 			expr: &actionExpr{
-				pos: position{line: 109, col: 14, offset: 2269},
+				pos: position{line: 109, col: 14, offset: 2335},
 				run: (*parser).callonOBJ_ENTRY1,
 				expr: &seqExpr{
-					pos: position{line: 109, col: 14, offset: 2269},
+					pos: position{line: 109, col: 14, offset: 2335},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 109, col: 14, offset: 2269},
+							pos:   position{line: 109, col: 14, offset: 2335},
 							label: "k",
 							expr: &choiceExpr{
-								pos: position{line: 109, col: 17, offset: 2272},
+								pos: position{line: 109, col: 17, offset: 2338},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 109, col: 17, offset: 2272},
+										pos:  position{line: 109, col: 17, offset: 2338},
 										name: "String",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 109, col: 26, offset: 2281},
+										pos:  position{line: 109, col: 26, offset: 2347},
 										name: "IDENT_WITHOUT_COLLON",
 									},
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 109, col: 48, offset: 2303},
+							pos:  position{line: 109, col: 48, offset: 2369},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 109, col: 51, offset: 2306},
+							pos:        position{line: 109, col: 51, offset: 2372},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 109, col: 55, offset: 2310},
+							pos:  position{line: 109, col: 55, offset: 2376},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 109, col: 58, offset: 2313},
+							pos:   position{line: 109, col: 58, offset: 2379},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 109, col: 61, offset: 2316},
+								pos:  position{line: 109, col: 61, offset: 2382},
 								name: "VALUE",
 							},
 						},
@@ -1254,38 +1294,38 @@ var g = &grammar{
 		},
 		{
 			name: "PRIMITIVE",
-			pos:  position{line: 113, col: 1, offset: 2357},
+			pos:  position{line: 113, col: 1, offset: 2423},
 			expr: &actionExpr{
-				pos: position{line: 113, col: 14, offset: 2370},
+				pos: position{line: 113, col: 14, offset: 2436},
 				run: (*parser).callonPRIMITIVE1,
 				expr: &labeledExpr{
-					pos:   position{line: 113, col: 14, offset: 2370},
+					pos:   position{line: 113, col: 14, offset: 2436},
 					label: "p",
 					expr: &choiceExpr{
-						pos: position{line: 113, col: 17, offset: 2373},
+						pos: position{line: 113, col: 17, offset: 2439},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 113, col: 17, offset: 2373},
+								pos:  position{line: 113, col: 17, offset: 2439},
 								name: "Null",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 113, col: 24, offset: 2380},
+								pos:  position{line: 113, col: 24, offset: 2446},
 								name: "Boolean",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 113, col: 34, offset: 2390},
+								pos:  position{line: 113, col: 34, offset: 2456},
 								name: "String",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 113, col: 43, offset: 2399},
+								pos:  position{line: 113, col: 43, offset: 2465},
 								name: "Float",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 113, col: 51, offset: 2407},
+								pos:  position{line: 113, col: 51, offset: 2473},
 								name: "Integer",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 113, col: 61, offset: 2417},
+								pos:  position{line: 113, col: 61, offset: 2483},
 								name: "CHAIN",
 							},
 						},
@@ -1295,65 +1335,65 @@ var g = &grammar{
 		},
 		{
 			name: "ONLY_RULE",
-			pos:  position{line: 119, col: 1, offset: 2455},
+			pos:  position{line: 119, col: 1, offset: 2521},
 			expr: &actionExpr{
-				pos: position{line: 119, col: 14, offset: 2468},
+				pos: position{line: 119, col: 14, offset: 2534},
 				run: (*parser).callonONLY_RULE1,
 				expr: &seqExpr{
-					pos: position{line: 119, col: 14, offset: 2468},
+					pos: position{line: 119, col: 14, offset: 2534},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 119, col: 14, offset: 2468},
+							pos:  position{line: 119, col: 14, offset: 2534},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 119, col: 22, offset: 2476},
+							pos:        position{line: 119, col: 22, offset: 2542},
 							val:        "only",
 							ignoreCase: false,
 							want:       "\"only\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 119, col: 29, offset: 2483},
+							pos:  position{line: 119, col: 29, offset: 2549},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 119, col: 37, offset: 2491},
+							pos:   position{line: 119, col: 37, offset: 2557},
 							label: "f",
 							expr: &ruleRefExpr{
-								pos:  position{line: 119, col: 40, offset: 2494},
+								pos:  position{line: 119, col: 40, offset: 2560},
 								name: "FILTER",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 119, col: 48, offset: 2502},
+							pos:   position{line: 119, col: 48, offset: 2568},
 							label: "fs",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 119, col: 51, offset: 2505},
+								pos: position{line: 119, col: 51, offset: 2571},
 								expr: &seqExpr{
-									pos: position{line: 119, col: 52, offset: 2506},
+									pos: position{line: 119, col: 52, offset: 2572},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 119, col: 52, offset: 2506},
+											pos:  position{line: 119, col: 52, offset: 2572},
 											name: "WS",
 										},
 										&notExpr{
-											pos: position{line: 119, col: 55, offset: 2509},
+											pos: position{line: 119, col: 55, offset: 2575},
 											expr: &choiceExpr{
-												pos: position{line: 119, col: 57, offset: 2511},
+												pos: position{line: 119, col: 57, offset: 2577},
 												alternatives: []interface{}{
 													&ruleRefExpr{
-														pos:  position{line: 119, col: 57, offset: 2511},
+														pos:  position{line: 119, col: 57, offset: 2577},
 														name: "FLAGS_RULE",
 													},
 													&seqExpr{
-														pos: position{line: 119, col: 70, offset: 2524},
+														pos: position{line: 119, col: 70, offset: 2590},
 														exprs: []interface{}{
 															&ruleRefExpr{
-																pos:  position{line: 119, col: 70, offset: 2524},
+																pos:  position{line: 119, col: 70, offset: 2590},
 																name: "BS",
 															},
 															&ruleRefExpr{
-																pos:  position{line: 119, col: 73, offset: 2527},
+																pos:  position{line: 119, col: 73, offset: 2593},
 																name: "BLOCK",
 															},
 														},
@@ -1362,30 +1402,30 @@ var g = &grammar{
 											},
 										},
 										&choiceExpr{
-											pos: position{line: 119, col: 81, offset: 2535},
+											pos: position{line: 119, col: 81, offset: 2601},
 											alternatives: []interface{}{
 												&seqExpr{
-													pos: position{line: 119, col: 81, offset: 2535},
+													pos: position{line: 119, col: 81, offset: 2601},
 													exprs: []interface{}{
 														&ruleRefExpr{
-															pos:  position{line: 119, col: 81, offset: 2535},
+															pos:  position{line: 119, col: 81, offset: 2601},
 															name: "LS",
 														},
 														&zeroOrMoreExpr{
-															pos: position{line: 119, col: 84, offset: 2538},
+															pos: position{line: 119, col: 84, offset: 2604},
 															expr: &seqExpr{
-																pos: position{line: 119, col: 85, offset: 2539},
+																pos: position{line: 119, col: 85, offset: 2605},
 																exprs: []interface{}{
 																	&ruleRefExpr{
-																		pos:  position{line: 119, col: 85, offset: 2539},
+																		pos:  position{line: 119, col: 85, offset: 2605},
 																		name: "WS",
 																	},
 																	&ruleRefExpr{
-																		pos:  position{line: 119, col: 88, offset: 2542},
+																		pos:  position{line: 119, col: 88, offset: 2608},
 																		name: "NL",
 																	},
 																	&ruleRefExpr{
-																		pos:  position{line: 119, col: 91, offset: 2545},
+																		pos:  position{line: 119, col: 91, offset: 2611},
 																		name: "WS",
 																	},
 																},
@@ -1394,17 +1434,17 @@ var g = &grammar{
 													},
 												},
 												&ruleRefExpr{
-													pos:  position{line: 119, col: 98, offset: 2552},
+													pos:  position{line: 119, col: 98, offset: 2618},
 													name: "LS",
 												},
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 119, col: 102, offset: 2556},
+											pos:  position{line: 119, col: 102, offset: 2622},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 119, col: 105, offset: 2559},
+											pos:  position{line: 119, col: 105, offset: 2625},
 											name: "FILTER",
 										},
 									},
@@ -1417,28 +1457,28 @@ var g = &grammar{
 		},
 		{
 			name: "FILTER",
-			pos:  position{line: 123, col: 1, offset: 2596},
+			pos:  position{line: 123, col: 1, offset: 2662},
 			expr: &actionExpr{
-				pos: position{line: 123, col: 11, offset: 2606},
+				pos: position{line: 123, col: 11, offset: 2672},
 				run: (*parser).callonFILTER1,
 				expr: &seqExpr{
-					pos: position{line: 123, col: 11, offset: 2606},
+					pos: position{line: 123, col: 11, offset: 2672},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 123, col: 11, offset: 2606},
+							pos:   position{line: 123, col: 11, offset: 2672},
 							label: "f",
 							expr: &ruleRefExpr{
-								pos:  position{line: 123, col: 14, offset: 2609},
+								pos:  position{line: 123, col: 14, offset: 2675},
 								name: "FILTER_VALUE",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 123, col: 28, offset: 2623},
+							pos:   position{line: 123, col: 28, offset: 2689},
 							label: "fns",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 123, col: 32, offset: 2627},
+								pos: position{line: 123, col: 32, offset: 2693},
 								expr: &ruleRefExpr{
-									pos:  position{line: 123, col: 33, offset: 2628},
+									pos:  position{line: 123, col: 33, offset: 2694},
 									name: "APPLY_FILTER_FN",
 								},
 							},
@@ -1449,22 +1489,22 @@ var g = &grammar{
 		},
 		{
 			name: "FILTER_VALUE",
-			pos:  position{line: 127, col: 1, offset: 2677},
+			pos:  position{line: 127, col: 1, offset: 2743},
 			expr: &actionExpr{
-				pos: position{line: 127, col: 17, offset: 2693},
+				pos: position{line: 127, col: 17, offset: 2759},
 				run: (*parser).callonFILTER_VALUE1,
 				expr: &labeledExpr{
-					pos:   position{line: 127, col: 17, offset: 2693},
+					pos:   position{line: 127, col: 17, offset: 2759},
 					label: "fv",
 					expr: &choiceExpr{
-						pos: position{line: 127, col: 21, offset: 2697},
+						pos: position{line: 127, col: 21, offset: 2763},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 127, col: 21, offset: 2697},
+								pos:  position{line: 127, col: 21, offset: 2763},
 								name: "IDENT_WITH_DOT",
 							},
 							&litMatcher{
-								pos:        position{line: 127, col: 38, offset: 2714},
+								pos:        position{line: 127, col: 38, offset: 2780},
 								val:        "*",
 								ignoreCase: false,
 								want:       "\"*\"",
@@ -1476,35 +1516,35 @@ var g = &grammar{
 		},
 		{
 			name: "APPLY_FILTER_FN",
-			pos:  position{line: 131, col: 1, offset: 2751},
+			pos:  position{line: 131, col: 1, offset: 2817},
 			expr: &actionExpr{
-				pos: position{line: 131, col: 20, offset: 2770},
+				pos: position{line: 131, col: 20, offset: 2836},
 				run: (*parser).callonAPPLY_FILTER_FN1,
 				expr: &seqExpr{
-					pos: position{line: 131, col: 20, offset: 2770},
+					pos: position{line: 131, col: 20, offset: 2836},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 131, col: 20, offset: 2770},
+							pos:  position{line: 131, col: 20, offset: 2836},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 131, col: 23, offset: 2773},
+							pos:        position{line: 131, col: 23, offset: 2839},
 							val:        "->",
 							ignoreCase: false,
 							want:       "\"->\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 131, col: 28, offset: 2778},
+							pos: position{line: 131, col: 28, offset: 2844},
 							expr: &ruleRefExpr{
-								pos:  position{line: 131, col: 28, offset: 2778},
+								pos:  position{line: 131, col: 28, offset: 2844},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 131, col: 32, offset: 2782},
+							pos:   position{line: 131, col: 32, offset: 2848},
 							label: "fn",
 							expr: &ruleRefExpr{
-								pos:  position{line: 131, col: 36, offset: 2786},
+								pos:  position{line: 131, col: 36, offset: 2852},
 								name: "FILTER_FUNCTION",
 							},
 						},
@@ -1514,22 +1554,22 @@ var g = &grammar{
 		},
 		{
 			name: "FILTER_FUNCTION",
-			pos:  position{line: 135, col: 1, offset: 2824},
+			pos:  position{line: 135, col: 1, offset: 2890},
 			expr: &actionExpr{
-				pos: position{line: 135, col: 20, offset: 2843},
+				pos: position{line: 135, col: 20, offset: 2909},
 				run: (*parser).callonFILTER_FUNCTION1,
 				expr: &labeledExpr{
-					pos:   position{line: 135, col: 20, offset: 2843},
+					pos:   position{line: 135, col: 20, offset: 2909},
 					label: "f",
 					expr: &choiceExpr{
-						pos: position{line: 135, col: 23, offset: 2846},
+						pos: position{line: 135, col: 23, offset: 2912},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 135, col: 23, offset: 2846},
+								pos:  position{line: 135, col: 23, offset: 2912},
 								name: "MATCHES",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 135, col: 33, offset: 2856},
+								pos:  position{line: 135, col: 33, offset: 2922},
 								name: "FILTER_BY_REGEX",
 							},
 						},
@@ -1539,44 +1579,44 @@ var g = &grammar{
 		},
 		{
 			name: "MATCHES",
-			pos:  position{line: 139, col: 1, offset: 2893},
+			pos:  position{line: 139, col: 1, offset: 2959},
 			expr: &actionExpr{
-				pos: position{line: 139, col: 12, offset: 2904},
+				pos: position{line: 139, col: 12, offset: 2970},
 				run: (*parser).callonMATCHES1,
 				expr: &seqExpr{
-					pos: position{line: 139, col: 12, offset: 2904},
+					pos: position{line: 139, col: 12, offset: 2970},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 139, col: 12, offset: 2904},
+							pos:        position{line: 139, col: 12, offset: 2970},
 							val:        "matches",
 							ignoreCase: false,
 							want:       "\"matches\"",
 						},
 						&litMatcher{
-							pos:        position{line: 139, col: 22, offset: 2914},
+							pos:        position{line: 139, col: 22, offset: 2980},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 139, col: 26, offset: 2918},
+							pos:   position{line: 139, col: 26, offset: 2984},
 							label: "arg",
 							expr: &choiceExpr{
-								pos: position{line: 139, col: 31, offset: 2923},
+								pos: position{line: 139, col: 31, offset: 2989},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 139, col: 31, offset: 2923},
+										pos:  position{line: 139, col: 31, offset: 2989},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 139, col: 42, offset: 2934},
+										pos:  position{line: 139, col: 42, offset: 3000},
 										name: "String",
 									},
 								},
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 139, col: 50, offset: 2942},
+							pos:        position{line: 139, col: 50, offset: 3008},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -1587,95 +1627,95 @@ var g = &grammar{
 		},
 		{
 			name: "FILTER_BY_REGEX",
-			pos:  position{line: 143, col: 1, offset: 2979},
+			pos:  position{line: 143, col: 1, offset: 3045},
 			expr: &actionExpr{
-				pos: position{line: 143, col: 20, offset: 2998},
+				pos: position{line: 143, col: 20, offset: 3064},
 				run: (*parser).callonFILTER_BY_REGEX1,
 				expr: &seqExpr{
-					pos: position{line: 143, col: 20, offset: 2998},
+					pos: position{line: 143, col: 20, offset: 3064},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 143, col: 20, offset: 2998},
+							pos:        position{line: 143, col: 20, offset: 3064},
 							val:        "filterByRegex",
 							ignoreCase: false,
 							want:       "\"filterByRegex\"",
 						},
 						&litMatcher{
-							pos:        position{line: 143, col: 36, offset: 3014},
+							pos:        position{line: 143, col: 36, offset: 3080},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 143, col: 40, offset: 3018},
+							pos: position{line: 143, col: 40, offset: 3084},
 							expr: &ruleRefExpr{
-								pos:  position{line: 143, col: 40, offset: 3018},
+								pos:  position{line: 143, col: 40, offset: 3084},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 143, col: 44, offset: 3022},
+							pos:   position{line: 143, col: 44, offset: 3088},
 							label: "path",
 							expr: &choiceExpr{
-								pos: position{line: 143, col: 50, offset: 3028},
+								pos: position{line: 143, col: 50, offset: 3094},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 143, col: 50, offset: 3028},
+										pos:  position{line: 143, col: 50, offset: 3094},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 143, col: 61, offset: 3039},
+										pos:  position{line: 143, col: 61, offset: 3105},
 										name: "String",
 									},
 								},
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 143, col: 69, offset: 3047},
+							pos: position{line: 143, col: 69, offset: 3113},
 							expr: &ruleRefExpr{
-								pos:  position{line: 143, col: 69, offset: 3047},
+								pos:  position{line: 143, col: 69, offset: 3113},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 143, col: 73, offset: 3051},
+							pos:        position{line: 143, col: 73, offset: 3117},
 							val:        ",",
 							ignoreCase: false,
 							want:       "\",\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 143, col: 77, offset: 3055},
+							pos: position{line: 143, col: 77, offset: 3121},
 							expr: &ruleRefExpr{
-								pos:  position{line: 143, col: 77, offset: 3055},
+								pos:  position{line: 143, col: 77, offset: 3121},
 								name: "WS",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 143, col: 81, offset: 3059},
+							pos:   position{line: 143, col: 81, offset: 3125},
 							label: "regex",
 							expr: &choiceExpr{
-								pos: position{line: 143, col: 88, offset: 3066},
+								pos: position{line: 143, col: 88, offset: 3132},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 143, col: 88, offset: 3066},
+										pos:  position{line: 143, col: 88, offset: 3132},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 143, col: 99, offset: 3077},
+										pos:  position{line: 143, col: 99, offset: 3143},
 										name: "String",
 									},
 								},
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 143, col: 107, offset: 3085},
+							pos: position{line: 143, col: 107, offset: 3151},
 							expr: &ruleRefExpr{
-								pos:  position{line: 143, col: 107, offset: 3085},
+								pos:  position{line: 143, col: 107, offset: 3151},
 								name: "WS",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 143, col: 112, offset: 3090},
+							pos:        position{line: 143, col: 112, offset: 3156},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -1686,57 +1726,57 @@ var g = &grammar{
 		},
 		{
 			name: "HEADERS",
-			pos:  position{line: 147, col: 1, offset: 3137},
+			pos:  position{line: 147, col: 1, offset: 3203},
 			expr: &actionExpr{
-				pos: position{line: 147, col: 12, offset: 3148},
+				pos: position{line: 147, col: 12, offset: 3214},
 				run: (*parser).callonHEADERS1,
 				expr: &seqExpr{
-					pos: position{line: 147, col: 12, offset: 3148},
+					pos: position{line: 147, col: 12, offset: 3214},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 147, col: 12, offset: 3148},
+							pos:  position{line: 147, col: 12, offset: 3214},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 147, col: 20, offset: 3156},
+							pos:        position{line: 147, col: 20, offset: 3222},
 							val:        "headers",
 							ignoreCase: false,
 							want:       "\"headers\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 147, col: 30, offset: 3166},
+							pos:  position{line: 147, col: 30, offset: 3232},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 147, col: 38, offset: 3174},
+							pos:   position{line: 147, col: 38, offset: 3240},
 							label: "h",
 							expr: &ruleRefExpr{
-								pos:  position{line: 147, col: 41, offset: 3177},
+								pos:  position{line: 147, col: 41, offset: 3243},
 								name: "HEADER",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 147, col: 49, offset: 3185},
+							pos:   position{line: 147, col: 49, offset: 3251},
 							label: "hs",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 147, col: 52, offset: 3188},
+								pos: position{line: 147, col: 52, offset: 3254},
 								expr: &seqExpr{
-									pos: position{line: 147, col: 53, offset: 3189},
+									pos: position{line: 147, col: 53, offset: 3255},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 147, col: 53, offset: 3189},
+											pos:  position{line: 147, col: 53, offset: 3255},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 147, col: 56, offset: 3192},
+											pos:  position{line: 147, col: 56, offset: 3258},
 											name: "LS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 147, col: 59, offset: 3195},
+											pos:  position{line: 147, col: 59, offset: 3261},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 147, col: 62, offset: 3198},
+											pos:  position{line: 147, col: 62, offset: 3264},
 											name: "HEADER",
 										},
 									},
@@ -1749,51 +1789,51 @@ var g = &grammar{
 		},
 		{
 			name: "HEADER",
-			pos:  position{line: 151, col: 1, offset: 3238},
+			pos:  position{line: 151, col: 1, offset: 3304},
 			expr: &actionExpr{
-				pos: position{line: 151, col: 11, offset: 3248},
+				pos: position{line: 151, col: 11, offset: 3314},
 				run: (*parser).callonHEADER1,
 				expr: &seqExpr{
-					pos: position{line: 151, col: 11, offset: 3248},
+					pos: position{line: 151, col: 11, offset: 3314},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 151, col: 11, offset: 3248},
+							pos:   position{line: 151, col: 11, offset: 3314},
 							label: "n",
 							expr: &ruleRefExpr{
-								pos:  position{line: 151, col: 14, offset: 3251},
+								pos:  position{line: 151, col: 14, offset: 3317},
 								name: "IDENT",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 151, col: 21, offset: 3258},
+							pos:  position{line: 151, col: 21, offset: 3324},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 151, col: 24, offset: 3261},
+							pos:        position{line: 151, col: 24, offset: 3327},
 							val:        "=",
 							ignoreCase: false,
 							want:       "\"=\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 151, col: 28, offset: 3265},
+							pos:  position{line: 151, col: 28, offset: 3331},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 151, col: 31, offset: 3268},
+							pos:   position{line: 151, col: 31, offset: 3334},
 							label: "v",
 							expr: &choiceExpr{
-								pos: position{line: 151, col: 34, offset: 3271},
+								pos: position{line: 151, col: 34, offset: 3337},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 151, col: 34, offset: 3271},
+										pos:  position{line: 151, col: 34, offset: 3337},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 151, col: 45, offset: 3282},
+										pos:  position{line: 151, col: 45, offset: 3348},
 										name: "CHAIN",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 151, col: 53, offset: 3290},
+										pos:  position{line: 151, col: 53, offset: 3356},
 										name: "String",
 									},
 								},
@@ -1805,19 +1845,19 @@ var g = &grammar{
 		},
 		{
 			name: "HIDDEN_RULE",
-			pos:  position{line: 155, col: 1, offset: 3327},
+			pos:  position{line: 155, col: 1, offset: 3393},
 			expr: &actionExpr{
-				pos: position{line: 155, col: 16, offset: 3342},
+				pos: position{line: 155, col: 16, offset: 3408},
 				run: (*parser).callonHIDDEN_RULE1,
 				expr: &seqExpr{
-					pos: position{line: 155, col: 16, offset: 3342},
+					pos: position{line: 155, col: 16, offset: 3408},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 155, col: 16, offset: 3342},
+							pos:  position{line: 155, col: 16, offset: 3408},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 155, col: 24, offset: 3350},
+							pos:        position{line: 155, col: 24, offset: 3416},
 							val:        "hidden",
 							ignoreCase: false,
 							want:       "\"hidden\"",
@@ -1828,39 +1868,39 @@ var g = &grammar{
 		},
 		{
 			name: "TIMEOUT",
-			pos:  position{line: 159, col: 1, offset: 3384},
+			pos:  position{line: 159, col: 1, offset: 3450},
 			expr: &actionExpr{
-				pos: position{line: 159, col: 12, offset: 3395},
+				pos: position{line: 159, col: 12, offset: 3461},
 				run: (*parser).callonTIMEOUT1,
 				expr: &seqExpr{
-					pos: position{line: 159, col: 12, offset: 3395},
+					pos: position{line: 159, col: 12, offset: 3461},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 159, col: 12, offset: 3395},
+							pos:  position{line: 159, col: 12, offset: 3461},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 159, col: 20, offset: 3403},
+							pos:        position{line: 159, col: 20, offset: 3469},
 							val:        "timeout",
 							ignoreCase: false,
 							want:       "\"timeout\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 159, col: 30, offset: 3413},
+							pos:  position{line: 159, col: 30, offset: 3479},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 159, col: 38, offset: 3421},
+							pos:   position{line: 159, col: 38, offset: 3487},
 							label: "t",
 							expr: &choiceExpr{
-								pos: position{line: 159, col: 41, offset: 3424},
+								pos: position{line: 159, col: 41, offset: 3490},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 159, col: 41, offset: 3424},
+										pos:  position{line: 159, col: 41, offset: 3490},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 159, col: 52, offset: 3435},
+										pos:  position{line: 159, col: 52, offset: 3501},
 										name: "Integer",
 									},
 								},
@@ -1872,39 +1912,39 @@ var g = &grammar{
 		},
 		{
 			name: "MAX_AGE",
-			pos:  position{line: 163, col: 1, offset: 3471},
+			pos:  position{line: 163, col: 1, offset: 3537},
 			expr: &actionExpr{
-				pos: position{line: 163, col: 12, offset: 3482},
+				pos: position{line: 163, col: 12, offset: 3548},
 				run: (*parser).callonMAX_AGE1,
 				expr: &seqExpr{
-					pos: position{line: 163, col: 12, offset: 3482},
+					pos: position{line: 163, col: 12, offset: 3548},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 163, col: 12, offset: 3482},
+							pos:  position{line: 163, col: 12, offset: 3548},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 163, col: 20, offset: 3490},
+							pos:        position{line: 163, col: 20, offset: 3556},
 							val:        "max-age",
 							ignoreCase: false,
 							want:       "\"max-age\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 163, col: 30, offset: 3500},
+							pos:  position{line: 163, col: 30, offset: 3566},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 163, col: 38, offset: 3508},
+							pos:   position{line: 163, col: 38, offset: 3574},
 							label: "t",
 							expr: &choiceExpr{
-								pos: position{line: 163, col: 41, offset: 3511},
+								pos: position{line: 163, col: 41, offset: 3577},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 163, col: 41, offset: 3511},
+										pos:  position{line: 163, col: 41, offset: 3577},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 163, col: 52, offset: 3522},
+										pos:  position{line: 163, col: 52, offset: 3588},
 										name: "Integer",
 									},
 								},
@@ -1916,39 +1956,39 @@ var g = &grammar{
 		},
 		{
 			name: "S_MAX_AGE",
-			pos:  position{line: 167, col: 1, offset: 3557},
+			pos:  position{line: 167, col: 1, offset: 3623},
 			expr: &actionExpr{
-				pos: position{line: 167, col: 14, offset: 3570},
+				pos: position{line: 167, col: 14, offset: 3636},
 				run: (*parser).callonS_MAX_AGE1,
 				expr: &seqExpr{
-					pos: position{line: 167, col: 14, offset: 3570},
+					pos: position{line: 167, col: 14, offset: 3636},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 167, col: 14, offset: 3570},
+							pos:  position{line: 167, col: 14, offset: 3636},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 167, col: 22, offset: 3578},
+							pos:        position{line: 167, col: 22, offset: 3644},
 							val:        "s-max-age",
 							ignoreCase: false,
 							want:       "\"s-max-age\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 167, col: 34, offset: 3590},
+							pos:  position{line: 167, col: 34, offset: 3656},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 167, col: 42, offset: 3598},
+							pos:   position{line: 167, col: 42, offset: 3664},
 							label: "t",
 							expr: &choiceExpr{
-								pos: position{line: 167, col: 45, offset: 3601},
+								pos: position{line: 167, col: 45, offset: 3667},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 167, col: 45, offset: 3601},
+										pos:  position{line: 167, col: 45, offset: 3667},
 										name: "VARIABLE",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 167, col: 56, offset: 3612},
+										pos:  position{line: 167, col: 56, offset: 3678},
 										name: "Integer",
 									},
 								},
@@ -1960,32 +2000,32 @@ var g = &grammar{
 		},
 		{
 			name: "DEPENDS_ON",
-			pos:  position{line: 172, col: 1, offset: 3649},
+			pos:  position{line: 172, col: 1, offset: 3715},
 			expr: &actionExpr{
-				pos: position{line: 172, col: 15, offset: 3663},
+				pos: position{line: 172, col: 15, offset: 3729},
 				run: (*parser).callonDEPENDS_ON1,
 				expr: &seqExpr{
-					pos: position{line: 172, col: 15, offset: 3663},
+					pos: position{line: 172, col: 15, offset: 3729},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 172, col: 15, offset: 3663},
+							pos:  position{line: 172, col: 15, offset: 3729},
 							name: "WS_MAND",
 						},
 						&litMatcher{
-							pos:        position{line: 172, col: 23, offset: 3671},
+							pos:        position{line: 172, col: 23, offset: 3737},
 							val:        "depends-on",
 							ignoreCase: false,
 							want:       "\"depends-on\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 172, col: 36, offset: 3684},
+							pos:  position{line: 172, col: 36, offset: 3750},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 172, col: 44, offset: 3692},
+							pos:   position{line: 172, col: 44, offset: 3758},
 							label: "t",
 							expr: &ruleRefExpr{
-								pos:  position{line: 172, col: 47, offset: 3695},
+								pos:  position{line: 172, col: 47, offset: 3761},
 								name: "IDENT",
 							},
 						},
@@ -1995,47 +2035,47 @@ var g = &grammar{
 		},
 		{
 			name: "FLAGS_RULE",
-			pos:  position{line: 176, col: 1, offset: 3731},
+			pos:  position{line: 176, col: 1, offset: 3797},
 			expr: &actionExpr{
-				pos: position{line: 176, col: 15, offset: 3745},
+				pos: position{line: 176, col: 15, offset: 3811},
 				run: (*parser).callonFLAGS_RULE1,
 				expr: &seqExpr{
-					pos: position{line: 176, col: 15, offset: 3745},
+					pos: position{line: 176, col: 15, offset: 3811},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 176, col: 15, offset: 3745},
+							pos:  position{line: 176, col: 15, offset: 3811},
 							name: "WS_MAND",
 						},
 						&labeledExpr{
-							pos:   position{line: 176, col: 23, offset: 3753},
+							pos:   position{line: 176, col: 23, offset: 3819},
 							label: "i",
 							expr: &ruleRefExpr{
-								pos:  position{line: 176, col: 25, offset: 3755},
+								pos:  position{line: 176, col: 25, offset: 3821},
 								name: "IGNORE_FLAG",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 176, col: 37, offset: 3767},
+							pos:   position{line: 176, col: 37, offset: 3833},
 							label: "is",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 176, col: 40, offset: 3770},
+								pos: position{line: 176, col: 40, offset: 3836},
 								expr: &seqExpr{
-									pos: position{line: 176, col: 41, offset: 3771},
+									pos: position{line: 176, col: 41, offset: 3837},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 176, col: 41, offset: 3771},
+											pos:  position{line: 176, col: 41, offset: 3837},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 176, col: 44, offset: 3774},
+											pos:  position{line: 176, col: 44, offset: 3840},
 											name: "LS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 176, col: 47, offset: 3777},
+											pos:  position{line: 176, col: 47, offset: 3843},
 											name: "WS",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 176, col: 50, offset: 3780},
+											pos:  position{line: 176, col: 50, offset: 3846},
 											name: "IGNORE_FLAG",
 										},
 									},
@@ -2048,12 +2088,12 @@ var g = &grammar{
 		},
 		{
 			name: "IGNORE_FLAG",
-			pos:  position{line: 180, col: 1, offset: 3823},
+			pos:  position{line: 180, col: 1, offset: 3889},
 			expr: &actionExpr{
-				pos: position{line: 180, col: 16, offset: 3838},
+				pos: position{line: 180, col: 16, offset: 3904},
 				run: (*parser).callonIGNORE_FLAG1,
 				expr: &litMatcher{
-					pos:        position{line: 180, col: 16, offset: 3838},
+					pos:        position{line: 180, col: 16, offset: 3904},
 					val:        "ignore-errors",
 					ignoreCase: false,
 					want:       "\"ignore-errors\"",
@@ -2062,40 +2102,40 @@ var g = &grammar{
 		},
 		{
 			name: "CHAIN",
-			pos:  position{line: 184, col: 1, offset: 3885},
+			pos:  position{line: 184, col: 1, offset: 3951},
 			expr: &actionExpr{
-				pos: position{line: 184, col: 10, offset: 3894},
+				pos: position{line: 184, col: 10, offset: 3960},
 				run: (*parser).callonCHAIN1,
 				expr: &seqExpr{
-					pos: position{line: 184, col: 10, offset: 3894},
+					pos: position{line: 184, col: 10, offset: 3960},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 184, col: 10, offset: 3894},
+							pos:   position{line: 184, col: 10, offset: 3960},
 							label: "i",
 							expr: &ruleRefExpr{
-								pos:  position{line: 184, col: 13, offset: 3897},
+								pos:  position{line: 184, col: 13, offset: 3963},
 								name: "CHAINED_ITEM",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 184, col: 27, offset: 3911},
+							pos:   position{line: 184, col: 27, offset: 3977},
 							label: "ii",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 184, col: 30, offset: 3914},
+								pos: position{line: 184, col: 30, offset: 3980},
 								expr: &seqExpr{
-									pos: position{line: 184, col: 31, offset: 3915},
+									pos: position{line: 184, col: 31, offset: 3981},
 									exprs: []interface{}{
 										&zeroOrOneExpr{
-											pos: position{line: 184, col: 31, offset: 3915},
+											pos: position{line: 184, col: 31, offset: 3981},
 											expr: &litMatcher{
-												pos:        position{line: 184, col: 31, offset: 3915},
+												pos:        position{line: 184, col: 31, offset: 3981},
 												val:        ".",
 												ignoreCase: false,
 												want:       "\".\"",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 184, col: 36, offset: 3920},
+											pos:  position{line: 184, col: 36, offset: 3986},
 											name: "CHAINED_ITEM",
 										},
 									},
@@ -2108,22 +2148,22 @@ var g = &grammar{
 		},
 		{
 			name: "CHAINED_ITEM",
-			pos:  position{line: 188, col: 1, offset: 3964},
+			pos:  position{line: 188, col: 1, offset: 4030},
 			expr: &actionExpr{
-				pos: position{line: 188, col: 17, offset: 3980},
+				pos: position{line: 188, col: 17, offset: 4046},
 				run: (*parser).callonCHAINED_ITEM1,
 				expr: &labeledExpr{
-					pos:   position{line: 188, col: 17, offset: 3980},
+					pos:   position{line: 188, col: 17, offset: 4046},
 					label: "ci",
 					expr: &choiceExpr{
-						pos: position{line: 188, col: 21, offset: 3984},
+						pos: position{line: 188, col: 21, offset: 4050},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 188, col: 21, offset: 3984},
+								pos:  position{line: 188, col: 21, offset: 4050},
 								name: "PATH_VARIABLE",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 188, col: 37, offset: 4000},
+								pos:  position{line: 188, col: 37, offset: 4066},
 								name: "IDENT",
 							},
 						},
@@ -2133,40 +2173,40 @@ var g = &grammar{
 		},
 		{
 			name: "PATH_VARIABLE",
-			pos:  position{line: 192, col: 1, offset: 4035},
+			pos:  position{line: 192, col: 1, offset: 4101},
 			expr: &actionExpr{
-				pos: position{line: 192, col: 18, offset: 4052},
+				pos: position{line: 192, col: 18, offset: 4118},
 				run: (*parser).callonPATH_VARIABLE1,
 				expr: &seqExpr{
-					pos: position{line: 192, col: 18, offset: 4052},
+					pos: position{line: 192, col: 18, offset: 4118},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 192, col: 18, offset: 4052},
+							pos: position{line: 192, col: 18, offset: 4118},
 							expr: &litMatcher{
-								pos:        position{line: 192, col: 18, offset: 4052},
+								pos:        position{line: 192, col: 18, offset: 4118},
 								val:        "[",
 								ignoreCase: false,
 								want:       "\"[\"",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 192, col: 23, offset: 4057},
+							pos:        position{line: 192, col: 23, offset: 4123},
 							val:        "$",
 							ignoreCase: false,
 							want:       "\"$\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 192, col: 27, offset: 4061},
+							pos:   position{line: 192, col: 27, offset: 4127},
 							label: "i",
 							expr: &ruleRefExpr{
-								pos:  position{line: 192, col: 30, offset: 4064},
+								pos:  position{line: 192, col: 30, offset: 4130},
 								name: "IDENT",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 192, col: 37, offset: 4071},
+							pos: position{line: 192, col: 37, offset: 4137},
 							expr: &litMatcher{
-								pos:        position{line: 192, col: 37, offset: 4071},
+								pos:        position{line: 192, col: 37, offset: 4137},
 								val:        "]",
 								ignoreCase: false,
 								want:       "\"]\"",
@@ -2178,24 +2218,24 @@ var g = &grammar{
 		},
 		{
 			name: "VARIABLE",
-			pos:  position{line: 196, col: 1, offset: 4113},
+			pos:  position{line: 196, col: 1, offset: 4179},
 			expr: &actionExpr{
-				pos: position{line: 196, col: 13, offset: 4125},
+				pos: position{line: 196, col: 13, offset: 4191},
 				run: (*parser).callonVARIABLE1,
 				expr: &seqExpr{
-					pos: position{line: 196, col: 13, offset: 4125},
+					pos: position{line: 196, col: 13, offset: 4191},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 196, col: 13, offset: 4125},
+							pos:        position{line: 196, col: 13, offset: 4191},
 							val:        "$",
 							ignoreCase: false,
 							want:       "\"$\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 196, col: 17, offset: 4129},
+							pos:   position{line: 196, col: 17, offset: 4195},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 196, col: 20, offset: 4132},
+								pos:  position{line: 196, col: 20, offset: 4198},
 								name: "IDENT_WITH_DOT",
 							},
 						},
@@ -2205,14 +2245,14 @@ var g = &grammar{
 		},
 		{
 			name: "IDENT",
-			pos:  position{line: 200, col: 1, offset: 4176},
+			pos:  position{line: 200, col: 1, offset: 4242},
 			expr: &actionExpr{
-				pos: position{line: 200, col: 10, offset: 4185},
+				pos: position{line: 200, col: 10, offset: 4251},
 				run: (*parser).callonIDENT1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 200, col: 10, offset: 4185},
+					pos: position{line: 200, col: 10, offset: 4251},
 					expr: &charClassMatcher{
-						pos:        position{line: 200, col: 10, offset: 4185},
+						pos:        position{line: 200, col: 10, offset: 4251},
 						val:        "[A-Za-z0-9:_-]",
 						chars:      []rune{':', '_', '-'},
 						ranges:     []rune{'A', 'Z', 'a', 'z', '0', '9'},
@@ -2224,14 +2264,14 @@ var g = &grammar{
 		},
 		{
 			name: "IDENT_WITHOUT_COLLON",
-			pos:  position{line: 204, col: 1, offset: 4232},
+			pos:  position{line: 204, col: 1, offset: 4298},
 			expr: &actionExpr{
-				pos: position{line: 204, col: 25, offset: 4256},
+				pos: position{line: 204, col: 25, offset: 4322},
 				run: (*parser).callonIDENT_WITHOUT_COLLON1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 204, col: 25, offset: 4256},
+					pos: position{line: 204, col: 25, offset: 4322},
 					expr: &charClassMatcher{
-						pos:        position{line: 204, col: 25, offset: 4256},
+						pos:        position{line: 204, col: 25, offset: 4322},
 						val:        "[A-Za-z0-9_-]",
 						chars:      []rune{'_', '-'},
 						ranges:     []rune{'A', 'Z', 'a', 'z', '0', '9'},
@@ -2243,14 +2283,14 @@ var g = &grammar{
 		},
 		{
 			name: "IDENT_WITH_DOT",
-			pos:  position{line: 208, col: 1, offset: 4302},
+			pos:  position{line: 208, col: 1, offset: 4368},
 			expr: &actionExpr{
-				pos: position{line: 208, col: 19, offset: 4320},
+				pos: position{line: 208, col: 19, offset: 4386},
 				run: (*parser).callonIDENT_WITH_DOT1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 208, col: 19, offset: 4320},
+					pos: position{line: 208, col: 19, offset: 4386},
 					expr: &charClassMatcher{
-						pos:        position{line: 208, col: 19, offset: 4320},
+						pos:        position{line: 208, col: 19, offset: 4386},
 						val:        "[a-zA-Z0-9-:_.]",
 						chars:      []rune{'-', ':', '_', '.'},
 						ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
@@ -2262,12 +2302,12 @@ var g = &grammar{
 		},
 		{
 			name: "Null",
-			pos:  position{line: 212, col: 1, offset: 4368},
+			pos:  position{line: 212, col: 1, offset: 4434},
 			expr: &actionExpr{
-				pos: position{line: 212, col: 9, offset: 4376},
+				pos: position{line: 212, col: 9, offset: 4442},
 				run: (*parser).callonNull1,
 				expr: &litMatcher{
-					pos:        position{line: 212, col: 9, offset: 4376},
+					pos:        position{line: 212, col: 9, offset: 4442},
 					val:        "null",
 					ignoreCase: false,
 					want:       "\"null\"",
@@ -2276,21 +2316,21 @@ var g = &grammar{
 		},
 		{
 			name: "Boolean",
-			pos:  position{line: 216, col: 1, offset: 4406},
+			pos:  position{line: 216, col: 1, offset: 4472},
 			expr: &actionExpr{
-				pos: position{line: 216, col: 12, offset: 4417},
+				pos: position{line: 216, col: 12, offset: 4483},
 				run: (*parser).callonBoolean1,
 				expr: &choiceExpr{
-					pos: position{line: 216, col: 13, offset: 4418},
+					pos: position{line: 216, col: 13, offset: 4484},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 216, col: 13, offset: 4418},
+							pos:        position{line: 216, col: 13, offset: 4484},
 							val:        "true",
 							ignoreCase: false,
 							want:       "\"true\"",
 						},
 						&litMatcher{
-							pos:        position{line: 216, col: 22, offset: 4427},
+							pos:        position{line: 216, col: 22, offset: 4493},
 							val:        "false",
 							ignoreCase: false,
 							want:       "\"false\"",
@@ -2301,28 +2341,28 @@ var g = &grammar{
 		},
 		{
 			name: "String",
-			pos:  position{line: 220, col: 1, offset: 4468},
+			pos:  position{line: 220, col: 1, offset: 4534},
 			expr: &actionExpr{
-				pos: position{line: 220, col: 11, offset: 4478},
+				pos: position{line: 220, col: 11, offset: 4544},
 				run: (*parser).callonString1,
 				expr: &seqExpr{
-					pos: position{line: 220, col: 11, offset: 4478},
+					pos: position{line: 220, col: 11, offset: 4544},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 220, col: 11, offset: 4478},
+							pos:        position{line: 220, col: 11, offset: 4544},
 							val:        "\"",
 							ignoreCase: false,
 							want:       "\"\\\"\"",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 220, col: 15, offset: 4482},
+							pos: position{line: 220, col: 15, offset: 4548},
 							expr: &seqExpr{
-								pos: position{line: 220, col: 17, offset: 4484},
+								pos: position{line: 220, col: 17, offset: 4550},
 								exprs: []interface{}{
 									&notExpr{
-										pos: position{line: 220, col: 17, offset: 4484},
+										pos: position{line: 220, col: 17, offset: 4550},
 										expr: &litMatcher{
-											pos:        position{line: 220, col: 18, offset: 4485},
+											pos:        position{line: 220, col: 18, offset: 4551},
 											val:        "\"",
 											ignoreCase: false,
 											want:       "\"\\\"\"",
@@ -2335,7 +2375,7 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 220, col: 27, offset: 4494},
+							pos:        position{line: 220, col: 27, offset: 4560},
 							val:        "\"",
 							ignoreCase: false,
 							want:       "\"\\\"\"",
@@ -2346,26 +2386,26 @@ var g = &grammar{
 		},
 		{
 			name: "Float",
-			pos:  position{line: 224, col: 1, offset: 4529},
+			pos:  position{line: 224, col: 1, offset: 4595},
 			expr: &actionExpr{
-				pos: position{line: 224, col: 10, offset: 4538},
+				pos: position{line: 224, col: 10, offset: 4604},
 				run: (*parser).callonFloat1,
 				expr: &seqExpr{
-					pos: position{line: 224, col: 10, offset: 4538},
+					pos: position{line: 224, col: 10, offset: 4604},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 224, col: 10, offset: 4538},
+							pos: position{line: 224, col: 10, offset: 4604},
 							expr: &choiceExpr{
-								pos: position{line: 224, col: 11, offset: 4539},
+								pos: position{line: 224, col: 11, offset: 4605},
 								alternatives: []interface{}{
 									&litMatcher{
-										pos:        position{line: 224, col: 11, offset: 4539},
+										pos:        position{line: 224, col: 11, offset: 4605},
 										val:        "+",
 										ignoreCase: false,
 										want:       "\"+\"",
 									},
 									&litMatcher{
-										pos:        position{line: 224, col: 17, offset: 4545},
+										pos:        position{line: 224, col: 17, offset: 4611},
 										val:        "-",
 										ignoreCase: false,
 										want:       "\"-\"",
@@ -2374,17 +2414,17 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 224, col: 23, offset: 4551},
+							pos:  position{line: 224, col: 23, offset: 4617},
 							name: "Natural",
 						},
 						&litMatcher{
-							pos:        position{line: 224, col: 31, offset: 4559},
+							pos:        position{line: 224, col: 31, offset: 4625},
 							val:        ".",
 							ignoreCase: false,
 							want:       "\".\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 224, col: 35, offset: 4563},
+							pos:  position{line: 224, col: 35, offset: 4629},
 							name: "Natural",
 						},
 					},
@@ -2393,26 +2433,26 @@ var g = &grammar{
 		},
 		{
 			name: "Integer",
-			pos:  position{line: 228, col: 1, offset: 4601},
+			pos:  position{line: 228, col: 1, offset: 4667},
 			expr: &actionExpr{
-				pos: position{line: 228, col: 12, offset: 4612},
+				pos: position{line: 228, col: 12, offset: 4678},
 				run: (*parser).callonInteger1,
 				expr: &seqExpr{
-					pos: position{line: 228, col: 12, offset: 4612},
+					pos: position{line: 228, col: 12, offset: 4678},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 228, col: 12, offset: 4612},
+							pos: position{line: 228, col: 12, offset: 4678},
 							expr: &choiceExpr{
-								pos: position{line: 228, col: 13, offset: 4613},
+								pos: position{line: 228, col: 13, offset: 4679},
 								alternatives: []interface{}{
 									&litMatcher{
-										pos:        position{line: 228, col: 13, offset: 4613},
+										pos:        position{line: 228, col: 13, offset: 4679},
 										val:        "+",
 										ignoreCase: false,
 										want:       "\"+\"",
 									},
 									&litMatcher{
-										pos:        position{line: 228, col: 19, offset: 4619},
+										pos:        position{line: 228, col: 19, offset: 4685},
 										val:        "-",
 										ignoreCase: false,
 										want:       "\"-\"",
@@ -2421,7 +2461,7 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 228, col: 25, offset: 4625},
+							pos:  position{line: 228, col: 25, offset: 4691},
 							name: "Natural",
 						},
 					},
@@ -2430,27 +2470,27 @@ var g = &grammar{
 		},
 		{
 			name: "Natural",
-			pos:  position{line: 232, col: 1, offset: 4665},
+			pos:  position{line: 232, col: 1, offset: 4731},
 			expr: &choiceExpr{
-				pos: position{line: 232, col: 11, offset: 4677},
+				pos: position{line: 232, col: 11, offset: 4743},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 232, col: 11, offset: 4677},
+						pos:        position{line: 232, col: 11, offset: 4743},
 						val:        "0",
 						ignoreCase: false,
 						want:       "\"0\"",
 					},
 					&seqExpr{
-						pos: position{line: 232, col: 17, offset: 4683},
+						pos: position{line: 232, col: 17, offset: 4749},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 232, col: 17, offset: 4683},
+								pos:  position{line: 232, col: 17, offset: 4749},
 								name: "NonZeroDecimalDigit",
 							},
 							&zeroOrMoreExpr{
-								pos: position{line: 232, col: 37, offset: 4703},
+								pos: position{line: 232, col: 37, offset: 4769},
 								expr: &ruleRefExpr{
-									pos:  position{line: 232, col: 37, offset: 4703},
+									pos:  position{line: 232, col: 37, offset: 4769},
 									name: "DecimalDigit",
 								},
 							},
@@ -2461,9 +2501,9 @@ var g = &grammar{
 		},
 		{
 			name: "DecimalDigit",
-			pos:  position{line: 234, col: 1, offset: 4718},
+			pos:  position{line: 234, col: 1, offset: 4784},
 			expr: &charClassMatcher{
-				pos:        position{line: 234, col: 16, offset: 4735},
+				pos:        position{line: 234, col: 16, offset: 4801},
 				val:        "[0-9]",
 				ranges:     []rune{'0', '9'},
 				ignoreCase: false,
@@ -2472,9 +2512,9 @@ var g = &grammar{
 		},
 		{
 			name: "NonZeroDecimalDigit",
-			pos:  position{line: 235, col: 1, offset: 4741},
+			pos:  position{line: 235, col: 1, offset: 4807},
 			expr: &charClassMatcher{
-				pos:        position{line: 235, col: 23, offset: 4765},
+				pos:        position{line: 235, col: 23, offset: 4831},
 				val:        "[1-9]",
 				ranges:     []rune{'1', '9'},
 				ignoreCase: false,
@@ -2483,9 +2523,9 @@ var g = &grammar{
 		},
 		{
 			name: "SPACE",
-			pos:  position{line: 237, col: 1, offset: 4772},
+			pos:  position{line: 237, col: 1, offset: 4838},
 			expr: &charClassMatcher{
-				pos:        position{line: 237, col: 10, offset: 4781},
+				pos:        position{line: 237, col: 10, offset: 4847},
 				val:        "[ \\t]",
 				chars:      []rune{' ', '\t'},
 				ignoreCase: false,
@@ -2495,22 +2535,22 @@ var g = &grammar{
 		{
 			name:        "WS_MAND",
 			displayName: "\"mandatory-whitespace\"",
-			pos:         position{line: 238, col: 1, offset: 4787},
+			pos:         position{line: 238, col: 1, offset: 4853},
 			expr: &oneOrMoreExpr{
-				pos: position{line: 238, col: 35, offset: 4821},
+				pos: position{line: 238, col: 35, offset: 4887},
 				expr: &choiceExpr{
-					pos: position{line: 238, col: 36, offset: 4822},
+					pos: position{line: 238, col: 36, offset: 4888},
 					alternatives: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 238, col: 36, offset: 4822},
+							pos:  position{line: 238, col: 36, offset: 4888},
 							name: "SPACE",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 238, col: 44, offset: 4830},
+							pos:  position{line: 238, col: 44, offset: 4896},
 							name: "COMMENT",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 238, col: 54, offset: 4840},
+							pos:  position{line: 238, col: 54, offset: 4906},
 							name: "NL",
 						},
 					},
@@ -2520,18 +2560,18 @@ var g = &grammar{
 		{
 			name:        "WS",
 			displayName: "\"whitespace\"",
-			pos:         position{line: 239, col: 1, offset: 4845},
+			pos:         position{line: 239, col: 1, offset: 4911},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 239, col: 20, offset: 4864},
+				pos: position{line: 239, col: 20, offset: 4930},
 				expr: &choiceExpr{
-					pos: position{line: 239, col: 21, offset: 4865},
+					pos: position{line: 239, col: 21, offset: 4931},
 					alternatives: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 239, col: 21, offset: 4865},
+							pos:  position{line: 239, col: 21, offset: 4931},
 							name: "SPACE",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 239, col: 29, offset: 4873},
+							pos:  position{line: 239, col: 29, offset: 4939},
 							name: "COMMENT",
 						},
 					},
@@ -2541,22 +2581,22 @@ var g = &grammar{
 		{
 			name:        "LS",
 			displayName: "\"line-separator\"",
-			pos:         position{line: 240, col: 1, offset: 4883},
+			pos:         position{line: 240, col: 1, offset: 4949},
 			expr: &choiceExpr{
-				pos: position{line: 240, col: 25, offset: 4907},
+				pos: position{line: 240, col: 25, offset: 4973},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 240, col: 25, offset: 4907},
+						pos:  position{line: 240, col: 25, offset: 4973},
 						name: "NL",
 					},
 					&litMatcher{
-						pos:        position{line: 240, col: 30, offset: 4912},
+						pos:        position{line: 240, col: 30, offset: 4978},
 						val:        ",",
 						ignoreCase: false,
 						want:       "\",\"",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 240, col: 36, offset: 4918},
+						pos:  position{line: 240, col: 36, offset: 4984},
 						name: "COMMENT",
 					},
 				},
@@ -2565,31 +2605,31 @@ var g = &grammar{
 		{
 			name:        "BS",
 			displayName: "\"block-separator\"",
-			pos:         position{line: 241, col: 1, offset: 4927},
+			pos:         position{line: 241, col: 1, offset: 4993},
 			expr: &oneOrMoreExpr{
-				pos: position{line: 241, col: 25, offset: 4951},
+				pos: position{line: 241, col: 25, offset: 5017},
 				expr: &seqExpr{
-					pos: position{line: 241, col: 26, offset: 4952},
+					pos: position{line: 241, col: 26, offset: 5018},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 241, col: 26, offset: 4952},
+							pos:  position{line: 241, col: 26, offset: 5018},
 							name: "WS",
 						},
 						&choiceExpr{
-							pos: position{line: 241, col: 30, offset: 4956},
+							pos: position{line: 241, col: 30, offset: 5022},
 							alternatives: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 241, col: 30, offset: 4956},
+									pos:  position{line: 241, col: 30, offset: 5022},
 									name: "NL",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 241, col: 35, offset: 4961},
+									pos:  position{line: 241, col: 35, offset: 5027},
 									name: "COMMENT",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 241, col: 44, offset: 4970},
+							pos:  position{line: 241, col: 44, offset: 5036},
 							name: "WS",
 						},
 					},
@@ -2599,9 +2639,9 @@ var g = &grammar{
 		{
 			name:        "NL",
 			displayName: "\"new-line\"",
-			pos:         position{line: 242, col: 1, offset: 4975},
+			pos:         position{line: 242, col: 1, offset: 5041},
 			expr: &litMatcher{
-				pos:        position{line: 242, col: 18, offset: 4992},
+				pos:        position{line: 242, col: 18, offset: 5058},
 				val:        "\n",
 				ignoreCase: false,
 				want:       "\"\\n\"",
@@ -2609,25 +2649,25 @@ var g = &grammar{
 		},
 		{
 			name: "COMMENT",
-			pos:  position{line: 244, col: 1, offset: 4998},
+			pos:  position{line: 244, col: 1, offset: 5064},
 			expr: &seqExpr{
-				pos: position{line: 244, col: 12, offset: 5009},
+				pos: position{line: 244, col: 12, offset: 5075},
 				exprs: []interface{}{
 					&litMatcher{
-						pos:        position{line: 244, col: 12, offset: 5009},
+						pos:        position{line: 244, col: 12, offset: 5075},
 						val:        "//",
 						ignoreCase: false,
 						want:       "\"//\"",
 					},
 					&zeroOrMoreExpr{
-						pos: position{line: 244, col: 17, offset: 5014},
+						pos: position{line: 244, col: 17, offset: 5080},
 						expr: &seqExpr{
-							pos: position{line: 244, col: 19, offset: 5016},
+							pos: position{line: 244, col: 19, offset: 5082},
 							exprs: []interface{}{
 								&notExpr{
-									pos: position{line: 244, col: 19, offset: 5016},
+									pos: position{line: 244, col: 19, offset: 5082},
 									expr: &litMatcher{
-										pos:        position{line: 244, col: 20, offset: 5017},
+										pos:        position{line: 244, col: 20, offset: 5083},
 										val:        "\n",
 										ignoreCase: false,
 										want:       "\"\\n\"",
@@ -2640,16 +2680,16 @@ var g = &grammar{
 						},
 					},
 					&choiceExpr{
-						pos: position{line: 244, col: 31, offset: 5028},
+						pos: position{line: 244, col: 31, offset: 5094},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 244, col: 31, offset: 5028},
+								pos:        position{line: 244, col: 31, offset: 5094},
 								val:        "\n",
 								ignoreCase: false,
 								want:       "\"\\n\"",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 244, col: 38, offset: 5035},
+								pos:  position{line: 244, col: 38, offset: 5101},
 								name: "EOF",
 							},
 						},
@@ -2659,9 +2699,9 @@ var g = &grammar{
 		},
 		{
 			name: "EOF",
-			pos:  position{line: 246, col: 1, offset: 5041},
+			pos:  position{line: 246, col: 1, offset: 5107},
 			expr: &notExpr{
-				pos: position{line: 246, col: 8, offset: 5048},
+				pos: position{line: 246, col: 8, offset: 5114},
 				expr: &anyMatcher{
 					line: 246, col: 9, offset: 5049,
 				},
@@ -29,9 +29,12 @@ func makeUse(queryAst *ast.Query) map[string]interface{} {
 	result := map[string]interface{}{}
 	for _, use := range queryAst.Use {
 		key := strings.Trim(use.Key, " ")
-		if use.Value.String != nil {
+		switch {
+		case use.Value.String != nil:
 			result[key] = *use.Value.String
-		} else {
+		case use.Value.Boolean != nil:
+			result[key] = *use.Value.Boolean
+		default:
 			result[key] = *use.Value.Int
 		}
 	}
@@ -149,6 +152,10 @@ func applyFunctions(v interface{}, functions []string) interface{} {
 			v = domain.NoExplode{Value: v}
 		case ast.AsQuery:
 			v = domain.AsQuery{Value: v}
+		case ast.AsForm:
+			v = domain.AsForm{Value: v}
+		case ast.AsXML:
+			v = domain.AsXML{Value: v}
 		}
 	}
 
@@ -177,6 +177,16 @@ func TestQueryParser(t *testing.T) {
 					from sidekick in hero.sidekick
 			`,
 		},
+		{
+			"Query with use modifiers",
+			domain.Query{
+				Use:        map[string]interface{}{"timeout": 200, "partial-on-timeout": true},
+				Statements: []domain.Statement{{Method: "from", Resource: "hero"}},
+			},
+			`use timeout = 200
+			use partial-on-timeout = true
+			from hero`,
+		},
 		{
 			"Full query",
 			domain.Query{
@@ -317,6 +327,16 @@ func TestQueryParser(t *testing.T) {
 			domain.Query{Statements: []domain.Statement{{Method: "to", Resource: "hero", With: domain.Params{Values: map[string]interface{}{"id": 1, "context": domain.AsQuery{Value: "crossover"}}}}}},
 			`to hero with id = 1, context = "crossover" -> as-query`,
 		},
+		{
+			"Unique to statement with default body encoded as form",
+			domain.Query{Statements: []domain.Statement{{Method: "to", Resource: "hero", With: domain.Params{Body: domain.AsForm{Value: domain.Variable{"hero"}}, Values: map[string]interface{}{}}}}},
+			`to hero with $hero -> as-form`,
+		},
+		{
+			"Unique to statement with parameter encoded as xml body",
+			domain.Query{Statements: []domain.Statement{{Method: "to", Resource: "hero", With: domain.Params{Values: map[string]interface{}{"hero": domain.AsBody{Value: domain.AsXML{Value: map[string]interface{}{"name": "batman"}}}}}}}},
+			`to hero with hero = {"name": "batman"} -> as-xml -> as-body`,
+		},
 	}
 
 	queryParser, err := parser.New()
@@ -0,0 +1,138 @@
+// Package accesslog collects what happened during a transaction,
+// like the query executed and the statements sent to upstream APIs,
+// so it can be logged in a single entry once the transaction ends.
+package accesslog
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+)
+
+// Statement is the execution of a statement
+// relative to the start of the transaction.
+type Statement struct {
+	Resource string
+	Method   string
+	Status   int
+	Success  bool
+	Offset   time.Duration
+	Duration time.Duration
+}
+
+// Entry holds the information about a transaction. All methods
+// are safe to call on a nil Entry, which is returned when the
+// access log is disabled, and from concurrent goroutines.
+type Entry struct {
+	start time.Time
+
+	mu         sync.Mutex
+	options    restql.QueryOptions
+	hasQuery   bool
+	statements []Statement
+}
+
+// NewEntry constructs an Entry for a transaction starting now.
+func NewEntry() *Entry {
+	return &Entry{start: time.Now()}
+}
+
+type entryKey struct{}
+
+// WithEntry returns a context holding the entry.
+func WithEntry(ctx context.Context, entry *Entry) context.Context {
+	return context.WithValue(ctx, entryKey{}, entry)
+}
+
+// FromContext returns the entry of the current transaction, if any.
+func FromContext(ctx context.Context) *Entry {
+	entry, _ := ctx.Value(entryKey{}).(*Entry)
+	return entry
+}
+
+// Duration returns the time elapsed since the transaction started.
+func (e *Entry) Duration() time.Duration {
+	if e == nil {
+		return 0
+	}
+
+	return time.Since(e.start)
+}
+
+// SetQuery records the identity of the query evaluated. When a transaction
+// evaluates many queries, as in batch requests, only the first is kept.
+func (e *Entry) SetQuery(options restql.QueryOptions) {
+	if e == nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.hasQuery {
+		return
+	}
+
+	e.options = options
+	e.hasQuery = true
+}
+
+// Query returns the identity of the query evaluated and whether there was one.
+func (e *Entry) Query() (restql.QueryOptions, bool) {
+	if e == nil {
+		return restql.QueryOptions{}, false
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.options, e.hasQuery
+}
+
+// AddStatement records a statement that started at the given time.
+func (e *Entry) AddStatement(resource string, method string, status int, success bool, start time.Time, duration time.Duration) {
+	if e == nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.statements = append(e.statements, Statement{
+		Resource: resource,
+		Method:   method,
+		Status:   status,
+		Success:  success,
+		Offset:   start.Sub(e.start),
+		Duration: duration,
+	})
+}
+
+// Statements returns the statements executed, in the order they finished.
+func (e *Entry) Statements() []Statement {
+	if e == nil {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	statements := make([]Statement, len(e.statements))
+	copy(statements, e.statements)
+
+	return statements
+}
+
+// UpstreamFailures returns the number of unsuccessful statements.
+func (e *Entry) UpstreamFailures() int {
+	count := 0
+	for _, s := range e.Statements() {
+		if !s.Success {
+			count++
+		}
+	}
+
+	return count
+}
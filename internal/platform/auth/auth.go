@@ -0,0 +1,257 @@
+// Package auth verifies the credentials sent to the query endpoints,
+// either JSON Web Tokens or API keys, and decides which queries
+// the authenticated client is allowed to run.
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+// ClaimVariablePrefix is prepended to the name of the verified
+// JWT claims when they are exposed as query variables.
+const ClaimVariablePrefix = "jwt."
+
+// Errors returned when authenticating or authorizing a client
+var (
+	ErrUnauthenticated = errors.New("missing or invalid credentials")
+	ErrForbidden       = errors.New("credentials not allowed to run the query")
+)
+
+// APIKey is a static credential allowed to run the saved queries
+// matching its patterns, given as `namespace/query`, `namespace/*`
+// or `*`, and the ad-hoc ones if AdHoc is set.
+type APIKey struct {
+	Name    string
+	Key     string
+	Queries []string
+	AdHoc   bool
+}
+
+// Options defines the credentials accepted. JWTs are verified with
+// the HMAC secrets and the keys of the JWKS files, and are allowed
+// to run any query.
+type Options struct {
+	Secrets   []string
+	JWKSFiles []string
+	Issuer    string
+	Audience  string
+	Leeway    time.Duration
+	APIKeys   []APIKey
+}
+
+// Credentials are the values sent by the client:
+// the bearer token and the API key header.
+type Credentials struct {
+	BearerToken string
+	APIKey      string
+}
+
+// Authenticator verifies client credentials.
+type Authenticator struct {
+	jwt     *jwtVerifier
+	apiKeys []APIKey
+}
+
+// NewAuthenticator constructs an Authenticator instance,
+// failing if any JWKS file cannot be loaded.
+func NewAuthenticator(options Options) (*Authenticator, error) {
+	var keys []verificationKey
+	for _, secret := range options.Secrets {
+		if secret != "" {
+			keys = append(keys, verificationKey{secret: []byte(secret)})
+		}
+	}
+
+	for _, path := range options.JWKSFiles {
+		fileKeys, err := readJWKSFile(path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, fileKeys...)
+	}
+
+	a := &Authenticator{apiKeys: options.APIKeys}
+	if len(keys) > 0 {
+		a.jwt = &jwtVerifier{
+			keys:     keys,
+			issuer:   options.Issuer,
+			audience: options.Audience,
+			leeway:   options.Leeway,
+			now:      time.Now,
+		}
+	}
+
+	return a, nil
+}
+
+// Identity is an authenticated client
+// along with the queries it can run.
+type Identity struct {
+	Principal restql.Principal
+	grant     grant
+}
+
+// Authenticate verifies the credentials, the API key taking
+// precedence over the bearer token when both are present.
+func (a *Authenticator) Authenticate(credentials Credentials) (Identity, error) {
+	if credentials.APIKey != "" && len(a.apiKeys) > 0 {
+		for _, key := range a.apiKeys {
+			if subtle.ConstantTimeCompare([]byte(key.Key), []byte(credentials.APIKey)) == 1 {
+				return Identity{
+					Principal: restql.Principal{Method: restql.AuthMethodAPIKey, Subject: key.Name},
+					grant:     grant{queries: key.Queries, adHoc: key.AdHoc},
+				}, nil
+			}
+		}
+
+		return Identity{}, errors.Wrap(ErrUnauthenticated, "unknown api key")
+	}
+
+	if credentials.BearerToken != "" && a.jwt != nil {
+		claims, err := a.jwt.verify(credentials.BearerToken)
+		if err != nil {
+			return Identity{}, errors.Wrap(ErrUnauthenticated, err.Error())
+		}
+
+		subject, _ := claims["sub"].(string)
+		return Identity{
+			Principal: restql.Principal{Method: restql.AuthMethodJWT, Subject: subject, Claims: claims},
+			grant:     grant{all: true},
+		}, nil
+	}
+
+	return Identity{}, errors.Wrap(ErrUnauthenticated, "no credentials")
+}
+
+// grant defines the queries an identity can run.
+type grant struct {
+	all     bool
+	adHoc   bool
+	queries []string
+}
+
+func (g grant) allows(options restql.QueryOptions) bool {
+	if g.all {
+		return true
+	}
+
+	if options.Namespace == "" && options.Id == "" {
+		return g.adHoc
+	}
+
+	for _, pattern := range g.queries {
+		if pattern == "*" {
+			return true
+		}
+
+		namespace, query := splitQueryPattern(pattern)
+		if namespace == options.Namespace && (query == "*" || query == options.Id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func splitQueryPattern(pattern string) (string, string) {
+	i := strings.LastIndex(pattern, "/")
+	if i < 0 {
+		return pattern, "*"
+	}
+
+	return pattern[:i], pattern[i+1:]
+}
+
+type grantKey struct{}
+
+// WithIdentity stores the authenticated client in a child context,
+// making the principal available to plugins.
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	ctx = restql.WithPrincipal(ctx, identity.Principal)
+	return context.WithValue(ctx, grantKey{}, identity.grant)
+}
+
+// Authorize checks whether the client authenticated on the context
+// can run the query. When authentication is not required every
+// query is allowed to contexts without a client, otherwise they
+// are rejected, since the credentials were never verified.
+func Authorize(ctx context.Context, options restql.QueryOptions, required bool) error {
+	g, ok := ctx.Value(grantKey{}).(grant)
+	if !ok {
+		if required {
+			return errors.Wrap(ErrUnauthenticated, "no client identity")
+		}
+		return nil
+	}
+
+	if g.allows(options) {
+		return nil
+	}
+
+	if options.Namespace == "" && options.Id == "" {
+		return errors.Wrap(ErrForbidden, "ad-hoc queries")
+	}
+
+	return errors.Wrapf(ErrForbidden, "query %s/%s", options.Namespace, options.Id)
+}
+
+// WithClaimVariables returns the query input with the verified JWT
+// claims as parameters prefixed by ClaimVariablePrefix. Parameters,
+// headers and body fields sent by the client with the same prefix
+// are removed, so claims can never be forged through them.
+func WithClaimVariables(ctx context.Context, input restql.QueryInput) restql.QueryInput {
+	params := make(map[string]interface{}, len(input.Params))
+	for name, value := range input.Params {
+		if !hasClaimPrefix(name) {
+			params[name] = value
+		}
+	}
+
+	headers := make(map[string]string, len(input.Headers))
+	for name, value := range input.Headers {
+		if !hasClaimPrefix(name) {
+			headers[name] = value
+		}
+	}
+
+	if body, ok := input.Body.(map[string]interface{}); ok {
+		fields := make(map[string]interface{}, len(body))
+		for name, value := range body {
+			if !hasClaimPrefix(name) {
+				fields[name] = value
+			}
+		}
+		input.Body = fields
+	}
+
+	if p, ok := restql.GetPrincipal(ctx); ok {
+		for name, value := range p.Claims {
+			params[ClaimVariablePrefix+name] = value
+		}
+	}
+
+	input.Params = params
+	input.Headers = headers
+
+	return input
+}
+
+func hasClaimPrefix(name string) bool {
+	return len(name) >= len(ClaimVariablePrefix) && strings.EqualFold(name[:len(ClaimVariablePrefix)], ClaimVariablePrefix)
+}
+
+// BearerToken extracts the token from an Authorization header value.
+func BearerToken(authorization string) string {
+	const prefix = "Bearer "
+	if len(authorization) <= len(prefix) || !strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return ""
+	}
+
+	return strings.TrimSpace(authorization[len(prefix):])
+}
@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/pkg/errors"
+)
+
+func TestAuthenticate(t *testing.T) {
+	a, err := NewAuthenticator(Options{
+		Secrets: []string{"my-secret"},
+		APIKeys: []APIKey{{Name: "mobile", Key: "mobile-key", Queries: []string{"dc/heroes"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := signHMAC(t, "my-secret", "", map[string]interface{}{"sub": "batman"})
+
+	tests := []struct {
+		name          string
+		credentials   Credentials
+		expected      restql.Principal
+		expectedError error
+	}{
+		{
+			name:        "authenticates api key",
+			credentials: Credentials{APIKey: "mobile-key"},
+			expected:    restql.Principal{Method: restql.AuthMethodAPIKey, Subject: "mobile"},
+		},
+		{
+			name:        "authenticates jwt",
+			credentials: Credentials{BearerToken: token},
+			expected:    restql.Principal{Method: restql.AuthMethodJWT, Subject: "batman", Claims: map[string]interface{}{"sub": "batman"}},
+		},
+		{
+			name:          "rejects unknown api key even with valid jwt",
+			credentials:   Credentials{APIKey: "other-key", BearerToken: token},
+			expectedError: ErrUnauthenticated,
+		},
+		{
+			name:          "rejects invalid jwt",
+			credentials:   Credentials{BearerToken: signHMAC(t, "other-secret", "", map[string]interface{}{"sub": "joker"})},
+			expectedError: ErrUnauthenticated,
+		},
+		{
+			name:          "rejects request without credentials",
+			credentials:   Credentials{},
+			expectedError: ErrUnauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := a.Authenticate(tt.credentials)
+
+			if tt.expectedError != nil {
+				test.Equal(t, errors.Is(err, tt.expectedError), true)
+				return
+			}
+
+			test.VerifyError(t, err)
+			test.Equal(t, got.Principal, tt.expected)
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name     string
+		identity *Identity
+		required bool
+		options  restql.QueryOptions
+		allowed  bool
+		expected error
+	}{
+		{
+			name:    "allows everything without authentication",
+			options: restql.QueryOptions{Namespace: "dc", Id: "heroes"},
+			allowed: true,
+		},
+		{
+			name:     "denies context without identity when authentication is required",
+			required: true,
+			options:  restql.QueryOptions{Namespace: "dc", Id: "heroes"},
+			allowed:  false,
+			expected: ErrUnauthenticated,
+		},
+		{
+			name:     "allows identity when authentication is required",
+			identity: &Identity{grant: grant{all: true}},
+			required: true,
+			options:  restql.QueryOptions{Namespace: "dc", Id: "heroes"},
+			allowed:  true,
+		},
+		{
+			name:     "allows everything to jwt",
+			identity: &Identity{grant: grant{all: true}},
+			options:  restql.QueryOptions{},
+			allowed:  true,
+		},
+		{
+			name:     "allows query matching pattern",
+			identity: &Identity{grant: grant{queries: []string{"dc/heroes"}}},
+			options:  restql.QueryOptions{Namespace: "dc", Id: "heroes", Revision: 2},
+			allowed:  true,
+		},
+		{
+			name:     "allows query in namespace pattern",
+			identity: &Identity{grant: grant{queries: []string{"marvel/heroes", "dc/*"}}},
+			options:  restql.QueryOptions{Namespace: "dc", Id: "villains"},
+			allowed:  true,
+		},
+		{
+			name:     "allows any saved query with wildcard",
+			identity: &Identity{grant: grant{queries: []string{"*"}}},
+			options:  restql.QueryOptions{Namespace: "dc", Id: "villains"},
+			allowed:  true,
+		},
+		{
+			name:     "denies query not matching patterns",
+			identity: &Identity{grant: grant{queries: []string{"dc/heroes"}}},
+			options:  restql.QueryOptions{Namespace: "dc", Id: "villains"},
+			allowed:  false,
+		},
+		{
+			name:     "denies ad-hoc query",
+			identity: &Identity{grant: grant{queries: []string{"*"}}},
+			options:  restql.QueryOptions{Tenant: "DC"},
+			allowed:  false,
+		},
+		{
+			name:     "allows ad-hoc query",
+			identity: &Identity{grant: grant{adHoc: true}},
+			options:  restql.QueryOptions{Tenant: "DC"},
+			allowed:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.identity != nil {
+				ctx = WithIdentity(ctx, *tt.identity)
+			}
+
+			err := Authorize(ctx, tt.options, tt.required)
+
+			test.Equal(t, err == nil, tt.allowed)
+			if !tt.allowed {
+				expected := tt.expected
+				if expected == nil {
+					expected = ErrForbidden
+				}
+				test.Equal(t, errors.Is(err, expected), true)
+			}
+		})
+	}
+}
+
+func TestWithClaimVariables(t *testing.T) {
+	input := restql.QueryInput{
+		Params:  map[string]interface{}{"id": "1", "jwt.sub": "joker"},
+		Headers: map[string]string{"Accept": "application/json", "Jwt.role": "admin"},
+		Body:    map[string]interface{}{"name": "batman", "JWT.sub": "joker"},
+	}
+
+	t.Run("strips client values without authentication", func(t *testing.T) {
+		got := WithClaimVariables(context.Background(), input)
+
+		test.Equal(t, got.Params, map[string]interface{}{"id": "1"})
+		test.Equal(t, got.Headers, map[string]string{"Accept": "application/json"})
+		test.Equal(t, got.Body, map[string]interface{}{"name": "batman"})
+	})
+
+	t.Run("adds verified claims", func(t *testing.T) {
+		ctx := WithIdentity(context.Background(), Identity{
+			Principal: restql.Principal{Method: restql.AuthMethodJWT, Subject: "batman", Claims: map[string]interface{}{"sub": "batman", "role": "hero"}},
+		})
+
+		got := WithClaimVariables(ctx, input)
+
+		test.Equal(t, got.Params, map[string]interface{}{"id": "1", "jwt.sub": "batman", "jwt.role": "hero"})
+		test.Equal(t, input.Params["jwt.sub"], "joker")
+	})
+}
+
+func TestBearerToken(t *testing.T) {
+	test.Equal(t, BearerToken("Bearer abc.def.ghi"), "abc.def.ghi")
+	test.Equal(t, BearerToken("bearer abc.def.ghi"), "abc.def.ghi")
+	test.Equal(t, BearerToken("Basic dXNlcjpwYXNz"), "")
+	test.Equal(t, BearerToken("Bearer "), "")
+}
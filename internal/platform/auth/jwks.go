@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// Key types supported in JWKS files
+const (
+	keyTypeRSA       = "RSA"
+	keyTypeEC        = "EC"
+	keyTypeSymmetric = "oct"
+)
+
+var errInvalidJWK = errors.New("invalid json web key")
+
+// verificationKey is a key able to verify token signatures,
+// either a public key or an HMAC secret.
+type verificationKey struct {
+	id     string
+	alg    string
+	public crypto.PublicKey
+	secret []byte
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+// readJWKSFile loads the signature keys of a JWKS file,
+// ignoring the ones meant for encryption.
+func readJWKSFile(path string) ([]verificationKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read jwks file %s", path)
+	}
+
+	var set jsonWebKeySet
+	err = json.Unmarshal(data, &set)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse jwks file %s", path)
+	}
+
+	keys := make([]verificationKey, 0, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := parseJWK(jwk)
+		if err != nil {
+			return nil, errors.Wrapf(err, "key %q in jwks file %s", jwk.Kid, path)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func parseJWK(jwk jsonWebKey) (verificationKey, error) {
+	key := verificationKey{id: jwk.Kid, alg: jwk.Alg}
+
+	switch jwk.Kty {
+	case keyTypeRSA:
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return verificationKey{}, err
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return verificationKey{}, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return verificationKey{}, errors.Wrap(errInvalidJWK, "rsa exponent too large")
+		}
+
+		key.public = &rsa.PublicKey{N: n, E: int(e.Int64())}
+	case keyTypeEC:
+		curve, err := ellipticCurve(jwk.Crv)
+		if err != nil {
+			return verificationKey{}, err
+		}
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return verificationKey{}, err
+		}
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return verificationKey{}, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return verificationKey{}, errors.Wrap(errInvalidJWK, "point is not on curve")
+		}
+
+		key.public = &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+	case keyTypeSymmetric:
+		secret, err := base64.RawURLEncoding.DecodeString(jwk.K)
+		if err != nil || len(secret) == 0 {
+			return verificationKey{}, errors.Wrap(errInvalidJWK, "invalid symmetric key")
+		}
+
+		key.secret = secret
+	default:
+		return verificationKey{}, errors.Wrapf(errInvalidJWK, "unsupported key type %q", jwk.Kty)
+	}
+
+	return key, nil
+}
+
+func ellipticCurve(name string) (elliptic.Curve, error) {
+	switch name {
+	case "P-256":
+		return elliptic.P256(), nil
+	case "P-384":
+		return elliptic.P384(), nil
+	case "P-521":
+		return elliptic.P521(), nil
+	default:
+		return nil, errors.Wrapf(errInvalidJWK, "unsupported curve %q", name)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(data) == 0 {
+		return nil, errors.Wrap(errInvalidJWK, "invalid key parameter")
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
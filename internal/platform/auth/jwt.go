@@ -0,0 +1,263 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"time"
+
+	// Register the hash functions used by the signature algorithms
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	errMalformedToken        = errors.New("malformed token")
+	errUnsupportedAlgorithm  = errors.New("unsupported signing algorithm")
+	errInvalidSignature      = errors.New("invalid token signature")
+	errTokenExpired          = errors.New("token expired")
+	errTokenNotYetValid      = errors.New("token not yet valid")
+	errInvalidIssuer         = errors.New("invalid token issuer")
+	errInvalidAudience       = errors.New("invalid token audience")
+	errNoVerificationKeyType = errors.New("no key available for the token algorithm")
+)
+
+type signatureFamily int
+
+const (
+	familyHMAC signatureFamily = iota
+	familyRSA
+	familyRSAPSS
+	familyECDSA
+)
+
+type signatureAlgorithm struct {
+	family signatureFamily
+	hash   crypto.Hash
+}
+
+var signatureAlgorithms = map[string]signatureAlgorithm{
+	"HS256": {familyHMAC, crypto.SHA256},
+	"HS384": {familyHMAC, crypto.SHA384},
+	"HS512": {familyHMAC, crypto.SHA512},
+	"RS256": {familyRSA, crypto.SHA256},
+	"RS384": {familyRSA, crypto.SHA384},
+	"RS512": {familyRSA, crypto.SHA512},
+	"PS256": {familyRSAPSS, crypto.SHA256},
+	"PS384": {familyRSAPSS, crypto.SHA384},
+	"PS512": {familyRSAPSS, crypto.SHA512},
+	"ES256": {familyECDSA, crypto.SHA256},
+	"ES384": {familyECDSA, crypto.SHA384},
+	"ES512": {familyECDSA, crypto.SHA512},
+}
+
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// jwtVerifier validates the signature and the
+// registered claims of JSON Web Tokens.
+type jwtVerifier struct {
+	keys     []verificationKey
+	issuer   string
+	audience string
+	leeway   time.Duration
+	now      func() time.Time
+}
+
+// verify returns the claims of a valid token.
+func (v *jwtVerifier) verify(token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errMalformedToken
+	}
+
+	var header tokenHeader
+	err := decodeSegment(parts[0], &header)
+	if err != nil {
+		return nil, err
+	}
+
+	alg, found := signatureAlgorithms[header.Alg]
+	if !found {
+		return nil, errors.Wrapf(errUnsupportedAlgorithm, "algorithm %q", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errMalformedToken
+	}
+
+	err = v.verifySignature(header, alg, []byte(parts[0]+"."+parts[1]), signature)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims map[string]interface{}
+	err = decodeSegment(parts[1], &claims)
+	if err != nil {
+		return nil, err
+	}
+
+	err = v.validateClaims(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// verifySignature tries every key compatible with the algorithm,
+// restricted to the one with the same id when the token has one.
+func (v *jwtVerifier) verifySignature(header tokenHeader, alg signatureAlgorithm, signed []byte, signature []byte) error {
+	h := alg.hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	candidates := 0
+	for _, key := range v.keys {
+		if header.Kid != "" && key.id != "" && key.id != header.Kid {
+			continue
+		}
+		if key.alg != "" && key.alg != header.Alg {
+			continue
+		}
+
+		var ok, compatible bool
+		switch alg.family {
+		case familyHMAC:
+			if key.secret != nil {
+				compatible = true
+				mac := hmac.New(alg.hash.New, key.secret)
+				mac.Write(signed)
+				ok = hmac.Equal(mac.Sum(nil), signature)
+			}
+		case familyRSA:
+			if pub, isRSA := key.public.(*rsa.PublicKey); isRSA {
+				compatible = true
+				ok = rsa.VerifyPKCS1v15(pub, alg.hash, digest, signature) == nil
+			}
+		case familyRSAPSS:
+			if pub, isRSA := key.public.(*rsa.PublicKey); isRSA {
+				compatible = true
+				ok = rsa.VerifyPSS(pub, alg.hash, digest, signature, nil) == nil
+			}
+		case familyECDSA:
+			if pub, isEC := key.public.(*ecdsa.PublicKey); isEC {
+				compatible = true
+				ok = verifyECDSA(pub, digest, signature)
+			}
+		}
+
+		if compatible {
+			candidates++
+		}
+		if ok {
+			return nil
+		}
+	}
+
+	if candidates == 0 {
+		return errNoVerificationKeyType
+	}
+
+	return errInvalidSignature
+}
+
+// verifyECDSA checks a signature in the JWS format,
+// which is the concatenation of r and s.
+func verifyECDSA(pub *ecdsa.PublicKey, digest []byte, signature []byte) bool {
+	size := (pub.Curve.Params().BitSize + 7) / 8
+	if len(signature) != 2*size {
+		return false
+	}
+
+	r := new(big.Int).SetBytes(signature[:size])
+	s := new(big.Int).SetBytes(signature[size:])
+
+	return ecdsa.Verify(pub, digest, r, s)
+}
+
+func (v *jwtVerifier) validateClaims(claims map[string]interface{}) error {
+	now := v.now()
+
+	if exp, found := claims["exp"]; found {
+		expiresAt, ok := numericDate(exp)
+		if !ok {
+			return errors.Wrap(errMalformedToken, "invalid exp claim")
+		}
+		if now.After(expiresAt.Add(v.leeway)) {
+			return errTokenExpired
+		}
+	}
+
+	if nbf, found := claims["nbf"]; found {
+		notBefore, ok := numericDate(nbf)
+		if !ok {
+			return errors.Wrap(errMalformedToken, "invalid nbf claim")
+		}
+		if now.Add(v.leeway).Before(notBefore) {
+			return errTokenNotYetValid
+		}
+	}
+
+	if v.issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != v.issuer {
+			return errInvalidIssuer
+		}
+	}
+
+	if v.audience != "" && !hasAudience(claims["aud"], v.audience) {
+		return errInvalidAudience
+	}
+
+	return nil
+}
+
+func numericDate(value interface{}) (time.Time, bool) {
+	seconds, ok := value.(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return time.Unix(0, int64(seconds*float64(time.Second))), true
+}
+
+// hasAudience checks the aud claim, which
+// can be either a string or a list of them.
+func hasAudience(aud interface{}, expected string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == expected
+	case []interface{}:
+		for _, a := range aud {
+			if s, ok := a.(string); ok && s == expected {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func decodeSegment(segment string, target interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errMalformedToken
+	}
+
+	err = json.Unmarshal(data, target)
+	if err != nil {
+		return errMalformedToken
+	}
+
+	return nil
+}
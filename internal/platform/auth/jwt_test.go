@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/pkg/errors"
+)
+
+func TestJWTVerification(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwksFile := writeJWKS(t, map[string]interface{}{
+		"keys": []map[string]interface{}{
+			{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": encodeBigInt(rsaKey.N), "e": encodeBigInt(big.NewInt(int64(rsaKey.E)))},
+			{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": encodeBigInt(ecKey.X), "y": encodeBigInt(ecKey.Y)},
+			{"kty": "RSA", "kid": "enc-1", "use": "enc", "n": "invalid"},
+		},
+	})
+
+	now := time.Unix(1600000000, 0)
+	claims := map[string]interface{}{"sub": "batman", "iss": "wayne", "aud": []string{"restql"}, "exp": now.Add(time.Minute).Unix()}
+
+	a, err := NewAuthenticator(Options{Secrets: []string{"my-secret"}, JWKSFiles: []string{jwksFile}, Issuer: "wayne", Audience: "restql", Leeway: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.jwt.now = func() time.Time { return now }
+
+	tests := []struct {
+		name          string
+		token         string
+		expectedError error
+	}{
+		{"accepts hmac token", signHMAC(t, "my-secret", "", claims), nil},
+		{"accepts rsa token", signRSA(t, rsaKey, "rsa-1", claims), nil},
+		{"accepts ecdsa token", signECDSA(t, ecKey, "ec-1", claims), nil},
+		{"accepts rsa token without key id", signRSA(t, rsaKey, "", claims), nil},
+		{"rejects token with wrong secret", signHMAC(t, "other-secret", "", claims), errInvalidSignature},
+		{"rejects token with unknown key id", signRSA(t, rsaKey, "rsa-2", claims), errNoVerificationKeyType},
+		{"rejects unsigned token", sign(t, "none", "", claims, func([]byte) []byte { return nil }), errUnsupportedAlgorithm},
+		{"rejects malformed token", "not-a-token", errMalformedToken},
+		{"rejects expired token", signHMAC(t, "my-secret", "", withClaim(claims, "exp", now.Add(-time.Minute).Unix())), errTokenExpired},
+		{"accepts expired token within leeway", signHMAC(t, "my-secret", "", withClaim(claims, "exp", now.Add(-time.Second).Unix())), nil},
+		{"rejects token not yet valid", signHMAC(t, "my-secret", "", withClaim(claims, "nbf", now.Add(time.Minute).Unix())), errTokenNotYetValid},
+		{"rejects token from other issuer", signHMAC(t, "my-secret", "", withClaim(claims, "iss", "joker")), errInvalidIssuer},
+		{"rejects token for other audience", signHMAC(t, "my-secret", "", withClaim(claims, "aud", "other")), errInvalidAudience},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := a.jwt.verify(tt.token)
+
+			if tt.expectedError != nil {
+				test.Equal(t, errors.Is(err, tt.expectedError), true)
+				return
+			}
+
+			test.VerifyError(t, err)
+			test.Equal(t, got["sub"], "batman")
+			test.Equal(t, got["iss"], "wayne")
+		})
+	}
+}
+
+func TestReadJWKSFileFailures(t *testing.T) {
+	_, err := NewAuthenticator(Options{JWKSFiles: []string{filepath.Join(os.TempDir(), "restql-missing-jwks.json")}})
+	test.NotEqual(t, err, nil)
+
+	path := writeJWKS(t, map[string]interface{}{
+		"keys": []map[string]interface{}{{"kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"}},
+	})
+	_, err = NewAuthenticator(Options{JWKSFiles: []string{path}})
+	test.Equal(t, errors.Is(err, errInvalidJWK), true)
+}
+
+func writeJWKS(t *testing.T, jwks interface{}) string {
+	data, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "restql-jwks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "jwks.json")
+	err = ioutil.WriteFile(path, data, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func withClaim(claims map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(claims)+1)
+	for k, v := range claims {
+		result[k] = v
+	}
+	result[key] = value
+
+	return result
+}
+
+func signHMAC(t *testing.T, secret string, kid string, claims map[string]interface{}) string {
+	return sign(t, "HS256", kid, claims, func(signed []byte) []byte {
+		mac := hmac.New(crypto.SHA256.New, []byte(secret))
+		mac.Write(signed)
+		return mac.Sum(nil)
+	})
+}
+
+func signRSA(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	return sign(t, "RS256", kid, claims, func(signed []byte) []byte {
+		digest := sha256Sum(signed)
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signature
+	})
+}
+
+func signECDSA(t *testing.T, key *ecdsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	return sign(t, "ES256", kid, claims, func(signed []byte) []byte {
+		r, s, err := ecdsa.Sign(rand.Reader, key, sha256Sum(signed))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signature := make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+		return signature
+	})
+}
+
+func sign(t *testing.T, alg string, kid string, claims map[string]interface{}, signer func(signed []byte) []byte) string {
+	header := map[string]string{"alg": alg, "typ": "JWT"}
+	if kid != "" {
+		header["kid"] = kid
+	}
+
+	signed := encodeSegment(t, header) + "." + encodeSegment(t, claims)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signer([]byte(signed)))
+}
+
+func encodeSegment(t *testing.T, value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func encodeBigInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func sha256Sum(data []byte) []byte {
+	h := crypto.SHA256.New()
+	h.Write(data)
+	return h.Sum(nil)
+}
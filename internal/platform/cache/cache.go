@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/metrics"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 
 	"github.com/bluele/gcache"
@@ -43,6 +44,14 @@ func WithRefreshQueueLength(length int) Option {
 	}
 }
 
+// WithName sets the name used to identify
+// the cache on the lookup metrics.
+func WithName(name string) Option {
+	return func(c *Cache) {
+		c.name = name
+	}
+}
+
 // WithExpiration sets the time to live of cache entries.
 func WithExpiration(expiration time.Duration) Option {
 	return func(c *Cache) {
@@ -57,6 +66,7 @@ func WithExpiration(expiration time.Duration) Option {
 // routine, never deleting the old value, only replacing it.
 type Cache struct {
 	log                restql.Logger
+	name               string
 	gcache             gcache.Cache
 	loader             Loader
 	refreshWorkCh      chan interface{}
@@ -90,6 +100,7 @@ func New(log restql.Logger, size int, loader Loader, options ...Option) *Cache {
 // Get retrieves and entry for the given key.
 func (c *Cache) Get(ctx context.Context, key interface{}) (interface{}, error) {
 	obj, err := c.gcache.Get(key)
+	c.recordLookup(err)
 
 	switch {
 	case err == gcache.KeyNotFoundError:
@@ -120,6 +131,18 @@ func (c *Cache) Get(ctx context.Context, key interface{}) (interface{}, error) {
 	return item.value, nil
 }
 
+func (c *Cache) recordLookup(err error) {
+	if c.name == "" {
+		return
+	}
+
+	if err == nil {
+		metrics.CacheHit(c.name)
+	} else {
+		metrics.CacheMiss(c.name)
+	}
+}
+
 func (c *Cache) populate(ctx context.Context, key interface{}) (cacheItem, error) {
 	value, err := c.loader(ctx, key)
 	if err != nil {
@@ -133,6 +156,17 @@ func (c *Cache) populate(ctx context.Context, key interface{}) (cacheItem, error
 		return cacheItem{}, err
 	}
 
+	return c.set(key, value)
+}
+
+// Set stores the value for the given key,
+// without the need of calling the loader.
+func (c *Cache) Set(key interface{}, value interface{}) error {
+	_, err := c.set(key, value)
+	return err
+}
+
+func (c *Cache) set(key interface{}, value interface{}) (cacheItem, error) {
 	item := cacheItem{
 		key:   key,
 		value: value,
@@ -141,7 +175,7 @@ func (c *Cache) populate(ctx context.Context, key interface{}) (cacheItem, error
 		item.expiration = time.Now().Add(c.expiration)
 	}
 
-	err = c.gcache.Set(key, item)
+	err := c.gcache.Set(key, item)
 	if err != nil {
 		c.log.Error("failed to set value on cache", err)
 		return cacheItem{}, err
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"strings"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
@@ -112,3 +113,64 @@ func QueryCacheLoader(qr persistence.QueryReader) Loader {
 		return query, nil
 	}
 }
+
+// PersistedQueryCache is a caching wrapper around a PersistedQueryStore.
+// Since registered queries are kept in cache, they are available even
+// when the database does not support persisted queries, until evicted.
+type PersistedQueryCache struct {
+	log   restql.Logger
+	cache *Cache
+	store persistence.PersistedQueryStore
+}
+
+// NewPersistedQueryCache constructs a PersistedQueryCache instance.
+func NewPersistedQueryCache(log restql.Logger, c *Cache, store persistence.PersistedQueryStore) *PersistedQueryCache {
+	return &PersistedQueryCache{log: log, cache: c, store: store}
+}
+
+// Get returns a cached query text if present, fetching it otherwise.
+func (c *PersistedQueryCache) Get(ctx context.Context, hash string) (string, error) {
+	result, err := c.cache.Get(ctx, strings.ToLower(hash))
+	if err != nil {
+		return "", err
+	}
+
+	text, ok := result.(string)
+	if !ok {
+		log := restql.GetLogger(ctx)
+		err := errors.Errorf("invalid persisted query cache content type: %T", result)
+
+		log.Error("failed to convert cache content", err)
+		return "", err
+	}
+
+	return text, nil
+}
+
+// Register stores the query text on the store and in cache.
+func (c *PersistedQueryCache) Register(ctx context.Context, hash string, text string) error {
+	err := c.store.Register(ctx, hash, text)
+	if err != nil {
+		return err
+	}
+
+	return c.cache.Set(strings.ToLower(hash), text)
+}
+
+// PersistedQueryCacheLoader is the strategy to load
+// values for the cached persisted query store.
+func PersistedQueryCacheLoader(store persistence.PersistedQueryStore) Loader {
+	return func(ctx context.Context, key interface{}) (interface{}, error) {
+		hash, ok := key.(string)
+		if !ok {
+			return nil, errors.Errorf("invalid key type : got %T", key)
+		}
+
+		text, err := store.Get(ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+
+		return text, nil
+	}
+}
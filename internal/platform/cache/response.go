@@ -0,0 +1,177 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/metrics"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/bluele/gcache"
+	"golang.org/x/sync/singleflight"
+)
+
+const responseCacheName = "response"
+
+// ResponseEntry is a query response stored in the ResponseCache,
+// along with the identity of the saved query that produced it,
+// if any, which allows purging it.
+type ResponseEntry struct {
+	Value     interface{}
+	Namespace string
+	Query     string
+	MaxAge    time.Duration
+	storedAt  time.Time
+}
+
+func (e ResponseEntry) age() time.Duration {
+	return time.Since(e.storedAt)
+}
+
+// ResponseLoader evaluates the query when its response is not found
+// in cache or is stale. Responses with no MaxAge are not stored.
+type ResponseLoader func(ctx context.Context) (ResponseEntry, error)
+
+// ResponseCache is an in-memory container for query responses that uses
+// a LRU eviction strategy. Each entry is fresh during its own MaxAge,
+// after which it is still returned during the stale-while-revalidate
+// window while being refreshed in background. Concurrent misses for
+// the same key share a single load.
+type ResponseCache struct {
+	log                  restql.Logger
+	gcache               gcache.Cache
+	staleWhileRevalidate time.Duration
+	loads                singleflight.Group
+
+	mu           sync.Mutex
+	revalidating map[string]bool
+}
+
+// NewResponseCache constructs a ResponseCache instance.
+func NewResponseCache(log restql.Logger, size int, staleWhileRevalidate time.Duration) *ResponseCache {
+	return &ResponseCache{
+		log:                  log,
+		gcache:               gcache.New(size).LRU().Build(),
+		staleWhileRevalidate: staleWhileRevalidate,
+		revalidating:         make(map[string]bool),
+	}
+}
+
+// Get returns the cached response and its age, loading and
+// storing it when it is absent or too old to be served.
+func (c *ResponseCache) Get(ctx context.Context, key string, load ResponseLoader) (interface{}, time.Duration, error) {
+	entry, found := c.lookup(key)
+	if found {
+		age := entry.age()
+
+		switch {
+		case age < entry.MaxAge:
+			metrics.CacheHit(responseCacheName)
+			return entry.Value, age, nil
+		case age < entry.MaxAge+c.staleWhileRevalidate:
+			metrics.CacheHit(responseCacheName)
+			c.revalidate(ctx, key, load)
+			return entry.Value, age, nil
+		}
+	}
+	metrics.CacheMiss(responseCacheName)
+
+	value, err, _ := c.loads.Do(key, func() (interface{}, error) {
+		entry, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		c.store(key, entry)
+		return entry.Value, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return value, 0, nil
+}
+
+// Purge removes the responses of saved queries in the given
+// namespace and, when informed, with the given query name.
+// If no namespace is informed every response is removed.
+// It returns the number of removed entries.
+func (c *ResponseCache) Purge(namespace string, query string) int {
+	if namespace == "" {
+		count := c.gcache.Len(false)
+		c.gcache.Purge()
+		return count
+	}
+
+	count := 0
+	for key, value := range c.gcache.GetALL(false) {
+		entry, ok := value.(ResponseEntry)
+		if !ok || entry.Namespace != namespace || (query != "" && entry.Query != query) {
+			continue
+		}
+
+		if c.gcache.Remove(key) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (c *ResponseCache) lookup(key string) (ResponseEntry, bool) {
+	value, err := c.gcache.Get(key)
+	if err != nil {
+		return ResponseEntry{}, false
+	}
+
+	entry, ok := value.(ResponseEntry)
+	return entry, ok
+}
+
+func (c *ResponseCache) store(key string, entry ResponseEntry) {
+	if entry.MaxAge <= 0 {
+		c.gcache.Remove(key)
+		return
+	}
+
+	entry.storedAt = time.Now()
+	err := c.gcache.Set(key, entry)
+	if err != nil {
+		c.log.Error("failed to set response on cache", err)
+	}
+}
+
+// revalidate loads the response in background, making sure
+// only one revalidation per key happens at the same time.
+// It keeps the values of the request context, like the client
+// identity and the tracing span, but not its cancellation,
+// since the request finishes before the revalidation.
+func (c *ResponseCache) revalidate(ctx context.Context, key string, load ResponseLoader) {
+	c.mu.Lock()
+	if c.revalidating[key] {
+		c.mu.Unlock()
+		return
+	}
+	c.revalidating[key] = true
+	c.mu.Unlock()
+
+	log := restql.GetLogger(ctx)
+	revalidationCtx := domain.DetachContext(ctx)
+
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			delete(c.revalidating, key)
+			c.mu.Unlock()
+		}()
+
+		entry, err := load(revalidationCtx)
+		if err != nil {
+			log.Debug("failed to revalidate cached response", "error", err)
+			return
+		}
+
+		c.store(key, entry)
+	}()
+}
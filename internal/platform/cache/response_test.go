@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestResponseCache(t *testing.T) {
+	var loads int32
+	load := func(maxAge time.Duration) ResponseLoader {
+		return func(ctx context.Context) (ResponseEntry, error) {
+			n := atomic.AddInt32(&loads, 1)
+			return ResponseEntry{Value: int(n), Namespace: "dc", Query: "heroes", MaxAge: maxAge}, nil
+		}
+	}
+
+	t.Run("should return fresh response from cache", func(t *testing.T) {
+		atomic.StoreInt32(&loads, 0)
+		c := NewResponseCache(test.NoOpLogger, 10, 0)
+
+		first, _, err := c.Get(context.Background(), "key", load(time.Minute))
+		test.VerifyError(t, err)
+		second, _, err := c.Get(context.Background(), "key", load(time.Minute))
+		test.VerifyError(t, err)
+
+		test.Equal(t, first, 1)
+		test.Equal(t, second, 1)
+	})
+
+	t.Run("should not store response without max age", func(t *testing.T) {
+		atomic.StoreInt32(&loads, 0)
+		c := NewResponseCache(test.NoOpLogger, 10, 0)
+
+		_, _, err := c.Get(context.Background(), "key", load(0))
+		test.VerifyError(t, err)
+		second, _, err := c.Get(context.Background(), "key", load(0))
+		test.VerifyError(t, err)
+
+		test.Equal(t, second, 2)
+	})
+
+	t.Run("should return stale response while revalidating", func(t *testing.T) {
+		atomic.StoreInt32(&loads, 0)
+		c := NewResponseCache(test.NoOpLogger, 10, time.Minute)
+
+		_, _, err := c.Get(context.Background(), "key", load(10*time.Millisecond))
+		test.VerifyError(t, err)
+		time.Sleep(20 * time.Millisecond)
+
+		stale, age, err := c.Get(context.Background(), "key", load(10*time.Millisecond))
+		test.VerifyError(t, err)
+		test.Equal(t, stale, 1)
+		if age < 10*time.Millisecond {
+			t.Fatalf("expected stale response age, got %s", age)
+		}
+
+		var refreshed interface{}
+		for i := 0; i < 100 && refreshed != 2; i++ {
+			time.Sleep(time.Millisecond)
+			refreshed, _, err = c.Get(context.Background(), "key", load(time.Minute))
+			test.VerifyError(t, err)
+		}
+		test.Equal(t, refreshed, 2)
+	})
+
+	t.Run("should share the load of concurrent misses", func(t *testing.T) {
+		atomic.StoreInt32(&loads, 0)
+		c := NewResponseCache(test.NoOpLogger, 10, 0)
+
+		release := make(chan struct{})
+		slowLoad := func(ctx context.Context) (ResponseEntry, error) {
+			<-release
+			return load(time.Minute)(ctx)
+		}
+
+		var wg sync.WaitGroup
+		results := make([]interface{}, 10)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], _, _ = c.Get(context.Background(), "key", slowLoad)
+			}(i)
+		}
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		test.Equal(t, atomic.LoadInt32(&loads), int32(1))
+		for _, r := range results {
+			test.Equal(t, r, 1)
+		}
+	})
+
+	t.Run("should revalidate with the request context values", func(t *testing.T) {
+		c := NewResponseCache(test.NoOpLogger, 10, time.Minute)
+		revalidated := make(chan interface{}, 1)
+		entry := func(ctx context.Context) (ResponseEntry, error) {
+			select {
+			case revalidated <- ctx.Value(testKey{}):
+			default:
+			}
+			return ResponseEntry{Value: "hero", MaxAge: 10 * time.Millisecond}, nil
+		}
+
+		_, _, err := c.Get(context.Background(), "key", func(ctx context.Context) (ResponseEntry, error) {
+			return ResponseEntry{Value: "hero", MaxAge: 10 * time.Millisecond}, nil
+		})
+		test.VerifyError(t, err)
+		time.Sleep(20 * time.Millisecond)
+
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), testKey{}, "batman"))
+		_, _, err = c.Get(ctx, "key", entry)
+		test.VerifyError(t, err)
+		cancel()
+
+		select {
+		case v := <-revalidated:
+			test.Equal(t, v, "batman")
+		case <-time.After(time.Second):
+			t.Fatalf("expected response to be revalidated")
+		}
+	})
+
+	t.Run("should purge responses by namespace and query", func(t *testing.T) {
+		c := NewResponseCache(test.NoOpLogger, 10, 0)
+		entry := func(namespace, query string) ResponseLoader {
+			return func(ctx context.Context) (ResponseEntry, error) {
+				return ResponseEntry{Value: query, Namespace: namespace, Query: query, MaxAge: time.Minute}, nil
+			}
+		}
+
+		_, _, _ = c.Get(context.Background(), "a", entry("dc", "heroes"))
+		_, _, _ = c.Get(context.Background(), "b", entry("dc", "villains"))
+		_, _, _ = c.Get(context.Background(), "c", entry("marvel", "heroes"))
+		_, _, _ = c.Get(context.Background(), "d", entry("", ""))
+
+		test.Equal(t, c.Purge("dc", "heroes"), 1)
+		test.Equal(t, c.Purge("dc", ""), 1)
+		test.Equal(t, c.Purge("", ""), 2)
+	})
+}
+
+type testKey struct{}
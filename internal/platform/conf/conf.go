@@ -2,6 +2,8 @@ package conf
 
 import (
 	"github.com/caarlos0/env/v6"
+	"github.com/imdario/mergo"
+	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
 	"io/ioutil"
 	"log"
@@ -39,6 +41,194 @@ type requestCancellationConf struct {
 	WatchInterval time.Duration `yaml:"watchInterval"`
 }
 
+type rateLimitValueConf struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+type rateLimitRuleConf struct {
+	Name           string                        `yaml:"name"`
+	Key            string                        `yaml:"key"`
+	Header         string                        `yaml:"header"`
+	TrustedProxies int                           `yaml:"trustedProxies"`
+	Rate           float64                       `yaml:"rate"`
+	Burst          int                           `yaml:"burst"`
+	Overrides      map[string]rateLimitValueConf `yaml:"overrides"`
+}
+
+type rateLimitConf struct {
+	Enable  bool                `yaml:"enable" env:"RESTQL_RATE_LIMIT_ENABLE"`
+	MaxKeys int                 `yaml:"maxKeys"`
+	Rules   []rateLimitRuleConf `yaml:"rules"`
+}
+
+type adminTokenConf struct {
+	Name       string   `yaml:"name"`
+	Env        string   `yaml:"env"`
+	Roles      []string `yaml:"roles"`
+	Tenants    []string `yaml:"tenants"`
+	Namespaces []string `yaml:"namespaces"`
+}
+
+type serverAPIKeyConf struct {
+	Name    string   `yaml:"name"`
+	Env     string   `yaml:"env"`
+	Queries []string `yaml:"queries"`
+	AdHoc   bool     `yaml:"adHoc"`
+}
+
+type serverAuthConf struct {
+	Enable bool `yaml:"enable" env:"RESTQL_AUTH_ENABLE"`
+	JWT    struct {
+		SecretEnv string        `yaml:"secretEnv"`
+		JWKSFiles []string      `yaml:"jwksFiles" env:"RESTQL_AUTH_JWT_JWKS_FILES" envSeparator:","`
+		Issuer    string        `yaml:"issuer" env:"RESTQL_AUTH_JWT_ISSUER"`
+		Audience  string        `yaml:"audience" env:"RESTQL_AUTH_JWT_AUDIENCE"`
+		Leeway    time.Duration `yaml:"leeway"`
+	} `yaml:"jwt"`
+	APIKeys struct {
+		Header string             `yaml:"header"`
+		Keys   []serverAPIKeyConf `yaml:"keys"`
+	} `yaml:"apiKeys"`
+}
+
+type discoveryConf struct {
+	SrvRefreshInterval time.Duration `yaml:"srvRefreshInterval"`
+	Registry           struct {
+		Path          string        `yaml:"path" env:"RESTQL_DISCOVERY_REGISTRY_PATH"`
+		WatchInterval time.Duration `yaml:"watchInterval"`
+	} `yaml:"registry"`
+}
+
+type egressConf struct {
+	Mode  string   `yaml:"mode" env:"RESTQL_EGRESS_MODE"`
+	Allow []string `yaml:"allow" env:"RESTQL_EGRESS_ALLOW" envSeparator:","`
+	Deny  []string `yaml:"deny" env:"RESTQL_EGRESS_DENY" envSeparator:","`
+}
+
+type compressionConf struct {
+	Disable             bool  `yaml:"disable"`
+	GzipRequestBody     bool  `yaml:"gzipRequestBody"`
+	MaxDecompressedSize int64 `yaml:"maxDecompressedSize"`
+}
+
+type tlsConf struct {
+	CertFile           string `yaml:"certFile"`
+	KeyFile            string `yaml:"keyFile"`
+	CAFile             string `yaml:"caFile"`
+	ServerName         string `yaml:"serverName"`
+	MinVersion         string `yaml:"minVersion"`
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify"`
+}
+
+type proxyConf struct {
+	URL     string `yaml:"url"`
+	Disable bool   `yaml:"disable"`
+}
+
+type authConf struct {
+	Type   string `yaml:"type"`
+	OAuth2 struct {
+		TokenURL        string `yaml:"tokenUrl"`
+		ClientID        string `yaml:"clientId"`
+		ClientSecretEnv string `yaml:"clientSecretEnv"`
+		Scopes          string `yaml:"scopes"`
+	} `yaml:"oauth2"`
+	APIKey struct {
+		Env    string `yaml:"env"`
+		Header string `yaml:"header"`
+		Prefix string `yaml:"prefix"`
+	} `yaml:"apiKey"`
+	HMAC struct {
+		SecretEnv       string `yaml:"secretEnv"`
+		Header          string `yaml:"header"`
+		TimestampHeader string `yaml:"timestampHeader"`
+	} `yaml:"hmac"`
+}
+
+type grpcConf struct {
+	DescriptorSets []string `yaml:"descriptorSets" env:"RESTQL_GRPC_DESCRIPTOR_SETS" envSeparator:","`
+}
+
+type graphqlConf struct {
+	Document      string `yaml:"document"`
+	OperationName string `yaml:"operationName"`
+}
+
+// ResourceClientConf represents the upstream client parameters that
+// can be defined globally and overridden by resource or by tenant resource.
+type ResourceClientConf struct {
+	MaxResponseBodySize int             `yaml:"maxResponseBodySize" env:"RESTQL_MAX_RESPONSE_BODY_SIZE"`
+	Compression         compressionConf `yaml:"compression"`
+	TLS                 tlsConf         `yaml:"tls"`
+	Proxy               proxyConf       `yaml:"proxy"`
+	Auth                authConf        `yaml:"auth"`
+	GraphQL             graphqlConf     `yaml:"graphql"`
+}
+
+// ResourceClientOverride represents the upstream client parameters
+// defined for a resource or tenant resource. When read from YAML,
+// exactly the fields present on it override the inherited ones,
+// even if set to zero values, like `false`. When built in code
+// only the fields with non-zero values override them.
+type ResourceClientOverride struct {
+	ResourceClientConf `yaml:",inline"`
+
+	document string
+}
+
+// UnmarshalYAML keeps the YAML document of the parameters,
+// which tells the fields that were defined.
+func (o *ResourceClientOverride) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	err := unmarshal(&o.ResourceClientConf)
+	if err != nil {
+		return err
+	}
+
+	var document yaml.MapSlice
+	err = unmarshal(&document)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(document)
+	if err != nil {
+		return err
+	}
+	o.document = string(data)
+
+	return nil
+}
+
+func (o ResourceClientOverride) applyTo(cfg *ResourceClientConf) error {
+	if o.document == "" {
+		return mergo.Merge(cfg, o.ResourceClientConf, mergo.WithOverride)
+	}
+
+	return yaml.Unmarshal([]byte(o.document), cfg)
+}
+
+type injectedHeaderConf struct {
+	Name string `yaml:"name"`
+	Env  string `yaml:"env"`
+}
+
+// HeaderPolicyConf represents the rules applied to the headers exchanged
+// with an upstream, that can be defined globally and overridden by
+// resource or by tenant resource.
+type HeaderPolicyConf struct {
+	Forward struct {
+		Allow  []string          `yaml:"allow"`
+		Deny   []string          `yaml:"deny"`
+		Rename map[string]string `yaml:"rename"`
+	} `yaml:"forward"`
+	Inject   []injectedHeaderConf `yaml:"inject"`
+	Response struct {
+		Allow []string `yaml:"allow"`
+		Deny  []string `yaml:"deny"`
+	} `yaml:"response"`
+}
+
 // Config represents all parameters allowed in restQL runtime.
 type Config struct {
 	HTTP struct {
@@ -47,6 +237,12 @@ type Config struct {
 
 		GlobalQueryTimeout time.Duration `env:"RESTQL_QUERY_GLOBAL_TIMEOUT" envDefault:"30s"`
 
+		Headers struct {
+			HeaderPolicyConf `yaml:",inline"`
+			Resources        map[string]HeaderPolicyConf            `yaml:"resources"`
+			Tenants          map[string]map[string]HeaderPolicyConf `yaml:"tenants"`
+		} `yaml:"headers"`
+
 		Server struct {
 			APIAddr         string `env:"RESTQL_PORT,required"`
 			APIHealthAddr   string `env:"RESTQL_HEALTH_PORT,required"`
@@ -54,9 +250,27 @@ type Config struct {
 			EnablePprof     bool   `env:"RESTQL_ENABLE_PPROF"`
 			EnableFullPprof bool   `env:"RESTQL_ENABLE_FULL_PPROF"`
 			Admin           struct {
-				Enable            bool   `yaml:"enable" env:"RESTQL_ADMIN_ENABLE"`
-				AuthorizationCode string `yaml:"authorizationCode" env:"RESTQL_ADMIN_AUTHORIZATION_CODE"`
+				Enable            bool             `yaml:"enable" env:"RESTQL_ADMIN_ENABLE"`
+				AuthorizationCode string           `yaml:"authorizationCode" env:"RESTQL_ADMIN_AUTHORIZATION_CODE"`
+				Tokens            []adminTokenConf `yaml:"tokens"`
+				Audit             struct {
+					File string `yaml:"file" env:"RESTQL_ADMIN_AUDIT_FILE"`
+				} `yaml:"audit"`
 			} `yaml:"admin"`
+			GraphQL struct {
+				Enable                bool          `yaml:"enable" env:"RESTQL_GRAPHQL_ENABLE"`
+				SchemaRefreshInterval time.Duration `yaml:"schemaRefreshInterval" env:"RESTQL_GRAPHQL_SCHEMA_REFRESH_INTERVAL"`
+				MaxRootFields         int           `yaml:"maxRootFields" env:"RESTQL_GRAPHQL_MAX_ROOT_FIELDS"`
+			} `yaml:"graphql"`
+			Batch struct {
+				MaxSize        int `yaml:"maxSize" env:"RESTQL_BATCH_MAX_SIZE"`
+				MaxConcurrency int `yaml:"maxConcurrency" env:"RESTQL_BATCH_MAX_CONCURRENCY"`
+			} `yaml:"batch"`
+			Auth  serverAuthConf `yaml:"auth"`
+			Debug struct {
+				Disable         bool     `yaml:"disable" env:"RESTQL_DEBUG_DISABLE"`
+				DisabledTenants []string `yaml:"disabledTenants" env:"RESTQL_DEBUG_DISABLED_TENANTS" envSeparator:","`
+			} `yaml:"debug"`
 
 			GracefulShutdownTimeout time.Duration `yaml:"gracefulShutdownTimeout"`
 			ReadTimeout             time.Duration `yaml:"readTimeout"`
@@ -67,6 +281,7 @@ type Config struct {
 				Timeout             timeoutConf             `yaml:"timeout"`
 				Cors                corsConf                `yaml:"cors"`
 				RequestCancellation requestCancellationConf `yaml:"requestCancellation"`
+				RateLimit           rateLimitConf           `yaml:"rateLimit"`
 			} `yaml:"middlewares"`
 		} `yaml:"server"`
 
@@ -81,6 +296,15 @@ type Config struct {
 			MaxIdleConns        int           `yaml:"maxIdleConnections"`
 			MaxIdleConnsPerHost int           `yaml:"maxIdleConnectionsPerHost"`
 			MaxIdleConnDuration time.Duration `yaml:"maxIdleConnectionDuration"`
+
+			Discovery        discoveryConf `yaml:"discovery"`
+			TLSWatchInterval time.Duration `yaml:"tlsWatchInterval"`
+			GRPC             grpcConf      `yaml:"grpc"`
+			Egress           egressConf    `yaml:"egress"`
+
+			ResourceClientConf `yaml:",inline"`
+			Resources          map[string]ResourceClientOverride            `yaml:"resources"`
+			Tenants            map[string]map[string]ResourceClientOverride `yaml:"tenants"`
 		} `yaml:"client"`
 	} `yaml:"http"`
 
@@ -90,8 +314,34 @@ type Config struct {
 		TimestampFieldFormat string `yaml:"timestampFieldFormat"`
 		Level                string `yaml:"level" env:"RESTQL_LOGGING_LEVEL"`
 		Format               string `yaml:"format"`
+		AccessLog            struct {
+			Enable bool `yaml:"enable" env:"RESTQL_LOGGING_ACCESS_LOG_ENABLE"`
+		} `yaml:"accessLog"`
+		SlowQuery struct {
+			Enable    bool          `yaml:"enable" env:"RESTQL_LOGGING_SLOW_QUERY_ENABLE"`
+			Threshold time.Duration `yaml:"threshold" env:"RESTQL_LOGGING_SLOW_QUERY_THRESHOLD"`
+		} `yaml:"slowQuery"`
 	} `yaml:"logging"`
 
+	Redaction struct {
+		Headers   []string `yaml:"headers" env:"RESTQL_REDACTION_HEADERS" envSeparator:","`
+		Params    []string `yaml:"params" env:"RESTQL_REDACTION_PARAMS" envSeparator:","`
+		JSONPaths []string `yaml:"jsonPaths"`
+		Patterns  []string `yaml:"patterns"`
+	} `yaml:"redaction"`
+
+	Tracing struct {
+		Enable         bool              `yaml:"enable" env:"RESTQL_TRACING_ENABLE"`
+		Endpoint       string            `yaml:"endpoint" env:"RESTQL_TRACING_ENDPOINT"`
+		ServiceName    string            `yaml:"serviceName" env:"RESTQL_TRACING_SERVICE_NAME"`
+		Headers        map[string]string `yaml:"headers"`
+		SampleRatio    float64           `yaml:"sampleRatio" env:"RESTQL_TRACING_SAMPLE_RATIO"`
+		BatchSize      int               `yaml:"batchSize"`
+		QueueSize      int               `yaml:"queueSize"`
+		ExportInterval time.Duration     `yaml:"exportInterval"`
+		ExportTimeout  time.Duration     `yaml:"exportTimeout"`
+	} `yaml:"tracing"`
+
 	Cache struct {
 		Mappings struct {
 			MaxSize            int           `yaml:"maxSize" env:"RESTQL_CACHE_MAPPINGS_MAX_SIZE"`
@@ -105,8 +355,25 @@ type Config struct {
 		Parser struct {
 			MaxSize int `yaml:"maxSize" env:"RESTQL_CACHE_PARSER_MAX_SIZE"`
 		} `yaml:"parser"`
+		PersistedQuery struct {
+			MaxSize int `yaml:"maxSize" env:"RESTQL_CACHE_PERSISTED_QUERY_MAX_SIZE"`
+		} `yaml:"persistedQuery"`
+		Response struct {
+			Enable               bool          `yaml:"enable" env:"RESTQL_CACHE_RESPONSE_ENABLE"`
+			MaxSize              int           `yaml:"maxSize" env:"RESTQL_CACHE_RESPONSE_MAX_SIZE"`
+			StaleWhileRevalidate time.Duration `yaml:"staleWhileRevalidate" env:"RESTQL_CACHE_RESPONSE_STALE_WHILE_REVALIDATE"`
+			VaryHeaders          []string      `yaml:"varyHeaders" env:"RESTQL_CACHE_RESPONSE_VARY_HEADERS" envSeparator:","`
+		} `yaml:"response"`
 	} `yaml:"cache"`
 
+	Health struct {
+		Readiness struct {
+			Timeout   time.Duration     `yaml:"timeout" env:"RESTQL_HEALTH_READINESS_TIMEOUT"`
+			Tenants   []string          `yaml:"tenants" env:"RESTQL_HEALTH_READINESS_TENANTS" envSeparator:","`
+			Upstreams map[string]string `yaml:"upstreams"`
+		} `yaml:"readiness"`
+	} `yaml:"health"`
+
 	Plugins struct {
 		DisableDatabase bool `yaml:"disableDatabase" env:"RESTQL_PLUGINS_DATABASE_DISABLE"`
 	} `yaml:"plugins"`
@@ -117,6 +384,12 @@ type Config struct {
 
 	Queries map[string]map[string][]string `yaml:"queries"`
 
+	PersistedQueries struct {
+		Allowlist    bool     `yaml:"allowlist" env:"RESTQL_PERSISTED_QUERIES_ALLOWLIST"`
+		AutoRegister bool     `yaml:"autoRegister" env:"RESTQL_PERSISTED_QUERIES_AUTO_REGISTER"`
+		Queries      []string `yaml:"queries"`
+	} `yaml:"persistedQueries"`
+
 	Env EnvSource
 
 	Build string
@@ -142,9 +415,101 @@ func Load(build string) (*Config, error) {
 	cfg.Build = build
 	cfg.Env = EnvSource{}
 
+	err = cfg.validateResourceClients()
+	if err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
 
+// ResourceClient returns the upstream client parameters for a resource,
+// merging the global values with the ones defined for the resource
+// and then the ones defined for the resource under the given tenant.
+func (c *Config) ResourceClient(tenant, resource string) (ResourceClientConf, error) {
+	clientCfg := c.HTTP.Client
+	result := clientCfg.ResourceClientConf
+
+	if resourceCfg, found := clientCfg.Resources[resource]; found {
+		err := resourceCfg.applyTo(&result)
+		if err != nil {
+			return ResourceClientConf{}, errors.Wrapf(err, "invalid client parameters for resource %s", resource)
+		}
+	}
+
+	if tenantResourceCfg, found := clientCfg.Tenants[tenant][resource]; found {
+		err := tenantResourceCfg.applyTo(&result)
+		if err != nil {
+			return ResourceClientConf{}, errors.Wrapf(err, "invalid client parameters for resource %s on tenant %s", resource, tenant)
+		}
+	}
+
+	return result, nil
+}
+
+// validateResourceClients merges the parameters of every configured
+// resource, so invalid ones are found when restQL starts.
+func (c *Config) validateResourceClients() error {
+	for resource := range c.HTTP.Client.Resources {
+		if _, err := c.ResourceClient("", resource); err != nil {
+			return err
+		}
+	}
+
+	for tenant, resources := range c.HTTP.Client.Tenants {
+		for resource := range resources {
+			if _, err := c.ResourceClient(tenant, resource); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// HeaderPolicy returns the header rules for a resource, merging the
+// global rules with the ones defined for the resource and then
+// the ones defined for the resource under the given tenant. Lists
+// defined on a level replace the inherited ones, even when empty,
+// while renames are added to the inherited ones.
+func (c *Config) HeaderPolicy(tenant, resource string) HeaderPolicyConf {
+	headersCfg := c.HTTP.Headers
+	levels := []HeaderPolicyConf{headersCfg.HeaderPolicyConf}
+
+	if resourceCfg, found := headersCfg.Resources[resource]; found {
+		levels = append(levels, resourceCfg)
+	}
+
+	if tenantResourceCfg, found := headersCfg.Tenants[tenant][resource]; found {
+		levels = append(levels, tenantResourceCfg)
+	}
+
+	var result HeaderPolicyConf
+	rename := make(map[string]string)
+	for _, level := range levels {
+		for from, to := range level.Forward.Rename {
+			rename[from] = to
+		}
+
+		overrideList(&result.Forward.Allow, level.Forward.Allow)
+		overrideList(&result.Forward.Deny, level.Forward.Deny)
+		overrideList(&result.Response.Allow, level.Response.Allow)
+		overrideList(&result.Response.Deny, level.Response.Deny)
+		if level.Inject != nil {
+			result.Inject = level.Inject
+		}
+	}
+	result.Forward.Rename = rename
+
+	return result
+}
+
+func overrideList(target *[]string, value []string) {
+	if value != nil {
+		*target = value
+	}
+}
+
 func readConfigFile() []byte {
 	path := getConfigFilepath()
 	if path == "" {
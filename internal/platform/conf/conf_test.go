@@ -0,0 +1,156 @@
+package conf
+
+import (
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"gopkg.in/yaml.v2"
+)
+
+func TestConfigResourceClient(t *testing.T) {
+	cfg := loadConfig(t, `
+http:
+  client:
+    maxResponseBodySize: 2048
+    compression:
+      gzipRequestBody: true
+    tls:
+      insecureSkipVerify: true
+      minVersion: "1.2"
+    proxy:
+      url: http://proxy.internal:3128
+    resources:
+      hero:
+        maxResponseBodySize: 0
+        tls:
+          insecureSkipVerify: false
+      sidekick:
+        compression:
+          disable: true
+    tenants:
+      DC:
+        hero:
+          proxy:
+            disable: true
+`)
+
+	tests := []struct {
+		name     string
+		tenant   string
+		resource string
+		expected func() ResourceClientConf
+	}{
+		{
+			"uses global parameters for resources without overrides",
+			"DC", "villain",
+			func() ResourceClientConf {
+				return cfg.HTTP.Client.ResourceClientConf
+			},
+		},
+		{
+			"overrides global values with zero values",
+			"MARVEL", "hero",
+			func() ResourceClientConf {
+				expected := cfg.HTTP.Client.ResourceClientConf
+				expected.MaxResponseBodySize = 0
+				expected.TLS.InsecureSkipVerify = false
+				return expected
+			},
+		},
+		{
+			"applies tenant resource on top of resource parameters",
+			"DC", "hero",
+			func() ResourceClientConf {
+				expected := cfg.HTTP.Client.ResourceClientConf
+				expected.MaxResponseBodySize = 0
+				expected.TLS.InsecureSkipVerify = false
+				expected.Proxy.Disable = true
+				return expected
+			},
+		},
+		{
+			"keeps global values of the same group not overridden",
+			"DC", "sidekick",
+			func() ResourceClientConf {
+				expected := cfg.HTTP.Client.ResourceClientConf
+				expected.Compression.Disable = true
+				return expected
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cfg.ResourceClient(tt.tenant, tt.resource)
+			test.VerifyError(t, err)
+			test.Equal(t, got, tt.expected())
+		})
+	}
+}
+
+func TestConfigResourceClientBuiltInCode(t *testing.T) {
+	cfg := &Config{}
+	cfg.HTTP.Client.MaxResponseBodySize = 2048
+	cfg.HTTP.Client.TLS.ServerName = "hero.io"
+	cfg.HTTP.Client.Resources = map[string]ResourceClientOverride{
+		"hero": {ResourceClientConf: ResourceClientConf{MaxResponseBodySize: 1024}},
+	}
+
+	got, err := cfg.ResourceClient("DC", "hero")
+	test.VerifyError(t, err)
+
+	test.Equal(t, got.MaxResponseBodySize, 1024)
+	test.Equal(t, got.TLS.ServerName, "hero.io")
+}
+
+func TestConfigHeaderPolicy(t *testing.T) {
+	cfg := loadConfig(t, `
+http:
+  headers:
+    forward:
+      allow: ["X-*"]
+      deny: ["X-Internal"]
+      rename:
+        X-Client: X-Origin
+    resources:
+      hero:
+        forward:
+          allow: []
+          rename:
+            X-Token: Authorization
+`)
+
+	got := cfg.HeaderPolicy("DC", "hero")
+
+	test.Equal(t, got.Forward.Allow, []string{})
+	test.Equal(t, got.Forward.Deny, []string{"X-Internal"})
+	test.Equal(t, got.Forward.Rename, map[string]string{"X-Client": "X-Origin", "X-Token": "Authorization"})
+	test.Equal(t, cfg.HTTP.Headers.Forward.Rename, map[string]string{"X-Client": "X-Origin"})
+}
+
+func TestUnmarshalInvalidResourceClient(t *testing.T) {
+	cfg := &Config{}
+	err := yaml.Unmarshal([]byte(`
+http:
+  client:
+    resources:
+      hero:
+        tls:
+          insecureSkipVerify: maybe
+`), cfg)
+
+	if err == nil {
+		t.Fatalf("expected error on invalid resource client parameters")
+	}
+}
+
+func loadConfig(t *testing.T, data string) *Config {
+	cfg := &Config{}
+	err := yaml.Unmarshal([]byte(data), cfg)
+	test.VerifyError(t, err)
+
+	err = cfg.validateResourceClients()
+	test.VerifyError(t, err)
+
+	return cfg
+}
@@ -11,10 +11,23 @@ http:
     readTimeout: 3s
     idleTimeout: 5s
     gracefulShutdownTimeout: 1s
+    graphql:
+      schemaRefreshInterval: 1m
+      maxRootFields: 20
+    batch:
+      maxSize: 20
+      maxConcurrency: 5
+    auth:
+      jwt:
+        leeway: 30s
+      apiKeys:
+        header: X-Api-Key
     middlewares:
       requestCancellation:
         enabled: false
         watchInterval: 10ms
+      rateLimit:
+        maxKeys: 10000
 
   client:
     readTimeout: 1s
@@ -22,12 +35,40 @@ http:
     writeTimeout: 1s
     maxIdleConnectionsPerHost: 512
     maxIdleConnectionDuration: 10s
+    tlsWatchInterval: 10s
+    egress:
+      mode: development
+    compression:
+      maxDecompressedSize: 10485760
+    discovery:
+      srvRefreshInterval: 30s
+      registry:
+        watchInterval: 5s
 
 logging:
   enable: true
   timestampFieldName: timestamp
   level: info
   format: json
+  slowQuery:
+    threshold: 1s
+
+redaction:
+  headers:
+    - Authorization
+    - Proxy-Authorization
+    - Cookie
+    - Set-Cookie
+    - X-Api-Key
+
+tracing:
+  endpoint: http://localhost:4318
+  serviceName: restql
+  sampleRatio: 1
+  batchSize: 512
+  queueSize: 2048
+  exportInterval: 5s
+  exportTimeout: 10s
 
 cache:
   mappings:
@@ -36,6 +77,17 @@ cache:
     maxSize: 100
   parser:
     maxSize: 100
+  persistedQuery:
+    maxSize: 1000
+  response:
+    maxSize: 1000
+    varyHeaders:
+      - Authorization
+      - Cookie
+
+health:
+  readiness:
+    timeout: 2s
 
 database:
   timeout: 1000
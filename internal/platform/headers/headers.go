@@ -0,0 +1,280 @@
+// Package headers implements the rules that decide which client
+// headers are forwarded to upstreams, which headers are added to
+// the upstream requests and which upstream response headers
+// are returned to the client.
+package headers
+
+import (
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/pkg/errors"
+)
+
+var (
+	errInvalidHeaderPattern = errors.New("invalid header pattern")
+	errMissingHeaderEnv     = errors.New("environment variable for injected header not found")
+)
+
+// Headers that are never forwarded from the client,
+// since they describe the client connection or
+// are defined by restQL for the upstream request.
+var blockedHeaders = []string{
+	"host",
+	"content-type",
+	"content-length",
+	"connection",
+	"origin",
+	"accept-encoding",
+}
+
+// authHeaders returns the headers holding the credentials
+// verified by restQL itself, which must not reach upstreams.
+func authHeaders(cfg *conf.Config) []string {
+	authCfg := cfg.HTTP.Server.Auth
+	if !authCfg.Enable {
+		return nil
+	}
+
+	var names []string
+	if len(authCfg.APIKeys.Keys) > 0 && authCfg.APIKeys.Header != "" {
+		names = append(names, strings.ToLower(authCfg.APIKeys.Header))
+	}
+	if authCfg.JWT.SecretEnv != "" || len(authCfg.JWT.JWKSFiles) > 0 {
+		names = append(names, "authorization")
+	}
+
+	return names
+}
+
+type upstream struct {
+	tenant   string
+	resource string
+}
+
+// Policies resolves the header rules of each upstream, caching
+// the compiled result since the configuration does not change
+// at runtime. All methods are safe to call on a nil Policies,
+// which returns the rules with only the built-in restrictions.
+type Policies struct {
+	cfg     *conf.Config
+	env     map[string]string
+	blocked []string
+
+	mu    sync.RWMutex
+	cache map[upstream]*Policy
+}
+
+// New constructs a Policies from the configuration, failing if any
+// header pattern is invalid or any injected header environment
+// variable is not defined.
+func New(cfg *conf.Config) (*Policies, error) {
+	headersCfg := cfg.HTTP.Headers
+
+	levels := []conf.HeaderPolicyConf{headersCfg.HeaderPolicyConf}
+	for _, resourceCfg := range headersCfg.Resources {
+		levels = append(levels, resourceCfg)
+	}
+	for _, resources := range headersCfg.Tenants {
+		for _, tenantResourceCfg := range resources {
+			levels = append(levels, tenantResourceCfg)
+		}
+	}
+
+	env := make(map[string]string)
+	for _, level := range levels {
+		patterns := [][]string{level.Forward.Allow, level.Forward.Deny, level.Response.Allow, level.Response.Deny}
+		for _, p := range patterns {
+			err := validatePatterns(p)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, h := range level.Inject {
+			value, found := os.LookupEnv(h.Env)
+			if !found {
+				return nil, errors.Wrapf(errMissingHeaderEnv, "header %s, variable %s", h.Name, h.Env)
+			}
+			env[h.Env] = value
+		}
+	}
+
+	return &Policies{cfg: cfg, env: env, blocked: authHeaders(cfg), cache: make(map[upstream]*Policy)}, nil
+}
+
+func validatePatterns(patterns []string) error {
+	for _, p := range patterns {
+		if _, err := path.Match(strings.ToLower(p), ""); err != nil || p == "" {
+			return errors.Wrapf(errInvalidHeaderPattern, "%q", p)
+		}
+	}
+
+	return nil
+}
+
+// Get returns the header rules for a resource under the given tenant.
+func (ps *Policies) Get(tenant, resource string) *Policy {
+	if ps == nil {
+		return nil
+	}
+
+	key := upstream{tenant: tenant, resource: resource}
+
+	ps.mu.RLock()
+	p, found := ps.cache[key]
+	ps.mu.RUnlock()
+
+	if found {
+		return p
+	}
+
+	p = newPolicy(ps.cfg.HeaderPolicy(tenant, resource), ps.env, ps.blocked)
+
+	ps.mu.Lock()
+	ps.cache[key] = p
+	ps.mu.Unlock()
+
+	return p
+}
+
+// Policy holds the header rules of an upstream. Header names are
+// matched ignoring case against patterns where * matches any
+// sequence of characters. A header is forwarded when it matches
+// the allow rules, or there are none, and no deny rule. Response
+// headers are filtered the same way. The headers with the
+// credentials verified by restQL are never forwarded. All methods
+// are safe to call on a nil Policy, which only applies the
+// built-in restrictions.
+type Policy struct {
+	blocked       []string
+	forwardAllow  []string
+	forwardDeny   []string
+	rename        map[string]string
+	inject        map[string]string
+	responseAllow []string
+	responseDeny  []string
+}
+
+func newPolicy(cfg conf.HeaderPolicyConf, env map[string]string, blocked []string) *Policy {
+	p := &Policy{
+		blocked:       blocked,
+		forwardAllow:  lowerAll(cfg.Forward.Allow),
+		forwardDeny:   lowerAll(cfg.Forward.Deny),
+		rename:        make(map[string]string),
+		inject:        make(map[string]string),
+		responseAllow: lowerAll(cfg.Response.Allow),
+		responseDeny:  lowerAll(cfg.Response.Deny),
+	}
+
+	for from, to := range cfg.Forward.Rename {
+		p.rename[strings.ToLower(from)] = http.CanonicalHeaderKey(to)
+	}
+
+	for _, h := range cfg.Inject {
+		p.inject[http.CanonicalHeaderKey(h.Name)] = env[h.Env]
+	}
+
+	return p
+}
+
+// Forward returns the client headers that should be sent
+// to the upstream, already renamed and in canonical form.
+func (p *Policy) Forward(clientHeaders map[string]string) map[string]string {
+	r := make(map[string]string)
+	for k, v := range clientHeaders {
+		name := strings.ToLower(k)
+		if isBlocked(name) {
+			continue
+		}
+
+		if p != nil {
+			if contains(p.blocked, name) || !p.permits(p.forwardAllow, p.forwardDeny, name) {
+				continue
+			}
+
+			if renamed, found := p.rename[name]; found {
+				r[renamed] = v
+				continue
+			}
+		}
+
+		r[http.CanonicalHeaderKey(k)] = v
+	}
+
+	return r
+}
+
+// Inject sets the static headers of the upstream,
+// replacing the ones with the same name.
+func (p *Policy) Inject(headers map[string]string) {
+	if p == nil {
+		return
+	}
+
+	for k, v := range p.inject {
+		headers[k] = v
+	}
+}
+
+// Expose returns the upstream response headers that can be
+// returned to the client or nil when there is no restriction.
+func (p *Policy) Expose(responseHeaders map[string]string) map[string]string {
+	if p == nil || (len(p.responseAllow) == 0 && len(p.responseDeny) == 0) {
+		return nil
+	}
+
+	r := make(map[string]string)
+	for k, v := range responseHeaders {
+		if p.permits(p.responseAllow, p.responseDeny, strings.ToLower(k)) {
+			r[k] = v
+		}
+	}
+
+	return r
+}
+
+func (p *Policy) permits(allow []string, deny []string, name string) bool {
+	if len(allow) > 0 && !matchAny(allow, name) {
+		return false
+	}
+
+	return !matchAny(deny, name)
+}
+
+func isBlocked(name string) bool {
+	return contains(blockedHeaders, name)
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if matched, _ := path.Match(p, name); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func lowerAll(values []string) []string {
+	r := make([]string, len(values))
+	for i, v := range values {
+		r[i] = strings.ToLower(v)
+	}
+
+	return r
+}
@@ -0,0 +1,273 @@
+package headers
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"gopkg.in/yaml.v2"
+)
+
+func TestPolicyForward(t *testing.T) {
+	clientHeaders := map[string]string{
+		"host":            "restql.io",
+		"authorization":   "Bearer abcdefgh",
+		"x-tid":           "1234567890",
+		"x-internal-flag": "true",
+		"x-client-token":  "token",
+		"accept":          "*/*",
+	}
+
+	tests := []struct {
+		name     string
+		cfg      func(cfg *conf.Config)
+		tenant   string
+		resource string
+		expected map[string]string
+	}{
+		{
+			"forwards every header but the blocked ones without rules",
+			func(cfg *conf.Config) {},
+			"DC", "hero",
+			map[string]string{"Authorization": "Bearer abcdefgh", "X-Tid": "1234567890", "X-Internal-Flag": "true", "X-Client-Token": "token", "Accept": "*/*"},
+		},
+		{
+			"forwards only allowed headers",
+			func(cfg *conf.Config) {
+				cfg.HTTP.Headers.Forward.Allow = []string{"X-*", "Accept"}
+			},
+			"DC", "hero",
+			map[string]string{"X-Tid": "1234567890", "X-Internal-Flag": "true", "X-Client-Token": "token", "Accept": "*/*"},
+		},
+		{
+			"does not forward denied headers",
+			func(cfg *conf.Config) {
+				cfg.HTTP.Headers.Forward.Allow = []string{"x-*"}
+				cfg.HTTP.Headers.Forward.Deny = []string{"X-Internal-*"}
+			},
+			"DC", "hero",
+			map[string]string{"X-Tid": "1234567890", "X-Client-Token": "token"},
+		},
+		{
+			"renames headers",
+			func(cfg *conf.Config) {
+				cfg.HTTP.Headers.Forward.Allow = []string{"X-Client-Token"}
+				cfg.HTTP.Headers.Forward.Rename = map[string]string{"X-Client-Token": "authorization"}
+			},
+			"DC", "hero",
+			map[string]string{"Authorization": "token"},
+		},
+		{
+			"applies resource rules",
+			func(cfg *conf.Config) {
+				cfg.HTTP.Headers.Forward.Deny = []string{"Authorization"}
+				cfg.HTTP.Headers.Resources = map[string]conf.HeaderPolicyConf{
+					"hero": headerPolicy(nil, []string{"X-*"}),
+				}
+			},
+			"DC", "hero",
+			map[string]string{"Authorization": "Bearer abcdefgh", "Accept": "*/*"},
+		},
+		{
+			"applies tenant resource rules",
+			func(cfg *conf.Config) {
+				cfg.HTTP.Headers.Resources = map[string]conf.HeaderPolicyConf{
+					"hero": headerPolicy(nil, []string{"X-*"}),
+				}
+				cfg.HTTP.Headers.Tenants = map[string]map[string]conf.HeaderPolicyConf{
+					"DC": {"hero": headerPolicy([]string{"Accept"}, []string{"Authorization"})},
+				}
+			},
+			"DC", "hero",
+			map[string]string{"Accept": "*/*"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &conf.Config{}
+			tt.cfg(cfg)
+
+			policies, err := New(cfg)
+			test.VerifyError(t, err)
+
+			got := policies.Get(tt.tenant, tt.resource).Forward(clientHeaders)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestPolicyForwardAuthHeaders(t *testing.T) {
+	clientHeaders := map[string]string{
+		"Authorization": "Bearer restql-token",
+		"X-Api-Key":     "restql-key",
+		"X-Tid":         "1234567890",
+	}
+
+	tests := []struct {
+		name     string
+		yaml     string
+		expected map[string]string
+	}{
+		{
+			"forwards credentials when authentication is disabled",
+			`
+http:
+  server:
+    auth:
+      apiKeys:
+        header: X-Api-Key
+        keys:
+          - name: mobile
+`,
+			clientHeaders,
+		},
+		{
+			"does not forward api key",
+			`
+http:
+  server:
+    auth:
+      enable: true
+      apiKeys:
+        header: X-Api-Key
+        keys:
+          - name: mobile
+`,
+			map[string]string{"Authorization": "Bearer restql-token", "X-Tid": "1234567890"},
+		},
+		{
+			"does not forward jwt even if allowed",
+			`
+http:
+  server:
+    auth:
+      enable: true
+      jwt:
+        secretEnv: RESTQL_JWT_SECRET
+      apiKeys:
+        header: X-Api-Key
+  headers:
+    forward:
+      allow: ["Authorization", "X-*"]
+`,
+			map[string]string{"X-Api-Key": "restql-key", "X-Tid": "1234567890"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policies, err := New(loadConfig(t, tt.yaml))
+			test.VerifyError(t, err)
+
+			got := policies.Get("DC", "hero").Forward(clientHeaders)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestPolicyInject(t *testing.T) {
+	os.Setenv("HERO_SERVICE_KEY", "my-key")
+	defer os.Unsetenv("HERO_SERVICE_KEY")
+
+	cfg := loadConfig(t, `
+http:
+  headers:
+    resources:
+      hero:
+        inject:
+          - name: x-service-key
+            env: HERO_SERVICE_KEY
+`)
+
+	policies, err := New(cfg)
+	test.VerifyError(t, err)
+
+	headers := map[string]string{"X-Service-Key": "from-client", "X-Tid": "1234567890"}
+	policies.Get("DC", "hero").Inject(headers)
+	test.Equal(t, headers, map[string]string{"X-Service-Key": "my-key", "X-Tid": "1234567890"})
+
+	headers = map[string]string{"X-Tid": "1234567890"}
+	policies.Get("DC", "sidekick").Inject(headers)
+	test.Equal(t, headers, map[string]string{"X-Tid": "1234567890"})
+}
+
+func TestPolicyExpose(t *testing.T) {
+	cfg := loadConfig(t, `
+http:
+  headers:
+    response:
+      deny: ["set-cookie"]
+    resources:
+      hero:
+        response:
+          allow: ["X-*"]
+`)
+
+	policies, err := New(cfg)
+	test.VerifyError(t, err)
+
+	responseHeaders := map[string]string{"X-Tid": "1234567890", "Set-Cookie": "session=secret", "Server": "nginx"}
+
+	test.Equal(t, policies.Get("DC", "hero").Expose(responseHeaders), map[string]string{"X-Tid": "1234567890"})
+	test.Equal(t, policies.Get("DC", "sidekick").Expose(responseHeaders), map[string]string{"X-Tid": "1234567890", "Server": "nginx"})
+
+	var noPolicy *Policy
+	test.Equal(t, noPolicy.Expose(responseHeaders) == nil, true)
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		expected error
+	}{
+		{
+			"fails on missing environment variable",
+			`
+http:
+  headers:
+    inject:
+      - name: X-Service-Key
+        env: UNDEFINED_HEADER_VARIABLE
+`,
+			errMissingHeaderEnv,
+		},
+		{
+			"fails on invalid pattern",
+			`
+http:
+  headers:
+    tenants:
+      DC:
+        hero:
+          forward:
+            deny: ["X-[Internal"]
+`,
+			errInvalidHeaderPattern,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(loadConfig(t, tt.yaml))
+			test.Equal(t, errors.Is(err, tt.expected), true)
+		})
+	}
+}
+
+func loadConfig(t *testing.T, data string) *conf.Config {
+	cfg := &conf.Config{}
+	err := yaml.Unmarshal([]byte(data), cfg)
+	test.VerifyError(t, err)
+	return cfg
+}
+
+func headerPolicy(allow []string, deny []string) conf.HeaderPolicyConf {
+	var p conf.HeaderPolicyConf
+	p.Forward.Allow = allow
+	p.Forward.Deny = deny
+	return p
+}
@@ -0,0 +1,391 @@
+package httpclient
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/pkg/errors"
+	"github.com/valyala/fasthttp"
+	"golang.org/x/sync/singleflight"
+)
+
+// Upstream authentication types supported in the resource parameters.
+const (
+	oauth2AuthType = "oauth2"
+	apiKeyAuthType = "apiKey"
+	hmacAuthType   = "hmac"
+)
+
+const (
+	defaultAPIKeyHeader        = "X-Api-Key"
+	defaultHMACHeader          = "X-Signature"
+	defaultHMACTimestampHeader = "X-Signature-Timestamp"
+
+	// tokenExpiryDelta anticipates the token refresh to
+	// avoid using it when it is about to expire. It is
+	// limited to a quarter of short token lifetimes.
+	tokenExpiryDelta = 30 * time.Second
+
+	// defaultTokenLifetime is used when the token
+	// endpoint does not inform the expiration.
+	defaultTokenLifetime = 5 * time.Minute
+)
+
+var (
+	errUnknownAuthType  = errors.New("unknown upstream auth type")
+	errMissingAuthEnv   = errors.New("upstream auth environment variable not set")
+	errTokenRequestFail = errors.New("failed to obtain oauth2 token")
+)
+
+// authProvider adds credentials to a request
+// before it is sent to the upstream.
+type authProvider interface {
+	Authenticate(req *fasthttp.Request, timeout time.Duration) error
+}
+
+// tokenRequester sends the requests to the token endpoint of a
+// resource through the same client used for the resource, hence
+// with the same TLS, proxy and egress settings.
+type tokenRequester func(cfg conf.ResourceClientConf, req *fasthttp.Request, res *fasthttp.Response, timeout time.Duration) error
+
+// authProviders keeps one authProvider for each distinct
+// authentication and transport parameters, the latter used
+// to request tokens, allowing tokens to be reused
+// among requests. Providers that fail to be created, like
+// when the secret is missing, are created again on the
+// next request.
+type authProviders struct {
+	requestToken tokenRequester
+
+	mu        sync.RWMutex
+	providers map[conf.ResourceClientConf]authProvider
+}
+
+func newAuthProviders(requestToken tokenRequester) *authProviders {
+	return &authProviders{
+		requestToken: requestToken,
+		providers:    make(map[conf.ResourceClientConf]authProvider),
+	}
+}
+
+// Authenticate applies the authentication defined
+// in the resource parameters to the request.
+func (ap *authProviders) Authenticate(cfg conf.ResourceClientConf, req *fasthttp.Request, timeout time.Duration) error {
+	if cfg.Auth.Type == "" {
+		return nil
+	}
+
+	provider, err := ap.get(cfg)
+	if err != nil {
+		return err
+	}
+
+	return provider.Authenticate(req, timeout)
+}
+
+// Refresh discards the credentials sent on the request when they
+// can be obtained again, as oauth2 tokens, returning whether the
+// request should be authenticated and sent again.
+func (ap *authProviders) Refresh(cfg conf.ResourceClientConf, req *fasthttp.Request) bool {
+	if cfg.Auth.Type != oauth2AuthType {
+		return false
+	}
+
+	ap.mu.RLock()
+	provider, found := ap.providers[authKey(cfg)]
+	ap.mu.RUnlock()
+
+	p, ok := provider.(*oauth2Provider)
+	if !found || !ok {
+		return false
+	}
+
+	p.discard(string(req.Header.Peek(fasthttp.HeaderAuthorization)))
+	return true
+}
+
+func (ap *authProviders) get(cfg conf.ResourceClientConf) (authProvider, error) {
+	cfg = authKey(cfg)
+
+	ap.mu.RLock()
+	provider, found := ap.providers[cfg]
+	ap.mu.RUnlock()
+
+	if found {
+		return provider, nil
+	}
+
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+
+	if provider, found := ap.providers[cfg]; found {
+		return provider, nil
+	}
+
+	provider, err := ap.newProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+	ap.providers[cfg] = provider
+
+	return provider, nil
+}
+
+func (ap *authProviders) newProvider(cfg conf.ResourceClientConf) (authProvider, error) {
+	authCfg := cfg.Auth
+	switch authCfg.Type {
+	case oauth2AuthType:
+		secret, err := lookupAuthEnv(authCfg.OAuth2.ClientSecretEnv)
+		if err != nil {
+			return nil, err
+		}
+
+		return &oauth2Provider{
+			do: func(req *fasthttp.Request, res *fasthttp.Response, timeout time.Duration) error {
+				return ap.requestToken(cfg, req, res, timeout)
+			},
+			tokenURL:     authCfg.OAuth2.TokenURL,
+			clientID:     authCfg.OAuth2.ClientID,
+			clientSecret: secret,
+			scopes:       authCfg.OAuth2.Scopes,
+			now:          time.Now,
+		}, nil
+	case apiKeyAuthType:
+		key, err := lookupAuthEnv(authCfg.APIKey.Env)
+		if err != nil {
+			return nil, err
+		}
+
+		return apiKeyProvider{
+			header: valueOrDefault(authCfg.APIKey.Header, defaultAPIKeyHeader),
+			value:  authCfg.APIKey.Prefix + key,
+		}, nil
+	case hmacAuthType:
+		secret, err := lookupAuthEnv(authCfg.HMAC.SecretEnv)
+		if err != nil {
+			return nil, err
+		}
+
+		return hmacProvider{
+			secret:          []byte(secret),
+			header:          valueOrDefault(authCfg.HMAC.Header, defaultHMACHeader),
+			timestampHeader: valueOrDefault(authCfg.HMAC.TimestampHeader, defaultHMACTimestampHeader),
+			now:             time.Now,
+		}, nil
+	default:
+		return nil, errors.Wrapf(errUnknownAuthType, "type %s", authCfg.Type)
+	}
+}
+
+// authKey returns the resource parameters
+// that require a dedicated authProvider.
+func authKey(cfg conf.ResourceClientConf) conf.ResourceClientConf {
+	key := transportConf(cfg)
+	key.Auth = cfg.Auth
+
+	return key
+}
+
+func lookupAuthEnv(name string) (string, error) {
+	value, found := os.LookupEnv(name)
+	if !found || value == "" {
+		return "", errors.Wrapf(errMissingAuthEnv, "variable %s", name)
+	}
+
+	return value, nil
+}
+
+func valueOrDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
+
+// apiKeyProvider sends a static key from
+// the environment in a request header.
+type apiKeyProvider struct {
+	header string
+	value  string
+}
+
+func (p apiKeyProvider) Authenticate(req *fasthttp.Request, _ time.Duration) error {
+	req.Header.Set(p.header, p.value)
+	return nil
+}
+
+// hmacProvider signs the request method, URI, timestamp and body
+// using HMAC-SHA256, sending the signature and timestamp in headers.
+//
+// The signed content is the hex encoded signature of:
+//
+//	<method>\n<request uri>\n<unix timestamp>\n<hex encoded sha256 of the body>
+type hmacProvider struct {
+	secret          []byte
+	header          string
+	timestampHeader string
+	now             func() time.Time
+}
+
+func (p hmacProvider) Authenticate(req *fasthttp.Request, _ time.Duration) error {
+	timestamp := strconv.FormatInt(p.now().Unix(), 10)
+	bodyHash := sha256.Sum256(req.Body())
+
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(req.Header.Method())
+	mac.Write([]byte("\n"))
+	mac.Write(req.URI().RequestURI())
+	mac.Write([]byte("\n"))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("\n"))
+	mac.Write([]byte(hex.EncodeToString(bodyHash[:])))
+
+	req.Header.Set(p.timestampHeader, timestamp)
+	req.Header.Set(p.header, hex.EncodeToString(mac.Sum(nil)))
+
+	return nil
+}
+
+type oauth2Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+
+	expiration time.Time
+}
+
+func (t *oauth2Token) authorization() string {
+	tokenType := t.TokenType
+	if tokenType == "" || tokenType == "bearer" {
+		tokenType = "Bearer"
+	}
+
+	return tokenType + " " + t.AccessToken
+}
+
+// oauth2Provider obtains an access token using the client
+// credentials grant, caching it until it is about to expire
+// or is rejected by the upstream.
+type oauth2Provider struct {
+	do           func(req *fasthttp.Request, res *fasthttp.Response, timeout time.Duration) error
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scopes       string
+	now          func() time.Time
+
+	group singleflight.Group
+	mu    sync.RWMutex
+	token *oauth2Token
+}
+
+func (p *oauth2Provider) Authenticate(req *fasthttp.Request, timeout time.Duration) error {
+	token, err := p.currentToken(timeout)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set(fasthttp.HeaderAuthorization, token.authorization())
+	return nil
+}
+
+// discard drops the cached token if it is the one sent on the
+// rejected authorization, since another request may already
+// have replaced it.
+func (p *oauth2Provider) discard(authorization string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != nil && p.token.authorization() == authorization {
+		p.token = nil
+	}
+}
+
+func (p *oauth2Provider) currentToken(timeout time.Duration) (*oauth2Token, error) {
+	p.mu.RLock()
+	token := p.token
+	p.mu.RUnlock()
+
+	if token != nil && p.now().Before(token.expiration) {
+		return token, nil
+	}
+
+	v, err, _ := p.group.Do(p.tokenURL, func() (interface{}, error) {
+		return p.fetchToken(timeout)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*oauth2Token), nil
+}
+
+func (p *oauth2Provider) fetchToken(timeout time.Duration) (*oauth2Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if p.scopes != "" {
+		form.Set("scope", p.scopes)
+	}
+
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	res := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(res)
+
+	req.SetRequestURI(p.tokenURL)
+	req.Header.SetMethod(fasthttp.MethodPost)
+	req.Header.SetContentType("application/x-www-form-urlencoded")
+	req.Header.Set(fasthttp.HeaderAccept, "application/json")
+	credentials := url.QueryEscape(p.clientID) + ":" + url.QueryEscape(p.clientSecret)
+	req.Header.Set(fasthttp.HeaderAuthorization, "Basic "+base64.StdEncoding.EncodeToString([]byte(credentials)))
+	req.SetBodyString(form.Encode())
+
+	err := p.do(req, res, timeout)
+	if err != nil {
+		return nil, errors.Wrapf(errTokenRequestFail, "token request failed: %v", err)
+	}
+
+	if res.StatusCode() != fasthttp.StatusOK {
+		return nil, errors.Wrapf(errTokenRequestFail, "token endpoint responded with status %d", res.StatusCode())
+	}
+
+	var token oauth2Token
+	err = json.Unmarshal(res.Body(), &token)
+	if err != nil || token.AccessToken == "" {
+		return nil, errors.Wrap(errTokenRequestFail, "invalid token response")
+	}
+
+	lifetime := defaultTokenLifetime
+	if token.ExpiresIn > 0 {
+		lifetime = time.Duration(token.ExpiresIn) * time.Second
+	}
+	token.expiration = p.now().Add(tokenRefreshAfter(lifetime))
+
+	p.mu.Lock()
+	p.token = &token
+	p.mu.Unlock()
+
+	return &token, nil
+}
+
+// tokenRefreshAfter returns how long a token with the given
+// lifetime is used, anticipating its expiration by at most
+// a quarter of the lifetime.
+func tokenRefreshAfter(lifetime time.Duration) time.Duration {
+	delta := tokenExpiryDelta
+	if quarter := lifetime / 4; quarter < delta {
+		delta = quarter
+	}
+
+	return lifetime - delta
+}
@@ -0,0 +1,216 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+func TestAPIKeyAuth(t *testing.T) {
+	setEnv(t, "RESTQL_TEST_HERO_API_KEY", "abcdef")
+
+	cfg := conf.ResourceClientConf{}
+	cfg.Auth.Type = "apiKey"
+	cfg.Auth.APIKey.Env = "RESTQL_TEST_HERO_API_KEY"
+	cfg.Auth.APIKey.Header = "Authorization"
+	cfg.Auth.APIKey.Prefix = "ApiKey "
+
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+
+	err := newAuthProviders(nil).Authenticate(cfg, req, time.Second)
+	test.VerifyError(t, err)
+
+	test.Equal(t, string(req.Header.Peek("Authorization")), "ApiKey abcdef")
+}
+
+func TestAuthWithMissingEnv(t *testing.T) {
+	cfg := conf.ResourceClientConf{}
+	cfg.Auth.Type = "apiKey"
+	cfg.Auth.APIKey.Env = "RESTQL_TEST_UNDEFINED_API_KEY"
+
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+
+	providers := newAuthProviders(nil)
+
+	err := providers.Authenticate(cfg, req, time.Second)
+	if !errors.Is(err, errMissingAuthEnv) {
+		t.Errorf("expected missing auth env error, got %v", err)
+	}
+
+	setEnv(t, "RESTQL_TEST_UNDEFINED_API_KEY", "abcdef")
+
+	err = providers.Authenticate(cfg, req, time.Second)
+	test.VerifyError(t, err)
+	test.Equal(t, string(req.Header.Peek("X-Api-Key")), "abcdef")
+}
+
+func TestHMACAuth(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	provider := hmacProvider{
+		secret:          []byte("secret"),
+		header:          defaultHMACHeader,
+		timestampHeader: defaultHMACTimestampHeader,
+		now:             func() time.Time { return now },
+	}
+
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	req.SetRequestURI("http://hero.api/hero?id=1")
+	req.Header.SetMethod("POST")
+	req.SetBodyString(`{"name": "batman"}`)
+
+	err := provider.Authenticate(req, time.Second)
+	test.VerifyError(t, err)
+
+	bodyHash := sha256.Sum256([]byte(`{"name": "batman"}`))
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("POST\n/hero?id=1\n1600000000\n" + hex.EncodeToString(bodyHash[:])))
+
+	test.Equal(t, string(req.Header.Peek("X-Signature-Timestamp")), "1600000000")
+	test.Equal(t, string(req.Header.Peek("X-Signature")), hex.EncodeToString(mac.Sum(nil)))
+}
+
+func TestOAuth2Auth(t *testing.T) {
+	var tokenRequests int32
+	tokenServer := startUpstream(t, func(ctx *fasthttp.RequestCtx) {
+		n := atomic.AddInt32(&tokenRequests, 1)
+
+		if string(ctx.PostArgs().Peek("grant_type")) != "client_credentials" || string(ctx.PostArgs().Peek("scope")) != "heroes:read" {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		// base64 of "restql:secret"
+		if string(ctx.Request.Header.Peek("Authorization")) != "Basic cmVzdHFsOnNlY3JldA==" {
+			ctx.SetStatusCode(fasthttp.StatusUnauthorized)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetBodyString(`{"access_token": "token-` + strconv.Itoa(int(n)) + `", "token_type": "bearer", "expires_in": 3600}`)
+	})
+
+	now := time.Now()
+	provider := &oauth2Provider{
+		do:           (&fasthttp.Client{}).DoTimeout,
+		tokenURL:     "http://" + tokenServer + "/oauth/token",
+		clientID:     "restql",
+		clientSecret: "secret",
+		scopes:       "heroes:read",
+		now:          func() time.Time { return now },
+	}
+
+	authenticate := func() string {
+		req := fasthttp.AcquireRequest()
+		defer fasthttp.ReleaseRequest(req)
+
+		err := provider.Authenticate(req, time.Second)
+		test.VerifyError(t, err)
+
+		return string(req.Header.Peek("Authorization"))
+	}
+
+	test.Equal(t, authenticate(), "Bearer token-1")
+	test.Equal(t, authenticate(), "Bearer token-1")
+	test.Equal(t, atomic.LoadInt32(&tokenRequests), int32(1))
+
+	now = now.Add(time.Hour)
+
+	test.Equal(t, authenticate(), "Bearer token-2")
+	test.Equal(t, atomic.LoadInt32(&tokenRequests), int32(2))
+}
+
+func TestTokenRefreshAfter(t *testing.T) {
+	tests := []struct {
+		name     string
+		lifetime time.Duration
+		expected time.Duration
+	}{
+		{"should anticipate the expiration of long lived tokens", time.Hour, time.Hour - tokenExpiryDelta},
+		{"should anticipate the expiration of short lived tokens by a quarter", 20 * time.Second, 15 * time.Second},
+		{"should not anticipate the expiration of tokens without lifetime", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.Equal(t, tokenRefreshAfter(tt.lifetime), tt.expected)
+		})
+	}
+}
+
+func TestOAuth2AuthFailure(t *testing.T) {
+	tokenServer := startUpstream(t, func(ctx *fasthttp.RequestCtx) {
+		ctx.SetStatusCode(fasthttp.StatusUnauthorized)
+	})
+
+	provider := &oauth2Provider{do: (&fasthttp.Client{}).DoTimeout, tokenURL: "http://" + tokenServer + "/oauth/token", now: time.Now}
+
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+
+	err := provider.Authenticate(req, time.Second)
+	if !errors.Is(err, errTokenRequestFail) {
+		t.Errorf("expected token request failure, got %v", err)
+	}
+}
+
+func TestOAuth2AuthRefreshOnUnauthorized(t *testing.T) {
+	setEnv(t, "RESTQL_TEST_HERO_CLIENT_SECRET", "secret")
+
+	var tokenRequests int32
+	tokenServer := startUpstream(t, func(ctx *fasthttp.RequestCtx) {
+		n := atomic.AddInt32(&tokenRequests, 1)
+		ctx.SetContentType("application/json")
+		ctx.SetBodyString(`{"access_token": "token-` + strconv.Itoa(int(n)) + `", "expires_in": 3600}`)
+	})
+
+	host := startUpstream(t, func(ctx *fasthttp.RequestCtx) {
+		if string(ctx.Request.Header.Peek("Authorization")) != "Bearer token-2" {
+			ctx.SetStatusCode(fasthttp.StatusUnauthorized)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetBodyString(`{"name": "batman"}`)
+	})
+
+	cfg := newTestConfig()
+	cfg.HTTP.Client.Auth.Type = "oauth2"
+	cfg.HTTP.Client.Auth.OAuth2.TokenURL = "http://" + tokenServer + "/oauth/token"
+	cfg.HTTP.Client.Auth.OAuth2.ClientID = "restql"
+	cfg.HTTP.Client.Auth.OAuth2.ClientSecretEnv = "RESTQL_TEST_HERO_CLIENT_SECRET"
+
+	client := newFastHTTPClient(noOpLogger, plugins.NoOpLifecycle, cfg, nil)
+	request := restql.HTTPRequest{Method: "GET", Schema: "http", Host: host, Path: "/hero", Timeout: time.Second}
+
+	response, err := client.Do(context.Background(), request)
+	test.VerifyError(t, err)
+	test.Equal(t, response.StatusCode, 200)
+	test.Equal(t, atomic.LoadInt32(&tokenRequests), int32(2))
+
+	response, err = client.Do(context.Background(), request)
+	test.VerifyError(t, err)
+	test.Equal(t, response.StatusCode, 200)
+	test.Equal(t, atomic.LoadInt32(&tokenRequests), int32(2))
+}
+
+func setEnv(t *testing.T, key, value string) {
+	err := os.Setenv(key, value)
+	test.VerifyError(t, err)
+	t.Cleanup(func() { _ = os.Unsetenv(key) })
+}
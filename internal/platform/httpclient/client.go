@@ -8,6 +8,17 @@ import (
 )
 
 // New constructs an HTTPClient instances.
-func New(log restql.Logger, pm plugins.Lifecycle, cfg *conf.Config) domain.HTTPClient {
-	return newFastHTTPClient(log, pm, cfg)
+func New(log restql.Logger, pm plugins.Lifecycle, cfg *conf.Config) (domain.HTTPClient, error) {
+	egress, err := newEgressPolicy(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	hc := newFastHTTPClient(log, pm, cfg, egress)
+	gc, err := newGRPCClient(log, pm, hc, hc.resources, hc.dialer, cfg, egress)
+	if err != nil {
+		return nil, err
+	}
+
+	return newGraphQLClient(log, gc, hc.resources, cfg.HTTP.ForwardPrefix), nil
 }
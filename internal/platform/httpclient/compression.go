@@ -0,0 +1,106 @@
+package httpclient
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/klauspost/compress/flate"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zlib"
+	"github.com/pkg/errors"
+	"github.com/valyala/fasthttp"
+)
+
+const supportedEncodings = "gzip, br, deflate"
+
+var (
+	errDecompressedBodyTooLarge = errors.New("decompressed body too large")
+	errUnsupportedEncoding      = errors.New("unsupported content encoding")
+)
+
+// setupCompression advertises the supported encodings to the upstream
+// and, when enabled, compresses the request body using gzip.
+func setupCompression(req *fasthttp.Request, cfg conf.ResourceClientConf) {
+	compressionCfg := cfg.Compression
+	if !compressionCfg.Disable {
+		req.Header.Set(fasthttp.HeaderAcceptEncoding, supportedEncodings)
+	}
+
+	body := req.Body()
+	if !compressionCfg.GzipRequestBody || len(body) == 0 {
+		return
+	}
+
+	compressed := fasthttp.AppendGzipBytes(nil, body)
+	req.SetBodyRaw(compressed)
+	req.Header.Set(fasthttp.HeaderContentEncoding, "gzip")
+}
+
+// decompressBody replaces an encoded response body by its decoded
+// content, failing if it exceeds the maximum size allowed.
+func decompressBody(res *fasthttp.Response, maxSize int64) error {
+	encoding := strings.ToLower(strings.TrimSpace(string(res.Header.Peek(fasthttp.HeaderContentEncoding))))
+	if encoding == "" || encoding == "identity" {
+		return nil
+	}
+
+	data, err := decompress(encoding, res.Body(), maxSize)
+	if err != nil {
+		return err
+	}
+
+	res.Header.Del(fasthttp.HeaderContentEncoding)
+	res.SetBody(data)
+
+	return nil
+}
+
+func decompress(encoding string, body []byte, maxSize int64) ([]byte, error) {
+	var r io.Reader
+	switch encoding {
+	case "gzip", "x-gzip":
+		gr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid gzip body")
+		}
+		defer gr.Close()
+		r = gr
+	case "deflate":
+		r = newDeflateReader(body)
+	case "br":
+		r = brotli.NewReader(bytes.NewReader(body))
+	default:
+		return nil, errors.Wrapf(errUnsupportedEncoding, "encoding %s", encoding)
+	}
+
+	if maxSize > 0 {
+		r = io.LimitReader(r, maxSize+1)
+	}
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %s body", encoding)
+	}
+
+	if maxSize > 0 && int64(len(data)) > maxSize {
+		return nil, errors.Wrapf(errDecompressedBodyTooLarge, "limit of %d bytes", maxSize)
+	}
+
+	return data, nil
+}
+
+// newDeflateReader handles both zlib wrapped content, as
+// defined by the HTTP specification, and raw deflate streams
+// sent by some servers.
+func newDeflateReader(body []byte) io.Reader {
+	zr, err := zlib.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return flate.NewReader(bytes.NewReader(body))
+	}
+
+	return zr
+}
@@ -0,0 +1,120 @@
+package httpclient
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/klauspost/compress/flate"
+	"github.com/klauspost/compress/zlib"
+	"github.com/valyala/fasthttp"
+)
+
+const heroBody = `{"id": 1, "name": "batman"}`
+
+func TestDecompressBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+	}{
+		{"should keep body without encoding", "", []byte(heroBody)},
+		{"should decompress gzip body", "gzip", fasthttp.AppendGzipBytes(nil, []byte(heroBody))},
+		{"should decompress brotli body", "br", brotliBytes(t, heroBody)},
+		{"should decompress zlib deflate body", "deflate", zlibBytes(t, heroBody)},
+		{"should decompress raw deflate body", "deflate", flateBytes(t, heroBody)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := fasthttp.AcquireResponse()
+			defer fasthttp.ReleaseResponse(res)
+
+			if tt.encoding != "" {
+				res.Header.Set(fasthttp.HeaderContentEncoding, tt.encoding)
+			}
+			res.SetBody(tt.body)
+
+			err := decompressBody(res, 1024)
+			test.VerifyError(t, err)
+
+			test.Equal(t, string(res.Body()), heroBody)
+			test.Equal(t, len(res.Header.Peek(fasthttp.HeaderContentEncoding)), 0)
+		})
+	}
+}
+
+func TestDecompressBodyLimit(t *testing.T) {
+	res := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(res)
+
+	res.Header.Set(fasthttp.HeaderContentEncoding, "gzip")
+	res.SetBody(fasthttp.AppendGzipBytes(nil, bytes.Repeat([]byte("a"), 4096)))
+
+	err := decompressBody(res, 1024)
+	if !errors.Is(err, errDecompressedBodyTooLarge) {
+		t.Errorf("expected decompressed body too large error, got %v", err)
+	}
+}
+
+func TestSetupCompression(t *testing.T) {
+	t.Run("should advertise encodings and keep body", func(t *testing.T) {
+		req := fasthttp.AcquireRequest()
+		defer fasthttp.ReleaseRequest(req)
+		req.SetBodyString(heroBody)
+
+		setupCompression(req, conf.ResourceClientConf{})
+
+		test.Equal(t, string(req.Header.Peek(fasthttp.HeaderAcceptEncoding)), "gzip, br, deflate")
+		test.Equal(t, string(req.Body()), heroBody)
+	})
+
+	t.Run("should gzip body when enabled", func(t *testing.T) {
+		req := fasthttp.AcquireRequest()
+		defer fasthttp.ReleaseRequest(req)
+		req.SetBodyString(heroBody)
+
+		cfg := conf.ResourceClientConf{}
+		cfg.Compression.Disable = true
+		cfg.Compression.GzipRequestBody = true
+		setupCompression(req, cfg)
+
+		test.Equal(t, len(req.Header.Peek(fasthttp.HeaderAcceptEncoding)), 0)
+		test.Equal(t, string(req.Header.Peek(fasthttp.HeaderContentEncoding)), "gzip")
+
+		body, err := fasthttp.AppendGunzipBytes(nil, req.Body())
+		test.VerifyError(t, err)
+		test.Equal(t, string(body), heroBody)
+	})
+}
+
+func brotliBytes(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	w := brotli.NewWriter(&buf)
+	_, err := w.Write([]byte(s))
+	test.VerifyError(t, err)
+	test.VerifyError(t, w.Close())
+	return buf.Bytes()
+}
+
+func zlibBytes(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	_, err := w.Write([]byte(s))
+	test.VerifyError(t, err)
+	test.VerifyError(t, w.Close())
+	return buf.Bytes()
+}
+
+func flateBytes(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	test.VerifyError(t, err)
+	_, err = w.Write([]byte(s))
+	test.VerifyError(t, err)
+	test.VerifyError(t, w.Close())
+	return buf.Bytes()
+}
@@ -0,0 +1,77 @@
+package httpclient
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/rs/dnscache"
+	"github.com/valyala/fasthttp"
+)
+
+// upstreamDialer opens the connections to the upstreams of every
+// protocol, resolving the services known by the discovery mechanisms,
+// caching DNS lookups, enforcing the egress policy on the resolved
+// addresses and tunneling through the proxy of the resource.
+type upstreamDialer struct {
+	discovery *serviceDiscovery
+	tcp       *fasthttp.TCPDialer
+	timeout   time.Duration
+}
+
+func newUpstreamDialer(log restql.Logger, cfg *conf.Config, egress *egressPolicy) *upstreamDialer {
+	clientCfg := cfg.HTTP.Client
+
+	r := &dnscache.Resolver{}
+	go func() {
+		t := time.NewTicker(clientCfg.DnsRefreshInterval)
+		defer t.Stop()
+		for range t.C {
+			r.Refresh(true)
+		}
+	}()
+	resolver := &net.Resolver{
+		PreferGo:     true,
+		StrictErrors: false,
+		Dial: func(ctx context.Context, network, address string) (conn net.Conn, err error) {
+			host, port, err := net.SplitHostPort(address)
+			if err != nil {
+				return nil, err
+			}
+			ips, err := r.LookupHost(ctx, host)
+			if err != nil {
+				return nil, err
+			}
+			for _, ip := range ips {
+				var dialer net.Dialer
+				conn, err = dialer.Dial(network, net.JoinHostPort(ip, port))
+				if err == nil {
+					break
+				}
+			}
+			return
+		},
+	}
+
+	return &upstreamDialer{
+		discovery: newServiceDiscovery(log, cfg),
+		tcp:       &fasthttp.TCPDialer{Resolver: egressResolver{resolver: resolver, policy: egress}},
+		timeout:   clientCfg.ConnTimeout,
+	}
+}
+
+// Dial returns the dial function for the upstreams
+// of the given schema and resource parameters.
+func (ud *upstreamDialer) Dial(schema string, cfg conf.ResourceClientConf) (fasthttp.DialFunc, error) {
+	if schema == unixSchema {
+		return newUnixDialer(ud.timeout), nil
+	}
+
+	resolve := func(addr string) (string, error) {
+		return ud.discovery.Target(context.Background(), schema, addr)
+	}
+
+	return newProxyDialer(transportSchema(schema), cfg, resolve, ud.tcp.Dial, ud.timeout)
+}
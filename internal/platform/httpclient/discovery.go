@@ -0,0 +1,350 @@
+package httpclient
+
+import (
+	"context"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Mapping schemas resolved through service discovery.
+// Both are called using plain HTTP once the endpoint is found.
+const (
+	srvSchema      = "srv"
+	registrySchema = "registry"
+)
+
+var (
+	errServiceNotFound = errors.New("service not found in registry")
+	errNoSrvRecords    = errors.New("no srv records found")
+)
+
+// serviceDiscovery translates service names into dialable
+// addresses using DNS SRV records or a file-based registry.
+type serviceDiscovery struct {
+	srv      *srvResolver
+	registry *fileRegistry
+}
+
+func newServiceDiscovery(log restql.Logger, cfg *conf.Config) *serviceDiscovery {
+	discoveryCfg := cfg.HTTP.Client.Discovery
+
+	sd := &serviceDiscovery{
+		srv: newSrvResolver(net.DefaultResolver, discoveryCfg.SrvRefreshInterval),
+	}
+
+	registryPath := discoveryCfg.Registry.Path
+	if registryPath != "" {
+		registry, err := newFileRegistry(log, registryPath)
+		if err != nil {
+			log.Error("failed to load service registry", err, "path", registryPath)
+		}
+
+		go registry.Watch(discoveryCfg.Registry.WatchInterval)
+		sd.registry = registry
+	}
+
+	return sd
+}
+
+// Target returns the address that should be dialed for the given
+// address. SRV names are resolved for any schema, while the registry
+// is only used by the registry schema, so mappings of other schemas
+// are never rerouted to a service of the same name. When the host is
+// not a service known by the discovery mechanisms the address is
+// returned as is.
+func (sd *serviceDiscovery) Target(ctx context.Context, schema string, addr string) (string, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", err
+	}
+
+	if isSrvName(host) {
+		return sd.srv.Lookup(ctx, host)
+	}
+
+	if schema == registrySchema && sd.registry != nil {
+		if endpoint, found := sd.registry.Lookup(host); found {
+			return endpoint, nil
+		}
+	}
+
+	return addr, nil
+}
+
+// Validate checks if a request using the registry schema
+// references a service present in the registry.
+func (sd *serviceDiscovery) Validate(request restql.HTTPRequest) error {
+	if request.Schema != registrySchema {
+		return nil
+	}
+
+	if sd.registry == nil || !sd.registry.Has(request.Host) {
+		return errors.Wrapf(errServiceNotFound, "service %s", request.Host)
+	}
+
+	return nil
+}
+
+func transportSchema(schema string) string {
+	switch schema {
+	case srvSchema, registrySchema, unixSchema, graphqlSchema, grpcSchema:
+		return "http"
+	case graphqlsSchema, grpcsSchema:
+		return "https"
+	default:
+		return schema
+	}
+}
+
+func isSrvName(host string) bool {
+	if !strings.HasPrefix(host, "_") {
+		return false
+	}
+
+	return strings.Contains(host, "._tcp.") || strings.Contains(host, "._udp.")
+}
+
+// srvLookupTimeout bounds each SRV lookup, so that a DNS outage
+// does not hold new connections for longer than a dial would.
+const srvLookupTimeout = 5 * time.Second
+
+type srvLookup interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+type srvEntry struct {
+	records    []*net.SRV
+	expiration time.Time
+}
+
+// srvResolver caches SRV lookups and selects a target
+// respecting record priority and weight.
+type srvResolver struct {
+	resolver        srvLookup
+	refreshInterval time.Duration
+
+	mu      sync.RWMutex
+	entries map[string]srvEntry
+}
+
+func newSrvResolver(resolver srvLookup, refreshInterval time.Duration) *srvResolver {
+	return &srvResolver{resolver: resolver, refreshInterval: refreshInterval, entries: make(map[string]srvEntry)}
+}
+
+func (s *srvResolver) Lookup(ctx context.Context, name string) (string, error) {
+	records, err := s.records(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	record := pickSrvRecord(records, rand.Intn)
+	if record == nil {
+		return "", errors.Wrapf(errNoSrvRecords, "name %s", name)
+	}
+
+	target := strings.TrimSuffix(record.Target, ".")
+	return net.JoinHostPort(target, strconv.Itoa(int(record.Port))), nil
+}
+
+func (s *srvResolver) records(ctx context.Context, name string) ([]*net.SRV, error) {
+	s.mu.RLock()
+	entry, found := s.entries[name]
+	s.mu.RUnlock()
+
+	if found && time.Now().Before(entry.expiration) {
+		return entry.records, nil
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, srvLookupTimeout)
+	defer cancel()
+
+	_, records, err := s.resolver.LookupSRV(lookupCtx, "", "", name)
+	switch {
+	case err != nil && found:
+		// keep serving the last known records and only try
+		// again after another refresh interval
+		s.mu.Lock()
+		s.entries[name] = srvEntry{records: entry.records, expiration: time.Now().Add(s.refreshInterval)}
+		s.mu.Unlock()
+
+		return entry.records, nil
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to lookup srv records for %s", name)
+	case len(records) == 0:
+		return nil, errors.Wrapf(errNoSrvRecords, "name %s", name)
+	}
+
+	s.mu.Lock()
+	s.entries[name] = srvEntry{records: records, expiration: time.Now().Add(s.refreshInterval)}
+	s.mu.Unlock()
+
+	return records, nil
+}
+
+// pickSrvRecord selects a record among the ones with the lowest
+// priority, using the weight as the probability of being chosen.
+func pickSrvRecord(records []*net.SRV, intn func(int) int) *net.SRV {
+	if len(records) == 0 {
+		return nil
+	}
+
+	lowest := records[0].Priority
+	for _, r := range records {
+		if r.Priority < lowest {
+			lowest = r.Priority
+		}
+	}
+
+	var candidates []*net.SRV
+	totalWeight := 0
+	for _, r := range records {
+		if r.Priority == lowest {
+			candidates = append(candidates, r)
+			totalWeight += int(r.Weight)
+		}
+	}
+
+	if totalWeight == 0 {
+		return candidates[intn(len(candidates))]
+	}
+
+	n := intn(totalWeight)
+	for _, c := range candidates {
+		n -= int(c.Weight)
+		if n < 0 {
+			return c
+		}
+	}
+
+	return candidates[len(candidates)-1]
+}
+
+// fileRegistry is a service registry backed by a JSON or YAML file
+// that maps a service name to a list of endpoints, like:
+//
+//	products:
+//	  - 10.0.0.1:8080
+//	  - products.internal:8080
+//
+// The file is watched for changes and reloaded without restart.
+type fileRegistry struct {
+	next uint64
+	log  restql.Logger
+	path string
+
+	mu       sync.RWMutex
+	services map[string][]string
+	modTime  time.Time
+	size     int64
+}
+
+func newFileRegistry(log restql.Logger, path string) (*fileRegistry, error) {
+	r := &fileRegistry{log: log, path: path, services: make(map[string][]string)}
+	_, err := r.Reload()
+	return r, err
+}
+
+// Has returns true if the service is present in the registry.
+func (r *fileRegistry) Has(service string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, found := r.services[service]
+	return found
+}
+
+// Lookup returns one of the service endpoints, distributing
+// calls among them in a round-robin fashion.
+func (r *fileRegistry) Lookup(service string) (string, bool) {
+	r.mu.RLock()
+	endpoints := r.services[service]
+	r.mu.RUnlock()
+
+	if len(endpoints) == 0 {
+		return "", false
+	}
+
+	n := atomic.AddUint64(&r.next, 1)
+	return endpoints[n%uint64(len(endpoints))], true
+}
+
+// Reload reads the registry file if it has changed
+// since the last successful load.
+func (r *fileRegistry) Reload() (bool, error) {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	unchanged := info.ModTime().Equal(r.modTime) && info.Size() == r.size
+	r.mu.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	data, err := ioutil.ReadFile(r.path)
+	if err != nil {
+		return false, err
+	}
+
+	services, err := parseRegistry(data)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	r.services = services
+	r.modTime = info.ModTime()
+	r.size = info.Size()
+	r.mu.Unlock()
+
+	return true, nil
+}
+
+// Watch periodically reloads the registry file.
+func (r *fileRegistry) Watch(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		reloaded, err := r.Reload()
+		if err != nil {
+			r.log.Error("failed to reload service registry", err, "path", r.path)
+			continue
+		}
+
+		if reloaded {
+			r.log.Info("service registry reloaded", "path", r.path)
+		}
+	}
+}
+
+// parseRegistry accepts both YAML and JSON, since
+// the later is a subset of the former.
+func parseRegistry(data []byte) (map[string][]string, error) {
+	services := make(map[string][]string)
+	err := yaml.Unmarshal(data, &services)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid service registry")
+	}
+
+	return services, nil
+}
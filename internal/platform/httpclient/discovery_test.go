@@ -0,0 +1,165 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/logger"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+var noOpLogger = logger.New(ioutil.Discard, logger.LogOptions{})
+
+func TestPickSrvRecord(t *testing.T) {
+	records := []*net.SRV{
+		{Target: "backup.internal.", Port: 8080, Priority: 20, Weight: 100},
+		{Target: "primary-a.internal.", Port: 8080, Priority: 10, Weight: 10},
+		{Target: "primary-b.internal.", Port: 8080, Priority: 10, Weight: 30},
+	}
+
+	tests := []struct {
+		name     string
+		random   int
+		expected string
+	}{
+		{"should pick the first lowest priority record within its weight", 9, "primary-a.internal."},
+		{"should pick the second lowest priority record within its weight", 10, "primary-b.internal."},
+		{"should pick the last lowest priority record at the upper bound", 39, "primary-b.internal."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pickSrvRecord(records, func(int) int { return tt.random })
+			test.Equal(t, got.Target, tt.expected)
+		})
+	}
+
+	t.Run("should return nil when there is no record", func(t *testing.T) {
+		got := pickSrvRecord(nil, func(int) int { return 0 })
+		if got != nil {
+			t.Errorf("expected nil record, got %v", got)
+		}
+	})
+}
+
+func TestIsSrvName(t *testing.T) {
+	test.Equal(t, isSrvName("_products._tcp.internal"), true)
+	test.Equal(t, isSrvName("_products._udp.internal"), true)
+	test.Equal(t, isSrvName("products.internal"), false)
+	test.Equal(t, isSrvName("_products.internal"), false)
+}
+
+func TestSrvResolver(t *testing.T) {
+	lookup := &stubSrvLookup{records: []*net.SRV{{Target: "hero.internal.", Port: 8080}}}
+	resolver := newSrvResolver(lookup, time.Minute)
+
+	target, err := resolver.Lookup(context.Background(), "_hero._tcp.internal")
+	test.VerifyError(t, err)
+	test.Equal(t, target, "hero.internal:8080")
+
+	t.Run("should bound the lookup with a timeout", func(t *testing.T) {
+		_, hasDeadline := lookup.lastCtx.Deadline()
+		test.Equal(t, hasDeadline, true)
+	})
+
+	t.Run("should keep the stale records until the next refresh when the lookup fails", func(t *testing.T) {
+		resolver.entries["_hero._tcp.internal"] = srvEntry{records: lookup.records, expiration: time.Now().Add(-time.Second)}
+		lookup.err = errors.New("dns unavailable")
+		lookup.calls = 0
+
+		for i := 0; i < 3; i++ {
+			target, err := resolver.Lookup(context.Background(), "_hero._tcp.internal")
+			test.VerifyError(t, err)
+			test.Equal(t, target, "hero.internal:8080")
+		}
+
+		test.Equal(t, lookup.calls, 1)
+	})
+}
+
+type stubSrvLookup struct {
+	records []*net.SRV
+	err     error
+	calls   int
+	lastCtx context.Context
+}
+
+func (s *stubSrvLookup) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	s.calls++
+	s.lastCtx = ctx
+	if s.err != nil {
+		return "", nil, s.err
+	}
+
+	return name, s.records, nil
+}
+
+func TestFileRegistry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "registry")
+	test.VerifyError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "registry.json")
+	writeRegistry(t, path, `{"products": ["10.0.0.1:8080", "10.0.0.2:8080"]}`, time.Now().Add(-time.Minute))
+
+	registry, err := newFileRegistry(noOpLogger, path)
+	test.VerifyError(t, err)
+
+	sd := &serviceDiscovery{registry: registry}
+
+	first, err := sd.Target(context.Background(), registrySchema, "products:80")
+	test.VerifyError(t, err)
+	second, err := sd.Target(context.Background(), registrySchema, "products:80")
+	test.VerifyError(t, err)
+
+	test.NotEqual(t, first, second)
+
+	unknown, err := sd.Target(context.Background(), registrySchema, "hero.api:80")
+	test.VerifyError(t, err)
+	test.Equal(t, unknown, "hero.api:80")
+
+	direct, err := sd.Target(context.Background(), "http", "products:80")
+	test.VerifyError(t, err)
+	test.Equal(t, direct, "products:80")
+
+	writeRegistry(t, path, "products:\n  - 10.0.0.3:9090\n", time.Now())
+
+	reloaded, err := registry.Reload()
+	test.VerifyError(t, err)
+	test.Equal(t, reloaded, true)
+
+	got, err := sd.Target(context.Background(), registrySchema, "products:80")
+	test.VerifyError(t, err)
+	test.Equal(t, got, "10.0.0.3:9090")
+}
+
+func TestServiceDiscoveryValidate(t *testing.T) {
+	registry := &fileRegistry{services: map[string][]string{"products": {"10.0.0.1:8080"}}}
+	sd := &serviceDiscovery{registry: registry}
+
+	err := sd.Validate(restql.HTTPRequest{Schema: "registry", Host: "products"})
+	test.VerifyError(t, err)
+
+	err = sd.Validate(restql.HTTPRequest{Schema: "registry", Host: "unknown"})
+	if err == nil {
+		t.Errorf("expected error for unknown service")
+	}
+
+	err = sd.Validate(restql.HTTPRequest{Schema: "http", Host: "unknown"})
+	test.VerifyError(t, err)
+}
+
+func writeRegistry(t *testing.T, path string, content string, modTime time.Time) {
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	test.VerifyError(t, err)
+
+	err = os.Chtimes(path, modTime, modTime)
+	test.VerifyError(t, err)
+}
@@ -0,0 +1,261 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/pkg/errors"
+	"github.com/valyala/fasthttp"
+)
+
+var (
+	errInvalidEgressMode = errors.New("invalid egress mode")
+	errInvalidEgressRule = errors.New("invalid egress rule")
+)
+
+const (
+	egressModeDevelopment = "development"
+	egressModeProduction  = "production"
+)
+
+// Loopback, unspecified, link-local and cloud metadata
+// destinations, denied by default in production mode.
+var (
+	productionDeniedNetworks = []string{
+		"127.0.0.0/8",
+		"::1/128",
+		"0.0.0.0/8",
+		"::/128",
+		"169.254.0.0/16",
+		"fe80::/10",
+		"100.100.100.200/32",
+		"fd00:ec2::254/128",
+	}
+	productionDeniedHosts = []string{
+		"localhost",
+		"*.localhost",
+		"metadata",
+		"metadata.google.internal",
+	}
+)
+
+// egressPolicy decides which upstream addresses restQL can connect to.
+// Rules are IP addresses, CIDRs, unix socket paths or host names, where
+// a leading *. matches any subdomain, and a destination is denied when it
+// matches a deny rule unless it also matches an allow rule.
+// Host names are checked before the DNS resolution and every
+// resolved address after it, so that a permitted name cannot
+// be used to reach a denied network. A nil egressPolicy
+// allows every destination.
+type egressPolicy struct {
+	production bool
+	allowNets  []*net.IPNet
+	denyNets   []*net.IPNet
+	allowHosts []string
+	denyHosts  []string
+}
+
+func newEgressPolicy(cfg *conf.Config) (*egressPolicy, error) {
+	egressCfg := cfg.HTTP.Client.Egress
+
+	p := &egressPolicy{}
+	switch strings.ToLower(egressCfg.Mode) {
+	case "", egressModeDevelopment:
+	case egressModeProduction:
+		p.production = true
+	default:
+		return nil, errors.Wrapf(errInvalidEgressMode, "%s", egressCfg.Mode)
+	}
+
+	if !p.production && len(egressCfg.Allow) == 0 && len(egressCfg.Deny) == 0 {
+		return nil, nil
+	}
+
+	var deny []string
+	if p.production {
+		deny = append(deny, productionDeniedNetworks...)
+		deny = append(deny, productionDeniedHosts...)
+	}
+	deny = append(deny, egressCfg.Deny...)
+
+	var err error
+	p.allowNets, p.allowHosts, err = parseEgressRules(egressCfg.Allow)
+	if err != nil {
+		return nil, err
+	}
+	p.denyNets, p.denyHosts, err = parseEgressRules(deny)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func parseEgressRules(rules []string) ([]*net.IPNet, []string, error) {
+	var nets []*net.IPNet
+	var hosts []string
+
+	for _, r := range rules {
+		rule := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(r), "."))
+		switch {
+		case rule == "":
+			return nil, nil, errors.Wrapf(errInvalidEgressRule, "%q", r)
+		case strings.HasPrefix(rule, "/"):
+			hosts = append(hosts, strings.TrimSpace(r))
+		case strings.Contains(rule, "/"):
+			_, ipNet, err := net.ParseCIDR(rule)
+			if err != nil {
+				return nil, nil, errors.Wrapf(errInvalidEgressRule, "%q", r)
+			}
+			nets = append(nets, ipNet)
+		case net.ParseIP(rule) != nil:
+			ip := net.ParseIP(rule)
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+		default:
+			hosts = append(hosts, rule)
+		}
+	}
+
+	return nets, hosts, nil
+}
+
+// checkTarget verifies the host of a request before it is dispatched,
+// which is the only check made for unix sockets and for requests
+// sent through a proxy, since the proxy resolves the host itself.
+// In production mode, unix sockets must be explicitly allowed.
+func (p *egressPolicy) checkTarget(schema string, host string) error {
+	if p == nil {
+		return nil
+	}
+
+	if schema == unixSchema {
+		if matchSocket(p.allowHosts, host) {
+			return nil
+		}
+		if p.production || matchSocket(p.denyHosts, host) {
+			return egressDenied(host)
+		}
+		return nil
+	}
+
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return p.checkIP(host, ip)
+	}
+
+	_, err := p.checkHost(host)
+	return err
+}
+
+// checkHost verifies a host name, telling whether it is explicitly allowed,
+// in which case its resolved addresses do not need to be checked.
+func (p *egressPolicy) checkHost(host string) (bool, error) {
+	if p == nil {
+		return true, nil
+	}
+
+	if matchHost(p.allowHosts, host) {
+		return true, nil
+	}
+	if matchHost(p.denyHosts, host) {
+		return false, egressDenied(host)
+	}
+
+	return false, nil
+}
+
+func (p *egressPolicy) checkIP(host string, ip net.IP) error {
+	if p == nil || matchNetwork(p.allowNets, ip) {
+		return nil
+	}
+	if matchNetwork(p.denyNets, ip) {
+		return egressDenied(host)
+	}
+
+	return nil
+}
+
+// egressResolver wraps the resolver used by the dialer,
+// removing the addresses the egress policy denies and
+// failing if none is left.
+type egressResolver struct {
+	resolver fasthttp.Resolver
+	policy   *egressPolicy
+}
+
+func (r egressResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	trusted, err := r.policy.checkHost(host)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := r.resolver.LookupIPAddr(ctx, host)
+	if err != nil || trusted {
+		return addrs, err
+	}
+
+	allowed := make([]net.IPAddr, 0, len(addrs))
+	for _, addr := range addrs {
+		err = r.policy.checkIP(host, addr.IP)
+		if err == nil {
+			allowed = append(allowed, addr)
+		}
+	}
+	if len(allowed) == 0 && err != nil {
+		return nil, err
+	}
+
+	return allowed, nil
+}
+
+func matchHost(patterns []string, host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, p := range patterns {
+		switch {
+		case p == "*":
+			return true
+		case strings.HasPrefix(p, "*."):
+			if strings.HasSuffix(host, p[1:]) {
+				return true
+			}
+		case p == host:
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchSocket(patterns []string, path string) bool {
+	for _, p := range patterns {
+		if p == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchNetwork(nets []*net.IPNet, ip net.IP) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func egressDenied(host string) error {
+	return fmt.Errorf("%w: %s", domain.ErrEgressDenied, host)
+}
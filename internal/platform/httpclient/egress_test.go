@@ -0,0 +1,161 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+func TestEgressPolicyCheckTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		allow    []string
+		deny     []string
+		schema   string
+		host     string
+		expected bool
+	}{
+		{"allows everything without rules", "", nil, nil, "http", "169.254.169.254", true},
+		{"denies metadata address in production", "production", nil, nil, "http", "169.254.169.254:80", false},
+		{"denies loopback address in production", "production", nil, nil, "http", "127.0.0.1:8080", false},
+		{"denies ipv6 loopback address in production", "production", nil, nil, "http", "[::1]:8080", false},
+		{"denies ipv4 mapped loopback address in production", "production", nil, nil, "http", "[::ffff:127.0.0.1]:8080", false},
+		{"denies metadata host in production", "production", nil, nil, "http", "metadata.google.internal", false},
+		{"denies localhost subdomain in production", "production", nil, nil, "http", "hero.localhost:9000", false},
+		{"allows public address in production", "production", nil, nil, "https", "93.184.216.34", true},
+		{"allows explicitly allowed address in production", "production", []string{"127.0.0.1"}, nil, "http", "127.0.0.1:8080", true},
+		{"denies unix socket in production", "production", nil, nil, "unix", "/var/run/docker.sock", false},
+		{"allows explicitly allowed unix socket in production", "production", []string{"/var/run/hero.sock"}, nil, "unix", "/var/run/hero.sock", true},
+		{"denies configured network", "", nil, []string{"10.0.0.0/8"}, "http", "10.1.2.3", false},
+		{"denies configured host pattern", "", nil, []string{"*.internal"}, "http", "admin.Internal:8080", false},
+		{"allow rule overrides deny rule", "", []string{"10.1.0.0/16"}, []string{"0.0.0.0/0"}, "http", "10.1.2.3", true},
+		{"deny all except allowed", "", []string{"10.1.0.0/16"}, []string{"0.0.0.0/0"}, "http", "10.2.0.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestConfig()
+			cfg.HTTP.Client.Egress.Mode = tt.mode
+			cfg.HTTP.Client.Egress.Allow = tt.allow
+			cfg.HTTP.Client.Egress.Deny = tt.deny
+
+			policy, err := newEgressPolicy(cfg)
+			test.VerifyError(t, err)
+
+			err = policy.checkTarget(tt.schema, tt.host)
+			if tt.expected && err != nil {
+				t.Fatalf("expected %s to be allowed, got %v", tt.host, err)
+			}
+			if !tt.expected && !errors.Is(err, domain.ErrEgressDenied) {
+				t.Fatalf("expected %s to be denied, got %v", tt.host, err)
+			}
+		})
+	}
+}
+
+func TestNewEgressPolicyInvalidConfig(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.HTTP.Client.Egress.Mode = "strict"
+
+	_, err := newEgressPolicy(cfg)
+	test.Equal(t, errors.Is(err, errInvalidEgressMode), true)
+
+	cfg = newTestConfig()
+	cfg.HTTP.Client.Egress.Deny = []string{"10.0.0.0/33"}
+
+	_, err = newEgressPolicy(cfg)
+	test.Equal(t, errors.Is(err, errInvalidEgressRule), true)
+}
+
+type staticResolver map[string][]net.IPAddr
+
+func (r staticResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	return r[host], nil
+}
+
+func TestEgressResolver(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.HTTP.Client.Egress.Mode = "production"
+	cfg.HTTP.Client.Egress.Allow = []string{"sidecar.local"}
+	policy, err := newEgressPolicy(cfg)
+	test.VerifyError(t, err)
+
+	public := net.IPAddr{IP: net.ParseIP("93.184.216.34")}
+	metadata := net.IPAddr{IP: net.ParseIP("169.254.169.254")}
+	loopback := net.IPAddr{IP: net.ParseIP("127.0.0.1")}
+
+	resolver := egressResolver{
+		policy: policy,
+		resolver: staticResolver{
+			"hero.api":      {public},
+			"mixed.api":     {metadata, public},
+			"rebind.api":    {metadata},
+			"sidecar.local": {loopback},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		host     string
+		expected []net.IPAddr
+		denied   bool
+	}{
+		{"keeps allowed addresses", "hero.api", []net.IPAddr{public}, false},
+		{"removes denied addresses", "mixed.api", []net.IPAddr{public}, false},
+		{"fails when every address is denied", "rebind.api", nil, true},
+		{"keeps every address of allowed host", "sidecar.local", []net.IPAddr{loopback}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addrs, err := resolver.LookupIPAddr(context.Background(), tt.host)
+
+			test.Equal(t, errors.Is(err, domain.ErrEgressDenied), tt.denied)
+			test.Equal(t, len(addrs), len(tt.expected))
+			for i := range tt.expected {
+				test.Equal(t, addrs[i].IP.Equal(tt.expected[i].IP), true)
+			}
+		})
+	}
+}
+
+func TestFastHTTPClientEgressPolicy(t *testing.T) {
+	host := startUpstream(t, func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+		ctx.SetBodyString(`{"name": "batman"}`)
+	})
+	_, port, err := net.SplitHostPort(host)
+	test.VerifyError(t, err)
+
+	cfg := newTestConfig()
+	cfg.HTTP.Client.Egress.Deny = []string{"127.0.0.0/8", "::1"}
+	policy, err := newEgressPolicy(cfg)
+	test.VerifyError(t, err)
+
+	client := newFastHTTPClient(noOpLogger, plugins.NoOpLifecycle, cfg, policy)
+
+	t.Run("should deny address before dispatching the request", func(t *testing.T) {
+		request := restql.HTTPRequest{Method: "GET", Schema: "http", Host: host, Path: "/hero", Timeout: time.Second}
+
+		response, err := client.Do(context.Background(), request)
+		test.Equal(t, errors.Is(err, domain.ErrEgressDenied), true)
+		test.Equal(t, response.StatusCode, 403)
+	})
+
+	t.Run("should deny address after resolving the host", func(t *testing.T) {
+		request := restql.HTTPRequest{Method: "GET", Schema: "http", Host: net.JoinHostPort("localhost", port), Path: "/hero", Timeout: time.Second}
+
+		response, err := client.Do(context.Background(), request)
+		test.Equal(t, errors.Is(err, domain.ErrEgressDenied), true)
+		test.Equal(t, response.StatusCode, 403)
+	})
+}
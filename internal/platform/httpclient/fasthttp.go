@@ -8,14 +8,15 @@ import (
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"github.com/pkg/errors"
-	"github.com/rs/dnscache"
 	"github.com/valyala/fasthttp"
-	"net"
 	"sync"
 	"time"
 )
 
-var errInvalidJSON = errors.New("invalid json")
+var (
+	errInvalidJSON          = errors.New("invalid json")
+	errResponseBodyTooLarge = errors.New("response body too large")
+)
 
 type httpResult struct {
 	target   string
@@ -25,47 +26,21 @@ type httpResult struct {
 }
 
 type fastHTTPClient struct {
-	client       *fasthttp.Client
+	clients      *clientPool
 	log          restql.Logger
 	lifecycle    plugins.Lifecycle
+	discovery    *serviceDiscovery
+	dialer       *upstreamDialer
+	resources    *resourceOptions
+	auth         *authProviders
+	egress       *egressPolicy
 	responsePool *sync.Pool
 }
 
-func newFastHTTPClient(log restql.Logger, pm plugins.Lifecycle, cfg *conf.Config) *fastHTTPClient {
+func newFastHTTPClient(log restql.Logger, pm plugins.Lifecycle, cfg *conf.Config, egress *egressPolicy) *fastHTTPClient {
 	clientCfg := cfg.HTTP.Client
 
-	r := &dnscache.Resolver{}
-	go func() {
-		t := time.NewTicker(clientCfg.DnsRefreshInterval)
-		defer t.Stop()
-		for range t.C {
-			r.Refresh(true)
-		}
-	}()
-	dialer := &fasthttp.TCPDialer{
-		Resolver: &net.Resolver{
-			PreferGo:     true,
-			StrictErrors: false,
-			Dial: func(ctx context.Context, network, address string) (conn net.Conn, err error) {
-				host, port, err := net.SplitHostPort(address)
-				if err != nil {
-					return nil, err
-				}
-				ips, err := r.LookupHost(ctx, host)
-				if err != nil {
-					return nil, err
-				}
-				for _, ip := range ips {
-					var dialer net.Dialer
-					conn, err = dialer.Dial(network, net.JoinHostPort(ip, port))
-					if err == nil {
-						break
-					}
-				}
-				return
-			},
-		},
-	}
+	dialer := newUpstreamDialer(log, cfg, egress)
 
 	rp := &sync.Pool{
 		New: func() interface{} {
@@ -73,28 +48,102 @@ func newFastHTTPClient(log restql.Logger, pm plugins.Lifecycle, cfg *conf.Config
 		},
 	}
 
-	c := &fasthttp.Client{
-		Name:                          "restql",
-		NoDefaultUserAgentHeader:      false,
-		DisableHeaderNamesNormalizing: true,
-		Dial:                          dialer.Dial,
-		MaxConnsPerHost:               clientCfg.MaxConnsPerHost,
-		MaxIdleConnDuration:           clientCfg.MaxIdleConnDuration,
-		MaxConnWaitTimeout:            clientCfg.ConnTimeout,
+	newClient := func(key clientKey) (*fasthttp.Client, error) {
+		resourceCfg := key.cfg
+
+		dial, err := dialer.Dial(key.schema, resourceCfg)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid upstream proxy configuration")
+		}
+
+		// Requests fail instead of being sent without the
+		// configured certificates or minimum version.
+		tlsConfig, err := newTLSConfig(log, resourceCfg, clientCfg.TLSWatchInterval)
+		if err != nil {
+			return nil, err
+		}
+
+		if tlsConfig != nil && tlsConfig.VerifyConnection != nil && transportSchema(key.schema) == "https" {
+			dial = dialTLS(dial, tlsConfig, clientCfg.ConnTimeout)
+		}
+
+		return &fasthttp.Client{
+			Name:                          "restql",
+			NoDefaultUserAgentHeader:      false,
+			DisableHeaderNamesNormalizing: true,
+			Dial:                          dial,
+			MaxConnsPerHost:               clientCfg.MaxConnsPerHost,
+			MaxIdleConnDuration:           clientCfg.MaxIdleConnDuration,
+			MaxConnWaitTimeout:            clientCfg.ConnTimeout,
+			MaxResponseBodySize:           resourceCfg.MaxResponseBodySize,
+			TLSConfig:                     tlsConfig,
+		}, nil
+	}
+
+	hc := &fastHTTPClient{
+		clients:      newClientPool(newClient),
+		log:          log,
+		lifecycle:    pm,
+		discovery:    dialer.discovery,
+		dialer:       dialer,
+		resources:    newResourceOptions(cfg),
+		egress:       egress,
+		responsePool: rp,
 	}
+	hc.auth = newAuthProviders(hc.requestToken)
 
-	return &fastHTTPClient{client: c, log: log, lifecycle: pm, responsePool: rp}
+	return hc
+}
+
+// requestToken sends a request to the token endpoint of a resource
+// with the same client and egress policy of the resource requests.
+func (hc *fastHTTPClient) requestToken(cfg conf.ResourceClientConf, req *fasthttp.Request, res *fasthttp.Response, timeout time.Duration) error {
+	schema := string(req.URI().Scheme())
+	err := hc.egress.checkTarget(schema, string(req.URI().Host()))
+	if err != nil {
+		return err
+	}
+
+	client, err := hc.clients.Get(schema, cfg)
+	if err != nil {
+		return err
+	}
+
+	return client.DoTimeout(req, res, timeout)
 }
 
 func (hc *fastHTTPClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
 	requestCtx := hc.lifecycle.BeforeRequest(ctx, request)
+	opts, err := hc.resources.Get(ctx)
+	if err != nil {
+		hc.log.Error("failed to resolve upstream client parameters", err)
+		response := makeErrorResponse(request.Host, 0, fasthttp.StatusInternalServerError)
+		hc.lifecycle.AfterRequest(requestCtx, request, response, err)
+
+		return response, err
+	}
+
+	err = hc.egress.checkTarget(request.Schema, request.Host)
+	if err != nil {
+		return hc.denyEgress(requestCtx, request, request.Host, 0, err)
+	}
 
 	c := hc.responsePool.Get().(chan httpResult)
 
 	go func() {
 		req := fasthttp.AcquireRequest()
 
-		err := setupRequest(request, req)
+		client, err := hc.clients.Get(request.Schema, opts)
+		if err == nil {
+			err = hc.discovery.Validate(request)
+		}
+		if err == nil {
+			err = setupRequest(request, req)
+		}
+		if err == nil {
+			setupCompression(req, opts)
+			err = hc.auth.Authenticate(opts, req, request.Timeout)
+		}
 		if err != nil {
 			hc.log.Error("failed to setup http client request", err)
 			fasthttp.ReleaseRequest(req)
@@ -104,10 +153,21 @@ func (hc *fastHTTPClient) Do(ctx context.Context, request restql.HTTPRequest) (r
 
 		res := fasthttp.AcquireResponse()
 		start := time.Now()
-		err = hc.client.DoTimeout(req, res, request.Timeout)
+		err = client.DoTimeout(req, res, request.Timeout)
+
+		// A rejected token may have been revoked before its expiration,
+		// hence the request is sent once more with a new one.
+		remaining := request.Timeout - time.Since(start)
+		if err == nil && res.StatusCode() == fasthttp.StatusUnauthorized && remaining > 0 && hc.auth.Refresh(opts, req) {
+			err = hc.auth.Authenticate(opts, req, remaining)
+			if err == nil {
+				res.Reset()
+				err = client.DoTimeout(req, res, request.Timeout-time.Since(start))
+			}
+		}
 		finish := time.Since(start)
 
-		reqUri := req.URI().String()
+		reqUri := requestURL(request, req)
 		fasthttp.ReleaseRequest(req)
 
 		c <- httpResult{target: reqUri, err: err, duration: finish, response: res}
@@ -127,18 +187,50 @@ func (hc *fastHTTPClient) Do(ctx context.Context, request restql.HTTPRequest) (r
 		hc.lifecycle.AfterRequest(requestCtx, request, response, err)
 
 		return response, domain.ErrRequestTimeout
-	case hr.err != nil:
-		response := makeErrorResponse(hr.target, hr.duration, hr.response.StatusCode())
+	case errors.Is(hr.err, domain.ErrEgressDenied):
+		fasthttp.ReleaseResponse(hr.response)
+
+		return hc.denyEgress(requestCtx, request, hr.target, hr.duration, hr.err)
+	case hr.err == fasthttp.ErrBodyTooLarge:
+		statusCode := hr.response.StatusCode()
+		contentLength := hr.response.Header.ContentLength()
+		hc.log.Info("response body too large", "url", hr.target, "method", request.Method, "statusCode", statusCode, "contentLength", contentLength)
+
+		response := makeErrorResponse(hr.target, hr.duration, fasthttp.StatusBadGateway)
+		response.Headers = readHeaders(hr.response)
+
+		fasthttp.ReleaseResponse(hr.response)
+
+		err := makeBodyTooLargeError(statusCode, contentLength, opts.MaxResponseBodySize)
+		hc.lifecycle.AfterRequest(requestCtx, request, response, err)
 
+		return response, err
+	case hr.err != nil:
+		var statusCode int
 		if hr.response != nil {
+			statusCode = hr.response.StatusCode()
 			fasthttp.ReleaseResponse(hr.response)
 		}
 
+		response := makeErrorResponse(hr.target, hr.duration, statusCode)
+
 		hc.lifecycle.AfterRequest(requestCtx, request, response, hr.err)
 
 		return response, errors.Wrap(hr.err, "request execution failed")
 	}
 
+	err = decompressBody(hr.response, opts.Compression.MaxDecompressedSize)
+	if err != nil {
+		hc.log.Info("failed to decompress response body", "url", hr.target, "method", request.Method, "statusCode", hr.response.StatusCode(), "error", err)
+		response := makeErrorResponse(hr.target, hr.duration, fasthttp.StatusBadGateway)
+
+		fasthttp.ReleaseResponse(hr.response)
+
+		hc.lifecycle.AfterRequest(requestCtx, request, response, err)
+
+		return response, errors.Wrap(err, "failed to decompress response body")
+	}
+
 	body, err := unmarshalBody(hc.log, hr.response)
 	if err != nil {
 		hc.log.Error("invalid json as body", err, "url", hr.target, "body", body.Unmarshal(), "statusCode", hr.response.StatusCode())
@@ -158,3 +250,12 @@ func (hc *fastHTTPClient) Do(ctx context.Context, request restql.HTTPRequest) (r
 
 	return response, nil
 }
+
+func (hc *fastHTTPClient) denyEgress(requestCtx context.Context, request restql.HTTPRequest, target string, duration time.Duration, err error) (restql.HTTPResponse, error) {
+	hc.log.Warn("upstream address denied by egress policy", "url", target, "method", request.Method, "error", err.Error())
+	response := makeErrorResponse(target, duration, fasthttp.StatusForbidden)
+
+	hc.lifecycle.AfterRequest(requestCtx, request, response, err)
+
+	return response, err
+}
@@ -0,0 +1,102 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+func TestFastHTTPClientMaxResponseBodySize(t *testing.T) {
+	body := `{"name": "` + strings.Repeat("a", 2048) + `"}`
+	host := startUpstream(t, func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+		ctx.SetBodyString(body)
+	})
+
+	cfg := newTestConfig()
+	cfg.HTTP.Client.Resources = map[string]conf.ResourceClientOverride{"hero": {ResourceClientConf: conf.ResourceClientConf{MaxResponseBodySize: 1024}}}
+
+	client := newFastHTTPClient(noOpLogger, plugins.NoOpLifecycle, cfg, nil)
+	request := restql.HTTPRequest{Method: "GET", Schema: "http", Host: host, Path: "/hero", Timeout: time.Second}
+
+	t.Run("should return response when within the limit", func(t *testing.T) {
+		ctx := domain.WithUpstream(context.Background(), domain.Upstream{Resource: "sidekick"})
+
+		response, err := client.Do(ctx, request)
+		test.VerifyError(t, err)
+		test.Equal(t, response.StatusCode, 200)
+	})
+
+	t.Run("should fail when response exceeds the resource limit", func(t *testing.T) {
+		ctx := domain.WithUpstream(context.Background(), domain.Upstream{Resource: "hero"})
+
+		response, err := client.Do(ctx, request)
+		if !errors.Is(err, errResponseBodyTooLarge) {
+			t.Fatalf("expected response body too large error, got %v", err)
+		}
+
+		test.Equal(t, response.StatusCode, 502)
+		expected := fmt.Sprintf("upstream responded with status 200 and a body of %d bytes, exceeding the limit of 1024 bytes: response body too large", len(body))
+		test.Equal(t, err.Error(), expected)
+	})
+}
+
+func newTestConfig() *conf.Config {
+	cfg := &conf.Config{}
+	cfg.HTTP.Client.DnsRefreshInterval = time.Minute
+	cfg.HTTP.Client.MaxConnsPerHost = 10
+	cfg.HTTP.Client.ConnTimeout = time.Second
+	return cfg
+}
+
+func startUpstream(t *testing.T, handler fasthttp.RequestHandler) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	test.VerifyError(t, err)
+
+	s := &fasthttp.Server{Handler: handler}
+	go s.Serve(ln)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	return ln.Addr().String()
+}
+
+func TestFastHTTPClientUnixSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "unix")
+	test.VerifyError(t, err)
+	defer os.RemoveAll(dir)
+
+	socketPath := filepath.Join(dir, "hero.sock")
+	ln, err := net.Listen("unix", socketPath)
+	test.VerifyError(t, err)
+	defer ln.Close()
+
+	s := &fasthttp.Server{Handler: func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+		ctx.SetBodyString(`{"path": "` + string(ctx.Path()) + `"}`)
+	}}
+	go s.Serve(ln)
+
+	client := newFastHTTPClient(noOpLogger, plugins.NoOpLifecycle, newTestConfig(), nil)
+	request := restql.HTTPRequest{Method: "GET", Schema: "unix", Host: socketPath, Path: "/hero/1", Timeout: time.Second}
+
+	response, err := client.Do(context.Background(), request)
+	test.VerifyError(t, err)
+
+	test.Equal(t, response.StatusCode, 200)
+	test.Equal(t, response.URL, "unix://"+socketPath+":/hero/1")
+	test.Equal(t, response.Body.Unmarshal(), map[string]interface{}{"path": "/hero/1"})
+}
@@ -0,0 +1,130 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+	"github.com/valyala/fasthttp"
+)
+
+// Mapping schemas of GraphQL upstreams, called
+// using HTTP and HTTPS respectively.
+const (
+	graphqlSchema  = "graphql"
+	graphqlsSchema = "graphqls"
+)
+
+// Statement parameters used to define the operation inline
+// rather than in the resource parameters.
+const (
+	graphqlQueryParam     = "graphql-query"
+	graphqlOperationParam = "graphql-operation"
+)
+
+var errMissingGraphQLDocument = errors.New("graphql operation document not defined")
+
+// graphqlClient translates calls to GraphQL upstreams into
+// HTTP requests, using the statement parameters as the operation
+// variables, and unwraps the operation result from the response.
+type graphqlClient struct {
+	log           restql.Logger
+	next          domain.HTTPClient
+	resources     *resourceOptions
+	forwardPrefix string
+}
+
+func newGraphQLClient(log restql.Logger, next domain.HTTPClient, resources *resourceOptions, forwardPrefix string) graphqlClient {
+	return graphqlClient{log: log, next: next, resources: resources, forwardPrefix: forwardPrefix}
+}
+
+func (gc graphqlClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	if !isGraphQLSchema(request.Schema) {
+		return gc.next.Do(ctx, request)
+	}
+
+	gqlRequest, err := gc.makeRequest(ctx, request)
+	if err != nil {
+		return restql.HTTPResponse{StatusCode: fasthttp.StatusBadRequest}, err
+	}
+
+	response, err := gc.next.Do(ctx, gqlRequest)
+	if err != nil {
+		return response, err
+	}
+
+	return gc.unwrapResponse(response), nil
+}
+
+func (gc graphqlClient) makeRequest(ctx context.Context, request restql.HTTPRequest) (restql.HTTPRequest, error) {
+	opts, err := gc.resources.Get(ctx)
+	if err != nil {
+		return restql.HTTPRequest{}, err
+	}
+	graphqlCfg := opts.GraphQL
+
+	query, variables := splitParams(gc.forwardPrefix, request)
+
+	document := graphqlCfg.Document
+	if inline, ok := variables[graphqlQueryParam].(string); ok {
+		document = inline
+	}
+	delete(variables, graphqlQueryParam)
+
+	operationName := graphqlCfg.OperationName
+	if inline, ok := variables[graphqlOperationParam].(string); ok {
+		operationName = inline
+	}
+	delete(variables, graphqlOperationParam)
+
+	if document == "" {
+		return restql.HTTPRequest{}, errors.Wrapf(errMissingGraphQLDocument, "host %s", request.Host)
+	}
+
+	body := map[string]interface{}{"query": document, "variables": variables}
+	if operationName != "" {
+		body["operationName"] = operationName
+	}
+
+	gqlRequest := request
+	gqlRequest.Method = http.MethodPost
+	gqlRequest.Query = query
+	gqlRequest.Body = body
+
+	return gqlRequest, nil
+}
+
+// unwrapResponse returns the operation data as the response body
+// when it succeeds. If the upstream returns any error, the whole
+// response is kept and, even if the HTTP call succeeded, the
+// status is changed to mark the statement as failed.
+func (gc graphqlClient) unwrapResponse(response restql.HTTPResponse) restql.HTTPResponse {
+	if response.Body == nil {
+		return response
+	}
+
+	result, ok := response.Body.Unmarshal().(map[string]interface{})
+	if !ok {
+		return response
+	}
+
+	if errs, ok := result["errors"].([]interface{}); ok && len(errs) > 0 {
+		if response.StatusCode >= 200 && response.StatusCode < 300 {
+			response.StatusCode = fasthttp.StatusBadGateway
+		}
+		return response
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response
+	}
+
+	response.Body = restql.NewResponseBodyFromValue(gc.log, result["data"])
+	return response
+}
+
+func isGraphQLSchema(schema string) bool {
+	return schema == graphqlSchema || schema == graphqlsSchema
+}
@@ -0,0 +1,183 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+type stubHTTPClient struct {
+	request  *restql.HTTPRequest
+	response restql.HTTPResponse
+}
+
+func (s stubHTTPClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	*s.request = request
+	return s.response, nil
+}
+
+func TestGraphQLClientRequest(t *testing.T) {
+	cfg := &conf.Config{}
+	heroCfg := conf.ResourceClientConf{}
+	heroCfg.GraphQL.Document = "query Hero($id: ID!) { hero(id: $id) { name } }"
+	heroCfg.GraphQL.OperationName = "Hero"
+	cfg.HTTP.Client.Resources = map[string]conf.ResourceClientOverride{"hero": {ResourceClientConf: heroCfg}}
+
+	tests := []struct {
+		name     string
+		resource string
+		request  restql.HTTPRequest
+		expected restql.HTTPRequest
+	}{
+		{
+			"should send query parameters as variables using the resource document",
+			"hero",
+			restql.HTTPRequest{
+				Method: http.MethodGet,
+				Schema: "graphql",
+				Host:   "hero.api",
+				Path:   "/graphql",
+				Query:  map[string]interface{}{"id": "1", "c_universe": "dc"},
+			},
+			restql.HTTPRequest{
+				Method: http.MethodPost,
+				Schema: "graphql",
+				Host:   "hero.api",
+				Path:   "/graphql",
+				Query:  map[string]interface{}{"c_universe": "dc"},
+				Body: map[string]interface{}{
+					"query":         "query Hero($id: ID!) { hero(id: $id) { name } }",
+					"operationName": "Hero",
+					"variables":     map[string]interface{}{"id": "1"},
+				},
+			},
+		},
+		{
+			"should send body as variables using an inline document",
+			"villain",
+			restql.HTTPRequest{
+				Method: http.MethodPost,
+				Schema: "graphqls",
+				Host:   "villain.api",
+				Path:   "/graphql",
+				Query:  map[string]interface{}{},
+				Body: map[string]interface{}{
+					"graphql-query": "mutation Add($name: String!) { addVillain(name: $name) { id } }",
+					"name":          "Joker",
+				},
+			},
+			restql.HTTPRequest{
+				Method: http.MethodPost,
+				Schema: "graphqls",
+				Host:   "villain.api",
+				Path:   "/graphql",
+				Query:  map[string]interface{}{},
+				Body: map[string]interface{}{
+					"query":     "mutation Add($name: String!) { addVillain(name: $name) { id } }",
+					"variables": map[string]interface{}{"name": "Joker"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got restql.HTTPRequest
+			next := stubHTTPClient{request: &got, response: restql.HTTPResponse{StatusCode: 200}}
+			client := newGraphQLClient(noOpLogger, next, newResourceOptions(cfg), "c_")
+
+			ctx := domain.WithUpstream(context.Background(), domain.Upstream{Resource: tt.resource})
+			_, err := client.Do(ctx, tt.request)
+			test.VerifyError(t, err)
+
+			test.Equal(t, got, tt.expected)
+		})
+	}
+}
+
+func TestGraphQLClientWithoutDocument(t *testing.T) {
+	var got restql.HTTPRequest
+	next := stubHTTPClient{request: &got}
+	client := newGraphQLClient(noOpLogger, next, newResourceOptions(&conf.Config{}), "c_")
+
+	response, err := client.Do(context.Background(), restql.HTTPRequest{Schema: "graphql", Host: "hero.api"})
+	if !errors.Is(err, errMissingGraphQLDocument) {
+		t.Fatalf("expected missing document error, got %v", err)
+	}
+	test.Equal(t, response.StatusCode, 400)
+}
+
+func TestGraphQLClientResponse(t *testing.T) {
+	tests := []struct {
+		name           string
+		statusCode     int
+		body           string
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			"should unwrap operation data",
+			200,
+			`{"data": {"hero": {"name": "Batman"}}}`,
+			200,
+			map[string]interface{}{"hero": map[string]interface{}{"name": "Batman"}},
+		},
+		{
+			"should fail statement when operation returns errors",
+			200,
+			`{"data": null, "errors": [{"message": "hero not found"}]}`,
+			502,
+			map[string]interface{}{"data": nil, "errors": []interface{}{map[string]interface{}{"message": "hero not found"}}},
+		},
+		{
+			"should keep upstream status and body when request fails",
+			500,
+			`{"errors": [{"message": "internal error"}]}`,
+			500,
+			map[string]interface{}{"errors": []interface{}{map[string]interface{}{"message": "internal error"}}},
+		},
+		{
+			"should keep response when it is not a graphql result",
+			200,
+			`[1, 2, 3]`,
+			200,
+			[]interface{}{float64(1), float64(2), float64(3)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got restql.HTTPRequest
+			next := stubHTTPClient{request: &got, response: restql.HTTPResponse{
+				StatusCode: tt.statusCode,
+				Body:       restql.NewResponseBodyFromBytes(noOpLogger, []byte(tt.body)),
+			}}
+			client := newGraphQLClient(noOpLogger, next, newResourceOptions(&conf.Config{}), "c_")
+
+			request := restql.HTTPRequest{Schema: "graphql", Host: "hero.api", Body: map[string]interface{}{"graphql-query": "{ hero { name } }"}}
+			response, err := client.Do(context.Background(), request)
+			test.VerifyError(t, err)
+
+			test.Equal(t, response.StatusCode, tt.expectedStatus)
+			test.Equal(t, response.Body.Unmarshal(), tt.expectedBody)
+		})
+	}
+}
+
+func TestGraphQLClientPassThrough(t *testing.T) {
+	var got restql.HTTPRequest
+	next := stubHTTPClient{request: &got, response: restql.HTTPResponse{StatusCode: 200}}
+	client := newGraphQLClient(noOpLogger, next, newResourceOptions(&conf.Config{}), "c_")
+
+	request := restql.HTTPRequest{Method: http.MethodGet, Schema: "http", Host: "hero.api", Query: map[string]interface{}{"id": "1"}}
+	_, err := client.Do(context.Background(), request)
+	test.VerifyError(t, err)
+
+	test.Equal(t, got, request)
+}
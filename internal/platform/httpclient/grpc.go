@@ -0,0 +1,398 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Mapping schemas of gRPC upstreams, called
+// using plaintext and TLS respectively.
+const (
+	grpcSchema  = "grpc"
+	grpcsSchema = "grpcs"
+)
+
+var (
+	errInvalidGRPCMethod     = errors.New("invalid grpc method")
+	errGRPCMethodNotFound    = errors.New("grpc method not found in descriptor sets")
+	errStreamingNotSupported = errors.New("grpc streaming methods are not supported")
+)
+
+var grpcStatusCodes = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusRequestTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+var (
+	grpcMarshalOptions   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	grpcUnmarshalOptions = protojson.UnmarshalOptions{}
+)
+
+// grpcClient calls unary methods of gRPC upstreams, building the
+// request message from the statement parameters and converting
+// the response message to JSON. Messages are described by the
+// compiled descriptor sets given in the configuration.
+type grpcClient struct {
+	log           restql.Logger
+	lifecycle     plugins.Lifecycle
+	next          domain.HTTPClient
+	resources     *resourceOptions
+	files         *protoregistry.Files
+	forwardPrefix string
+	watchInterval time.Duration
+	dialer        *upstreamDialer
+	egress        *egressPolicy
+
+	mu    sync.Mutex
+	conns map[grpcConnKey]*grpc.ClientConn
+}
+
+// grpcConnKey identifies the parameters that
+// require a dedicated grpc.ClientConn, being the
+// transport ones among the resource parameters.
+type grpcConnKey struct {
+	schema string
+	host   string
+	cfg    conf.ResourceClientConf
+}
+
+func newGRPCClient(log restql.Logger, pm plugins.Lifecycle, next domain.HTTPClient, resources *resourceOptions, dialer *upstreamDialer, cfg *conf.Config, egress *egressPolicy) (*grpcClient, error) {
+	descriptorSets := cfg.HTTP.Client.GRPC.DescriptorSets
+
+	files, err := loadDescriptorSets(descriptorSets)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load grpc descriptor sets %s", strings.Join(descriptorSets, ","))
+	}
+
+	return &grpcClient{
+		log:           log,
+		lifecycle:     pm,
+		next:          next,
+		resources:     resources,
+		files:         files,
+		forwardPrefix: cfg.HTTP.ForwardPrefix,
+		watchInterval: cfg.HTTP.Client.TLSWatchInterval,
+		dialer:        dialer,
+		egress:        egress,
+		conns:         make(map[grpcConnKey]*grpc.ClientConn),
+	}, nil
+}
+
+func (gc *grpcClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	if !isGRPCSchema(request.Schema) {
+		return gc.next.Do(ctx, request)
+	}
+
+	requestCtx := gc.lifecycle.BeforeRequest(ctx, request)
+	target := fmt.Sprintf("%s://%s%s", request.Schema, request.Host, request.Path)
+
+	opts, err := gc.resources.Get(ctx)
+	if err != nil {
+		gc.log.Error("failed to resolve upstream client parameters", err)
+		response := makeErrorResponse(target, 0, http.StatusInternalServerError)
+		gc.lifecycle.AfterRequest(requestCtx, request, response, err)
+
+		return response, err
+	}
+
+	err = gc.egress.checkTarget(request.Schema, request.Host)
+	if err != nil {
+		gc.log.Warn("upstream address denied by egress policy", "url", target, "method", request.Path, "error", err.Error())
+		response := makeErrorResponse(target, 0, http.StatusForbidden)
+		gc.lifecycle.AfterRequest(requestCtx, request, response, err)
+
+		return response, err
+	}
+
+	method, input, err := gc.makeMessage(request)
+	if err != nil {
+		gc.log.Error("failed to setup grpc client request", err)
+		response := makeErrorResponse(target, 0, http.StatusBadRequest)
+		gc.lifecycle.AfterRequest(requestCtx, request, response, err)
+
+		return response, err
+	}
+
+	conn, err := gc.conn(request.Schema, request.Host, opts)
+	if err != nil {
+		response := makeErrorResponse(target, 0, 0)
+		gc.lifecycle.AfterRequest(requestCtx, request, response, err)
+
+		return response, errors.Wrap(err, "request execution failed")
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, request.Timeout)
+	defer cancel()
+	callCtx = metadata.NewOutgoingContext(callCtx, makeMetadata(request.Headers))
+
+	output := dynamicpb.NewMessage(method.Output())
+	var header metadata.MD
+
+	start := time.Now()
+	err = conn.Invoke(callCtx, request.Path, input, output, grpc.Header(&header))
+	duration := time.Since(start)
+
+	st := status.Convert(err)
+	if st.Code() == codes.DeadlineExceeded {
+		gc.log.Info("request timed out", "url", target, "method", request.Path, "duration-ms", duration.Milliseconds())
+		response := makeErrorResponse(target, duration, http.StatusRequestTimeout)
+
+		err := fmt.Errorf("%w: %s", err, domain.ErrRequestTimeout)
+		gc.lifecycle.AfterRequest(requestCtx, request, response, err)
+
+		return response, domain.ErrRequestTimeout
+	}
+
+	response := restql.HTTPResponse{
+		URL:        target,
+		StatusCode: grpcStatusCode(st.Code()),
+		Headers:    readMetadata(header),
+		Duration:   duration,
+		Body:       gc.makeBody(st, output),
+	}
+
+	gc.lifecycle.AfterRequest(requestCtx, request, response, nil)
+
+	return response, nil
+}
+
+// makeMessage finds the method referenced by the request path,
+// in the format /package.Service/Method, and builds its input
+// message from the statement parameters.
+func (gc *grpcClient) makeMessage(request restql.HTTPRequest) (protoreflect.MethodDescriptor, *dynamicpb.Message, error) {
+	method, err := gc.findMethod(request.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, params := splitParams(gc.forwardPrefix, request)
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to marshal grpc request parameters")
+	}
+
+	input := dynamicpb.NewMessage(method.Input())
+	err = grpcUnmarshalOptions.Unmarshal(data, input)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid parameters for grpc method %s", method.FullName())
+	}
+
+	return method, input, nil
+}
+
+func (gc *grpcClient) findMethod(path string) (protoreflect.MethodDescriptor, error) {
+	fullName := strings.Replace(strings.TrimPrefix(path, "/"), "/", ".", 1)
+	name := protoreflect.FullName(fullName)
+	if !name.IsValid() || strings.Count(strings.TrimPrefix(path, "/"), "/") != 1 {
+		return nil, errors.Wrapf(errInvalidGRPCMethod, "path %s", path)
+	}
+
+	d, err := gc.files.FindDescriptorByName(name)
+	if err != nil {
+		return nil, errors.Wrapf(errGRPCMethodNotFound, "method %s", name)
+	}
+
+	method, ok := d.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, errors.Wrapf(errGRPCMethodNotFound, "method %s", name)
+	}
+
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return nil, errors.Wrapf(errStreamingNotSupported, "method %s", name)
+	}
+
+	return method, nil
+}
+
+// makeBody returns the JSON representation of the response message
+// or, when the call failed, of the gRPC status code and message.
+func (gc *grpcClient) makeBody(st *status.Status, output *dynamicpb.Message) *restql.ResponseBody {
+	if st.Code() != codes.OK {
+		return restql.NewResponseBodyFromValue(gc.log, map[string]interface{}{
+			"code":    st.Code().String(),
+			"message": st.Message(),
+		})
+	}
+
+	data, err := grpcMarshalOptions.Marshal(output)
+	if err != nil {
+		gc.log.Error("failed to marshal grpc response message", err)
+		return restql.NewResponseBodyFromValue(gc.log, nil)
+	}
+
+	return restql.NewResponseBodyFromBytes(gc.log, data)
+}
+
+// conn returns the connection to the upstream, creating it on the
+// first call. Connections are established lazily and shared by every
+// resource with the same host and transport parameters. They are dialed like
+// the HTTP ones, hence with service discovery, the proxy settings
+// and the egress policy.
+func (gc *grpcClient) conn(schema string, host string, cfg conf.ResourceClientConf) (*grpc.ClientConn, error) {
+	cfg = transportConf(cfg)
+	key := grpcConnKey{schema: schema, host: host, cfg: cfg}
+
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	if conn, found := gc.conns[key]; found {
+		return conn, nil
+	}
+
+	transportCredentials := insecure.NewCredentials()
+	if schema == grpcsSchema {
+		tlsConfig, err := newTLSConfig(gc.log, cfg, gc.watchInterval)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid upstream tls configuration")
+		}
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+
+		serverName, _, err := net.SplitHostPort(host)
+		if err != nil {
+			serverName = host
+		}
+		tlsConfig = tlsConfigForHost(tlsConfig, serverName)
+
+		transportCredentials = credentials.NewTLS(tlsConfig)
+	}
+
+	dial, err := gc.dialer.Dial(schema, cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid upstream proxy configuration")
+	}
+
+	defaultPort := "80"
+	if schema == grpcsSchema {
+		defaultPort = "443"
+	}
+
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(transportCredentials),
+		grpc.WithUserAgent("restql"),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			if _, _, err := net.SplitHostPort(addr); err != nil {
+				addr = net.JoinHostPort(addr, defaultPort)
+			}
+
+			return dial(addr)
+		}),
+	}
+	if cfg.MaxResponseBodySize > 0 {
+		dialOptions = append(dialOptions, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(cfg.MaxResponseBodySize)))
+	}
+
+	conn, err := grpc.Dial(host, dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	gc.conns[key] = conn
+	return conn, nil
+}
+
+// loadDescriptorSets reads compiled descriptor set files, like the
+// ones generated by protoc with the --descriptor_set_out and
+// --include_imports options, into a single registry.
+func loadDescriptorSets(paths []string) (*protoregistry.Files, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		fileSet := &descriptorpb.FileDescriptorSet{}
+		err = proto.Unmarshal(data, fileSet)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid descriptor set %s", path)
+		}
+
+		for _, file := range fileSet.GetFile() {
+			if seen[file.GetName()] {
+				continue
+			}
+
+			seen[file.GetName()] = true
+			set.File = append(set.File, file)
+		}
+	}
+
+	return protodesc.NewFiles(set)
+}
+
+func makeMetadata(headers map[string]string) metadata.MD {
+	md := metadata.MD{}
+	for key, value := range headers {
+		md.Set(key, value)
+	}
+
+	return md
+}
+
+func readMetadata(md metadata.MD) map[string]string {
+	headers := make(map[string]string)
+	for key, values := range md {
+		headers[key] = strings.Join(values, ",")
+	}
+
+	return headers
+}
+
+func grpcStatusCode(code codes.Code) int {
+	statusCode, found := grpcStatusCodes[code]
+	if !found {
+		return http.StatusInternalServerError
+	}
+
+	return statusCode
+}
+
+func isGRPCSchema(schema string) bool {
+	return schema == grpcSchema || schema == grpcsSchema
+}
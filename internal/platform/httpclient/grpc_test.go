@@ -0,0 +1,263 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestGRPCClient(t *testing.T) {
+	path := writeHeroDescriptorSet(t)
+	files, err := loadDescriptorSets([]string{path})
+	test.VerifyError(t, err)
+
+	d, err := files.FindDescriptorByName("hero.HeroService.GetHero")
+	test.VerifyError(t, err)
+	method := d.(protoreflect.MethodDescriptor)
+
+	host := startGRPCUpstream(t, func(stream grpc.ServerStream) error {
+		input := dynamicpb.NewMessage(method.Input())
+		if err := stream.RecvMsg(input); err != nil {
+			return err
+		}
+
+		id := input.Get(method.Input().Fields().ByName("id")).Int()
+		if id != 1 {
+			return status.Error(codes.NotFound, "hero not found")
+		}
+
+		md, _ := metadata.FromIncomingContext(stream.Context())
+		_ = stream.SetHeader(metadata.MD{"x-tenant": md.Get("x-tenant")})
+
+		output := dynamicpb.NewMessage(method.Output())
+		output.Set(method.Output().Fields().ByName("name"), protoreflect.ValueOfString("Batman"))
+		return stream.SendMsg(output)
+	})
+
+	cfg := newTestConfig()
+	cfg.HTTP.ForwardPrefix = "c_"
+	cfg.HTTP.Client.GRPC.DescriptorSets = []string{path}
+	client, err := newGRPCClient(noOpLogger, plugins.NoOpLifecycle, nil, newResourceOptions(cfg), newUpstreamDialer(noOpLogger, cfg, nil), cfg, nil)
+	test.VerifyError(t, err)
+
+	tests := []struct {
+		name           string
+		request        restql.HTTPRequest
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{
+			"should call method with parameters as input message",
+			restql.HTTPRequest{
+				Schema:  "grpc",
+				Host:    host,
+				Path:    "/hero.HeroService/GetHero",
+				Query:   map[string]interface{}{"id": "1", "c_universe": "dc"},
+				Headers: map[string]string{"X-Tenant": "dc"},
+				Timeout: time.Second,
+			},
+			200,
+			map[string]interface{}{"id": "0", "name": "Batman", "powers": []interface{}{}},
+		},
+		{
+			"should map grpc status to http status",
+			restql.HTTPRequest{
+				Schema:  "grpc",
+				Host:    host,
+				Path:    "/hero.HeroService/GetHero",
+				Body:    map[string]interface{}{"id": 2},
+				Timeout: time.Second,
+			},
+			404,
+			map[string]interface{}{"code": "NotFound", "message": "hero not found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := client.Do(context.Background(), tt.request)
+			test.VerifyError(t, err)
+
+			test.Equal(t, response.StatusCode, tt.expectedStatus)
+			test.Equal(t, response.Body.Unmarshal(), tt.expectedBody)
+		})
+	}
+
+	t.Run("should forward headers as metadata", func(t *testing.T) {
+		request := tests[0].request
+		response, err := client.Do(context.Background(), request)
+		test.VerifyError(t, err)
+
+		test.Equal(t, response.Headers["x-tenant"], "dc")
+	})
+}
+
+func TestGRPCClientInvalidRequest(t *testing.T) {
+	path := writeHeroDescriptorSet(t)
+
+	cfg := newTestConfig()
+	cfg.HTTP.Client.GRPC.DescriptorSets = []string{path}
+	client, err := newGRPCClient(noOpLogger, plugins.NoOpLifecycle, nil, newResourceOptions(cfg), newUpstreamDialer(noOpLogger, cfg, nil), cfg, nil)
+	test.VerifyError(t, err)
+
+	tests := []struct {
+		name     string
+		path     string
+		query    map[string]interface{}
+		expected error
+	}{
+		{"should fail on invalid method path", "/hero.HeroService", nil, errInvalidGRPCMethod},
+		{"should fail on unknown method", "/hero.HeroService/GetVillain", nil, errGRPCMethodNotFound},
+		{"should fail on streaming method", "/hero.HeroService/ListHeroes", nil, errStreamingNotSupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := restql.HTTPRequest{Schema: "grpc", Host: "localhost:0", Path: tt.path, Query: tt.query, Timeout: time.Second}
+			response, err := client.Do(context.Background(), request)
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("expected error %v, got %v", tt.expected, err)
+			}
+
+			test.Equal(t, response.StatusCode, 400)
+		})
+	}
+
+	t.Run("should fail on unknown parameter", func(t *testing.T) {
+		request := restql.HTTPRequest{Schema: "grpc", Host: "localhost:0", Path: "/hero.HeroService/GetHero", Query: map[string]interface{}{"universe": "dc"}, Timeout: time.Second}
+		response, err := client.Do(context.Background(), request)
+		if err == nil {
+			t.Fatalf("expected error on unknown parameter")
+		}
+
+		test.Equal(t, response.StatusCode, 400)
+	})
+}
+
+func TestGRPCClientProxy(t *testing.T) {
+	path := writeHeroDescriptorSet(t)
+	files, err := loadDescriptorSets([]string{path})
+	test.VerifyError(t, err)
+
+	d, err := files.FindDescriptorByName("hero.HeroService.GetHero")
+	test.VerifyError(t, err)
+	method := d.(protoreflect.MethodDescriptor)
+
+	host := startGRPCUpstream(t, func(stream grpc.ServerStream) error {
+		input := dynamicpb.NewMessage(method.Input())
+		if err := stream.RecvMsg(input); err != nil {
+			return err
+		}
+
+		output := dynamicpb.NewMessage(method.Output())
+		output.Set(method.Output().Fields().ByName("name"), protoreflect.ValueOfString("Batman"))
+		return stream.SendMsg(output)
+	})
+	proxy := startConnectProxy(t)
+
+	cfg := newTestConfig()
+	cfg.HTTP.Client.GRPC.DescriptorSets = []string{path}
+	cfg.HTTP.Client.Proxy.URL = "http://" + proxy.addr
+	client, err := newGRPCClient(noOpLogger, plugins.NoOpLifecycle, nil, newResourceOptions(cfg), newUpstreamDialer(noOpLogger, cfg, nil), cfg, nil)
+	test.VerifyError(t, err)
+
+	request := restql.HTTPRequest{Schema: "grpc", Host: host, Path: "/hero.HeroService/GetHero", Timeout: time.Second}
+	response, err := client.Do(context.Background(), request)
+	test.VerifyError(t, err)
+
+	test.Equal(t, response.StatusCode, 200)
+	test.Equal(t, response.Body.Unmarshal(), map[string]interface{}{"id": "0", "name": "Batman", "powers": []interface{}{}})
+	test.Equal(t, proxy.requests(), []connectRequest{{Target: host}})
+}
+
+func TestGRPCClientInvalidDescriptorSets(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.HTTP.Client.GRPC.DescriptorSets = []string{filepath.Join(os.TempDir(), "missing-hero.pb")}
+
+	_, err := newGRPCClient(noOpLogger, plugins.NoOpLifecycle, nil, newResourceOptions(cfg), newUpstreamDialer(noOpLogger, cfg, nil), cfg, nil)
+	if err == nil {
+		t.Errorf("expected an error for a missing descriptor set")
+	}
+}
+
+func TestGRPCStatusCode(t *testing.T) {
+	test.Equal(t, grpcStatusCode(codes.OK), 200)
+	test.Equal(t, grpcStatusCode(codes.InvalidArgument), 400)
+	test.Equal(t, grpcStatusCode(codes.Unavailable), 503)
+	test.Equal(t, grpcStatusCode(codes.Code(99)), 500)
+}
+
+func writeHeroDescriptorSet(t *testing.T) string {
+	message := func(name string, fields ...*descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+		return &descriptorpb.DescriptorProto{Name: proto.String(name), Field: fields}
+	}
+	field := func(name string, number int32, label descriptorpb.FieldDescriptorProto_Label, kind descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{Name: proto.String(name), Number: proto.Int32(number), Label: label.Enum(), Type: kind.Enum(), JsonName: proto.String(name)}
+	}
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	repeated := descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("hero.proto"),
+		Package: proto.String("hero"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			message("GetHeroRequest", field("id", 1, optional, descriptorpb.FieldDescriptorProto_TYPE_INT32)),
+			message("Hero",
+				field("id", 1, optional, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+				field("name", 2, optional, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+				field("powers", 3, repeated, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+			),
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("HeroService"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				{Name: proto.String("GetHero"), InputType: proto.String(".hero.GetHeroRequest"), OutputType: proto.String(".hero.Hero")},
+				{Name: proto.String("ListHeroes"), InputType: proto.String(".hero.GetHeroRequest"), OutputType: proto.String(".hero.Hero"), ServerStreaming: proto.Bool(true)},
+			},
+		}},
+	}
+
+	data, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{file}})
+	test.VerifyError(t, err)
+
+	dir, err := ioutil.TempDir("", "descriptors")
+	test.VerifyError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "hero.pb")
+	err = ioutil.WriteFile(path, data, 0644)
+	test.VerifyError(t, err)
+
+	return path
+}
+
+func startGRPCUpstream(t *testing.T, handler func(stream grpc.ServerStream) error) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	test.VerifyError(t, err)
+
+	server := grpc.NewServer(grpc.UnknownServiceHandler(func(srv interface{}, stream grpc.ServerStream) error {
+		return handler(stream)
+	}))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
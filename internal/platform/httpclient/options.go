@@ -0,0 +1,46 @@
+package httpclient
+
+import (
+	"context"
+	"sync"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+)
+
+// resourceOptions resolves the client parameters of the
+// upstream targeted by a request, caching the merged result
+// since the configuration does not change at runtime.
+type resourceOptions struct {
+	cfg *conf.Config
+
+	mu    sync.RWMutex
+	cache map[domain.Upstream]conf.ResourceClientConf
+}
+
+func newResourceOptions(cfg *conf.Config) *resourceOptions {
+	return &resourceOptions{cfg: cfg, cache: make(map[domain.Upstream]conf.ResourceClientConf)}
+}
+
+func (ro *resourceOptions) Get(ctx context.Context) (conf.ResourceClientConf, error) {
+	upstream, _ := domain.GetUpstream(ctx)
+
+	ro.mu.RLock()
+	opts, found := ro.cache[upstream]
+	ro.mu.RUnlock()
+
+	if found {
+		return opts, nil
+	}
+
+	opts, err := ro.cfg.ResourceClient(upstream.Tenant, upstream.Resource)
+	if err != nil {
+		return conf.ResourceClientConf{}, err
+	}
+
+	ro.mu.Lock()
+	ro.cache[upstream] = opts
+	ro.mu.Unlock()
+
+	return opts, nil
+}
@@ -0,0 +1,71 @@
+package httpclient
+
+import (
+	"sync"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/valyala/fasthttp"
+)
+
+// clientKey identifies the parameters that require
+// a dedicated fasthttp.Client.
+type clientKey struct {
+	schema string
+	cfg    conf.ResourceClientConf
+}
+
+// transportConf returns only the resource parameters used to build
+// the transport to an upstream: the maximum response size, the TLS
+// and the proxy settings. Resources that differ on other parameters,
+// like the authentication or compression, share the same connections.
+func transportConf(cfg conf.ResourceClientConf) conf.ResourceClientConf {
+	return conf.ResourceClientConf{
+		MaxResponseBodySize: cfg.MaxResponseBodySize,
+		TLS:                 cfg.TLS,
+		Proxy:               cfg.Proxy,
+	}
+}
+
+// clientPool keeps one fasthttp.Client for each distinct schema and set
+// of transport parameters, since options like the maximum response size
+// or the dialer are defined per client. Resources sharing the same
+// parameters share the same client and connection pool. Clients that
+// fail to be created, like when the certificates are missing during
+// a rotation, are created again on the next request.
+type clientPool struct {
+	newClient func(key clientKey) (*fasthttp.Client, error)
+
+	mu      sync.RWMutex
+	clients map[clientKey]*fasthttp.Client
+}
+
+func newClientPool(newClient func(key clientKey) (*fasthttp.Client, error)) *clientPool {
+	return &clientPool{newClient: newClient, clients: make(map[clientKey]*fasthttp.Client)}
+}
+
+func (cp *clientPool) Get(schema string, cfg conf.ResourceClientConf) (*fasthttp.Client, error) {
+	key := clientKey{schema: schema, cfg: transportConf(cfg)}
+
+	cp.mu.RLock()
+	c, found := cp.clients[key]
+	cp.mu.RUnlock()
+
+	if found {
+		return c, nil
+	}
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if c, found := cp.clients[key]; found {
+		return c, nil
+	}
+
+	c, err := cp.newClient(key)
+	if err != nil {
+		return nil, err
+	}
+	cp.clients[key] = c
+
+	return c, nil
+}
@@ -0,0 +1,70 @@
+package httpclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+func TestClientPool(t *testing.T) {
+	var created []clientKey
+	var failure error
+	pool := newClientPool(func(key clientKey) (*fasthttp.Client, error) {
+		if failure != nil {
+			return nil, failure
+		}
+
+		created = append(created, key)
+		return &fasthttp.Client{}, nil
+	})
+
+	base := conf.ResourceClientConf{MaxResponseBodySize: 1024}
+
+	t.Run("should share the client among resources with the same transport parameters", func(t *testing.T) {
+		authCfg := base
+		authCfg.Auth.Type = apiKeyAuthType
+		authCfg.Compression.GzipRequestBody = true
+
+		c := getClient(t, pool, "http", base)
+
+		test.Equal(t, getClient(t, pool, "http", authCfg) == c, true)
+		test.Equal(t, len(created), 1)
+		test.Equal(t, created[0].cfg, base)
+	})
+
+	t.Run("should create a client for each schema and transport parameters", func(t *testing.T) {
+		tlsCfg := base
+		tlsCfg.TLS.ServerName = "hero.api"
+
+		c := getClient(t, pool, "http", base)
+
+		test.Equal(t, getClient(t, pool, "https", base) == c, false)
+		test.Equal(t, getClient(t, pool, "http", tlsCfg) == c, false)
+		test.Equal(t, len(created), 3)
+	})
+
+	t.Run("should create again a client that failed to be created", func(t *testing.T) {
+		proxyCfg := base
+		proxyCfg.Proxy.URL = "http://proxy.local"
+
+		failure = errors.New("certificate not found")
+		_, err := pool.Get("http", proxyCfg)
+		if err != failure {
+			t.Errorf("expected error %v, got %v", failure, err)
+		}
+
+		failure = nil
+		getClient(t, pool, "http", proxyCfg)
+		test.Equal(t, len(created), 4)
+	})
+}
+
+func getClient(t *testing.T, pool *clientPool, schema string, cfg conf.ResourceClientConf) *fasthttp.Client {
+	c, err := pool.Get(schema, cfg)
+	test.VerifyError(t, err)
+
+	return c
+}
@@ -0,0 +1,127 @@
+package httpclient
+
+import (
+	"bufio"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/pkg/errors"
+	"github.com/valyala/fasthttp"
+)
+
+var (
+	errProxyConnectFailed = errors.New("proxy connect failed")
+	errUnsupportedProxy   = errors.New("unsupported proxy")
+)
+
+type proxyFunc func(target string) (*url.URL, error)
+
+// newProxyDialer returns a dialer that resolves the target address using
+// service discovery and, if a proxy should be used, opens a tunnel to it
+// through the proxy with the CONNECT method.
+//
+// The proxy is defined by the resource parameters or, when absent,
+// by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func newProxyDialer(schema string, cfg conf.ResourceClientConf, resolve func(addr string) (string, error), dial fasthttp.DialFunc, timeout time.Duration) (fasthttp.DialFunc, error) {
+	proxy, err := newProxyFunc(schema, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(addr string) (net.Conn, error) {
+		target, err := resolve(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		proxyURL, err := proxy(target)
+		if err != nil {
+			return nil, err
+		}
+
+		if proxyURL == nil {
+			return dial(target)
+		}
+
+		return dialThroughProxy(proxyURL, target, dial, timeout)
+	}, nil
+}
+
+func newProxyFunc(schema string, cfg conf.ResourceClientConf) (proxyFunc, error) {
+	proxyCfg := cfg.Proxy
+	switch {
+	case proxyCfg.Disable:
+		return func(string) (*url.URL, error) { return nil, nil }, nil
+	case proxyCfg.URL != "":
+		proxyURL, err := url.Parse(proxyCfg.URL)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid proxy url %s", proxyCfg.URL)
+		}
+
+		return func(string) (*url.URL, error) { return proxyURL, nil }, nil
+	default:
+		return func(target string) (*url.URL, error) {
+			req := &http.Request{URL: &url.URL{Scheme: schema, Host: target}}
+			return http.ProxyFromEnvironment(req)
+		}, nil
+	}
+}
+
+func dialThroughProxy(proxyURL *url.URL, target string, dial fasthttp.DialFunc, timeout time.Duration) (net.Conn, error) {
+	if proxyURL.Scheme != "http" {
+		return nil, errors.Wrapf(errUnsupportedProxy, "scheme %s", proxyURL.Scheme)
+	}
+
+	conn, err := dial(proxyAddress(proxyURL))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial proxy %s", proxyURL.Host)
+	}
+
+	if timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(timeout))
+	}
+
+	req := "CONNECT " + target + " HTTP/1.1\r\nHost: " + target + "\r\n"
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(proxyURL.User.Username() + ":" + password))
+		req += "Proxy-Authorization: Basic " + credentials + "\r\n"
+	}
+	req += "\r\n"
+
+	_, err = conn.Write([]byte(req))
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "failed to send proxy connect request")
+	}
+
+	res, err := http.ReadResponse(bufio.NewReader(conn), &http.Request{Method: http.MethodConnect})
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "failed to read proxy connect response")
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, errors.Wrapf(errProxyConnectFailed, "proxy %s responded with status %d", proxyURL.Host, res.StatusCode)
+	}
+
+	if timeout > 0 {
+		_ = conn.SetDeadline(time.Time{})
+	}
+
+	return conn, nil
+}
+
+func proxyAddress(proxyURL *url.URL) string {
+	if proxyURL.Port() != "" {
+		return proxyURL.Host
+	}
+
+	return net.JoinHostPort(proxyURL.Hostname(), "80")
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 var (
@@ -22,8 +23,12 @@ func setupRequest(request restql.HTTPRequest, req *fasthttp.Request) error {
 		fasthttp.ReleaseURI(uri)
 	}()
 	uri.DisablePathNormalizing = true
-	uri.SetScheme(request.Schema)
-	uri.SetHost(request.Host)
+	uri.SetScheme(transportSchema(request.Schema))
+	if request.Schema == unixSchema {
+		uri.SetHost(encodeUnixHost(request.Host))
+	} else {
+		uri.SetHost(request.Host)
+	}
 	uri.SetPath(request.Path)
 	uri.SetQueryStringBytes(makeQueryArgs(uri.QueryString(), request))
 
@@ -110,3 +115,27 @@ func appendStringParam(buf *bytes.Buffer, key string, value string) {
 	buf.WriteString(url.QueryEscape(value))
 	buf.Write(ampersand)
 }
+
+// splitParams separates the forwarded parameters from the statement
+// parameters, which are read from both the query and the body, for
+// upstreams that receive them as a message instead of an URL.
+func splitParams(forwardPrefix string, request restql.HTTPRequest) (forwarded map[string]interface{}, params map[string]interface{}) {
+	forwarded = make(map[string]interface{})
+	params = make(map[string]interface{})
+	for key, value := range request.Query {
+		if forwardPrefix != "" && strings.HasPrefix(key, forwardPrefix) {
+			forwarded[key] = value
+			continue
+		}
+
+		params[key] = value
+	}
+
+	if body, ok := request.Body.(map[string]interface{}); ok {
+		for key, value := range body {
+			params[key] = value
+		}
+	}
+
+	return forwarded, params
+}
@@ -2,6 +2,7 @@ package httpclient
 
 import (
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
 	"github.com/valyala/fasthttp"
 	"time"
 )
@@ -12,6 +13,7 @@ func unmarshalBody(log restql.Logger, response *fasthttp.Response) (*restql.Resp
 	copy(bb, bodyByte)
 
 	rb := restql.NewResponseBodyFromBytes(log, bb)
+	rb.SetContentType(string(response.Header.ContentType()))
 	if !rb.Valid() {
 		return rb, errInvalidJSON
 	}
@@ -35,3 +37,11 @@ func makeErrorResponse(requestURL string, responseTime time.Duration, statusCode
 		Duration:   responseTime,
 	}
 }
+
+func makeBodyTooLargeError(statusCode int, contentLength int, limit int) error {
+	if contentLength < 0 {
+		return errors.Wrapf(errResponseBodyTooLarge, "upstream responded with status %d and a body exceeding the limit of %d bytes", statusCode, limit)
+	}
+
+	return errors.Wrapf(errResponseBodyTooLarge, "upstream responded with status %d and a body of %d bytes, exceeding the limit of %d bytes", statusCode, contentLength, limit)
+}
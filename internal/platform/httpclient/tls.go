@@ -0,0 +1,296 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+	"github.com/valyala/fasthttp"
+)
+
+var (
+	errNoClientCertificate = errors.New("client certificate not loaded")
+	errInvalidCABundle     = errors.New("no certificate found in ca bundle")
+	errNoPeerCertificate   = errors.New("upstream presented no certificate")
+	errNoCABundle          = errors.New("ca bundle not loaded")
+	errInvalidTLSConfig    = errors.New("invalid upstream tls configuration")
+	errUnknownServerName   = errors.New("upstream server name unknown")
+)
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// newTLSConfig builds the TLS configuration used to call upstreams.
+// Client certificates and CA bundles are read from files that are
+// watched for changes, allowing rotation without restart. It fails
+// if the files cannot be loaded, since ignoring them would trust
+// every system CA or skip the client certificate.
+func newTLSConfig(log restql.Logger, cfg conf.ResourceClientConf, watchInterval time.Duration) (*tls.Config, error) {
+	tlsCfg := cfg.TLS
+	if tlsCfg == (conf.ResourceClientConf{}).TLS {
+		return nil, nil
+	}
+
+	c := &tls.Config{
+		ServerName:         tlsCfg.ServerName,
+		InsecureSkipVerify: tlsCfg.InsecureSkipVerify,
+	}
+
+	if tlsCfg.MinVersion != "" {
+		version, found := tlsVersions[tlsCfg.MinVersion]
+		if !found {
+			return nil, errors.Wrapf(errInvalidTLSConfig, "min version %s", tlsCfg.MinVersion)
+		}
+		c.MinVersion = version
+	}
+
+	if tlsCfg.CertFile == "" && tlsCfg.CAFile == "" {
+		return c, nil
+	}
+
+	files, err := newTLSFiles(log, tlsCfg.CertFile, tlsCfg.KeyFile, tlsCfg.CAFile)
+	if err != nil {
+		return nil, errors.Wrapf(errInvalidTLSConfig, "failed to load certificates: %s", err)
+	}
+	go files.Watch(watchInterval)
+
+	if tlsCfg.CertFile != "" {
+		c.GetClientCertificate = files.ClientCertificate
+	}
+
+	// The standard verification uses the RootCAs defined when the
+	// configuration is created, hence it is replaced by one using
+	// the current CA bundle, so a reloaded bundle takes effect.
+	if tlsCfg.CAFile != "" && !tlsCfg.InsecureSkipVerify {
+		c.InsecureSkipVerify = true
+		c.VerifyConnection = files.VerifyConnection(tlsCfg.ServerName)
+		c.ClientSessionCache = tls.NewLRUClientSessionCache(0)
+	}
+
+	return c, nil
+}
+
+// tlsFiles holds the client certificate and CA bundle
+// loaded from files, reloading them when they change.
+type tlsFiles struct {
+	log      restql.Logger
+	certFile string
+	keyFile  string
+	caFile   string
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	roots    *x509.CertPool
+	modTimes map[string]time.Time
+}
+
+func newTLSFiles(log restql.Logger, certFile, keyFile, caFile string) (*tlsFiles, error) {
+	f := &tlsFiles{log: log, certFile: certFile, keyFile: keyFile, caFile: caFile, modTimes: make(map[string]time.Time)}
+	_, err := f.Reload()
+	return f, err
+}
+
+// ClientCertificate returns the current client certificate.
+func (f *tlsFiles) ClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.cert == nil {
+		return nil, errNoClientCertificate
+	}
+
+	return f.cert, nil
+}
+
+// VerifyConnection returns a function that checks the upstream
+// certificate chain against the current CA bundle. The certificate
+// must be valid for the server name, or the one in the connection
+// state when it is not defined, failing when neither is known.
+func (f *tlsFiles) VerifyConnection(serverName string) func(cs tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return errNoPeerCertificate
+		}
+
+		f.mu.RLock()
+		roots := f.roots
+		f.mu.RUnlock()
+
+		if roots == nil {
+			return errNoCABundle
+		}
+
+		name := serverName
+		if name == "" {
+			name = cs.ServerName
+		}
+		if name == "" {
+			return errUnknownServerName
+		}
+
+		opts := x509.VerifyOptions{
+			Roots:         roots,
+			DNSName:       name,
+			Intermediates: x509.NewCertPool(),
+		}
+		for _, c := range cs.PeerCertificates[1:] {
+			opts.Intermediates.AddCert(c)
+		}
+
+		_, err := cs.PeerCertificates[0].Verify(opts)
+		return err
+	}
+}
+
+// tlsConfigForHost returns a copy of the configuration for the
+// connections to the given host. The CA bundle verification takes
+// the name from the connection state, which Go leaves empty for IP
+// addresses, hence the host is set there explicitly, allowing it to
+// be checked against the IP addresses of the certificate.
+func tlsConfigForHost(c *tls.Config, host string) *tls.Config {
+	c = c.Clone()
+	if c.ServerName == "" {
+		c.ServerName = host
+	}
+
+	verify := c.VerifyConnection
+	if verify != nil {
+		c.VerifyConnection = func(cs tls.ConnectionState) error {
+			if cs.ServerName == "" {
+				cs.ServerName = host
+			}
+			return verify(cs)
+		}
+	}
+
+	return c
+}
+
+// dialTLS returns a dial function that establishes the TLS session
+// itself, with the configuration for the dialed host, instead of
+// leaving it to FastHTTP, which shares it among the hosts.
+func dialTLS(dial fasthttp.DialFunc, c *tls.Config, timeout time.Duration) fasthttp.DialFunc {
+	return func(addr string) (net.Conn, error) {
+		conn, err := dial(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			host = addr
+		}
+
+		if timeout > 0 {
+			_ = conn.SetDeadline(time.Now().Add(timeout))
+		}
+
+		tlsConn := tls.Client(conn, tlsConfigForHost(c, host))
+		err = tlsConn.Handshake()
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		_ = conn.SetDeadline(time.Time{})
+
+		return tlsConn, nil
+	}
+}
+
+// Reload reads the certificate files if any of them
+// has changed since the last successful load.
+func (f *tlsFiles) Reload() (bool, error) {
+	modTimes := make(map[string]time.Time)
+	for _, path := range []string{f.certFile, f.keyFile, f.caFile} {
+		if path == "" {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return false, err
+		}
+		modTimes[path] = info.ModTime()
+	}
+
+	if !f.changed(modTimes) {
+		return false, nil
+	}
+
+	var cert *tls.Certificate
+	if f.certFile != "" {
+		c, err := tls.LoadX509KeyPair(f.certFile, f.keyFile)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to load client certificate")
+		}
+		cert = &c
+	}
+
+	var roots *x509.CertPool
+	if f.caFile != "" {
+		data, err := ioutil.ReadFile(f.caFile)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to read ca bundle")
+		}
+
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(data) {
+			return false, errors.Wrapf(errInvalidCABundle, "file %s", f.caFile)
+		}
+	}
+
+	f.mu.Lock()
+	f.cert = cert
+	f.roots = roots
+	f.modTimes = modTimes
+	f.mu.Unlock()
+
+	return true, nil
+}
+
+func (f *tlsFiles) changed(modTimes map[string]time.Time) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	for path, modTime := range modTimes {
+		if !modTime.Equal(f.modTimes[path]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Watch periodically reloads the certificate files.
+func (f *tlsFiles) Watch(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		reloaded, err := f.Reload()
+		if err != nil {
+			f.log.Error("failed to reload tls certificates", err, "cert", f.certFile, "ca", f.caFile)
+			continue
+		}
+
+		if reloaded {
+			f.log.Info("tls certificates reloaded", "cert", f.certFile, "ca", f.caFile)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestFastHTTPClientMutualTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	test.VerifyError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCertificate(t, nil, "restql-ca")
+	serverCert := newTestCertificate(t, ca, "127.0.0.1")
+	clientCert := newTestCertificate(t, ca, "restql")
+
+	caFile := writePEM(t, dir, "ca.pem", ca)
+	certFile := writePEM(t, dir, "client.pem", clientCert)
+	keyFile := writeKey(t, dir, "client-key.pem", clientCert)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.leaf)
+
+	upstream := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "batman"}`))
+	}))
+	upstream.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert.tls},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	upstream.StartTLS()
+	defer upstream.Close()
+
+	tlsCfg := conf.ResourceClientConf{}
+	tlsCfg.TLS.CertFile = certFile
+	tlsCfg.TLS.KeyFile = keyFile
+	tlsCfg.TLS.CAFile = caFile
+	tlsCfg.TLS.MinVersion = "1.2"
+
+	missingCACfg := tlsCfg
+	missingCACfg.TLS.CAFile = filepath.Join(dir, "missing-ca.pem")
+
+	cfg := newTestConfig()
+	cfg.HTTP.Client.Resources = map[string]conf.ResourceClientOverride{"hero": {ResourceClientConf: tlsCfg}, "villain": {ResourceClientConf: missingCACfg}}
+
+	client := newFastHTTPClient(noOpLogger, plugins.NoOpLifecycle, cfg, nil)
+	request := restql.HTTPRequest{Method: "GET", Schema: "https", Host: upstream.Listener.Addr().String(), Path: "/hero", Timeout: time.Second}
+
+	t.Run("should call upstream using client certificate and custom ca", func(t *testing.T) {
+		ctx := domain.WithUpstream(context.Background(), domain.Upstream{Resource: "hero"})
+
+		response, err := client.Do(ctx, request)
+		test.VerifyError(t, err)
+		test.Equal(t, response.StatusCode, 200)
+		test.Equal(t, response.Body.Unmarshal(), map[string]interface{}{"name": "batman"})
+	})
+
+	t.Run("should fail when upstream certificate is signed by an unknown ca", func(t *testing.T) {
+		ctx := domain.WithUpstream(context.Background(), domain.Upstream{Resource: "sidekick"})
+
+		_, err := client.Do(ctx, request)
+		if err == nil {
+			t.Errorf("expected error when calling upstream without tls configuration")
+		}
+	})
+
+	t.Run("should fail when the ca bundle cannot be loaded", func(t *testing.T) {
+		ctx := domain.WithUpstream(context.Background(), domain.Upstream{Resource: "villain"})
+
+		_, err := client.Do(ctx, request)
+		test.Equal(t, errors.Is(err, errInvalidTLSConfig), true)
+	})
+
+	t.Run("should load the ca bundle once it is available", func(t *testing.T) {
+		writePEM(t, dir, "missing-ca.pem", ca)
+		ctx := domain.WithUpstream(context.Background(), domain.Upstream{Resource: "villain"})
+
+		response, err := client.Do(ctx, request)
+		test.VerifyError(t, err)
+		test.Equal(t, response.StatusCode, 200)
+	})
+}
+
+func TestFastHTTPClientTLSHostVerification(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	test.VerifyError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCertificate(t, nil, "restql-ca")
+	serverCert := newTestCertificate(t, ca, "hero.api")
+
+	upstream := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "batman"}`))
+	}))
+	upstream.TLS = &tls.Config{Certificates: []tls.Certificate{serverCert.tls}}
+	upstream.StartTLS()
+	defer upstream.Close()
+
+	tlsCfg := conf.ResourceClientConf{}
+	tlsCfg.TLS.CAFile = writePEM(t, dir, "ca.pem", ca)
+
+	namedCfg := tlsCfg
+	namedCfg.TLS.ServerName = "hero.api"
+
+	cfg := newTestConfig()
+	cfg.HTTP.Client.Resources = map[string]conf.ResourceClientOverride{"hero": {ResourceClientConf: tlsCfg}, "sidekick": {ResourceClientConf: namedCfg}}
+
+	client := newFastHTTPClient(noOpLogger, plugins.NoOpLifecycle, cfg, nil)
+	request := restql.HTTPRequest{Method: "GET", Schema: "https", Host: upstream.Listener.Addr().String(), Path: "/hero", Timeout: time.Second}
+
+	t.Run("should fail when the certificate is not valid for the upstream address", func(t *testing.T) {
+		ctx := domain.WithUpstream(context.Background(), domain.Upstream{Resource: "hero"})
+
+		_, err := client.Do(ctx, request)
+		if err == nil {
+			t.Errorf("expected error when the upstream certificate does not match its address")
+		}
+	})
+
+	t.Run("should verify the certificate against the server name", func(t *testing.T) {
+		ctx := domain.WithUpstream(context.Background(), domain.Upstream{Resource: "sidekick"})
+
+		response, err := client.Do(ctx, request)
+		test.VerifyError(t, err)
+		test.Equal(t, response.StatusCode, 200)
+	})
+}
+
+func TestNewTLSConfigInvalidFiles(t *testing.T) {
+	cfg := conf.ResourceClientConf{}
+	cfg.TLS.CertFile = "/nonexistent/client.pem"
+	cfg.TLS.KeyFile = "/nonexistent/client-key.pem"
+
+	c, err := newTLSConfig(noOpLogger, cfg, 0)
+	test.Equal(t, errors.Is(err, errInvalidTLSConfig), true)
+	test.Equal(t, c == nil, true)
+
+	cfg = conf.ResourceClientConf{}
+	cfg.TLS.MinVersion = "0.9"
+
+	_, err = newTLSConfig(noOpLogger, cfg, 0)
+	test.Equal(t, errors.Is(err, errInvalidTLSConfig), true)
+}
+
+func TestTLSFilesReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	test.VerifyError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCertificate(t, nil, "restql-ca")
+	caFile := writePEM(t, dir, "ca.pem", ca)
+
+	files, err := newTLSFiles(noOpLogger, "", "", caFile)
+	test.VerifyError(t, err)
+
+	reloaded, err := files.Reload()
+	test.VerifyError(t, err)
+	test.Equal(t, reloaded, false)
+
+	future := time.Now().Add(time.Minute)
+	err = os.Chtimes(caFile, future, future)
+	test.VerifyError(t, err)
+
+	reloaded, err = files.Reload()
+	test.VerifyError(t, err)
+	test.Equal(t, reloaded, true)
+
+	err = ioutil.WriteFile(caFile, []byte("invalid"), 0644)
+	test.VerifyError(t, err)
+
+	_, err = files.Reload()
+	if err == nil {
+		t.Errorf("expected error when reloading invalid ca bundle")
+	}
+
+	_, err = files.ClientCertificate(nil)
+	if err != errNoClientCertificate {
+		t.Errorf("expected client certificate not loaded error, got %v", err)
+	}
+}
+
+type testCertificate struct {
+	tls  tls.Certificate
+	leaf *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCertificate(t *testing.T, parent *testCertificate, commonName string) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.VerifyError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	test.VerifyError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	if ip := net.ParseIP(commonName); ip != nil {
+		template.IPAddresses = []net.IP{ip}
+	} else if parent != nil {
+		template.DNSNames = []string{commonName}
+	}
+
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.leaf, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	test.VerifyError(t, err)
+
+	leaf, err := x509.ParseCertificate(der)
+	test.VerifyError(t, err)
+
+	return &testCertificate{
+		tls:  tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf},
+		leaf: leaf,
+		key:  key,
+	}
+}
+
+func writePEM(t *testing.T, dir string, name string, cert *testCertificate) string {
+	path := filepath.Join(dir, name)
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.leaf.Raw})
+
+	err := ioutil.WriteFile(path, data, 0644)
+	test.VerifyError(t, err)
+
+	return path
+}
+
+func writeKey(t *testing.T, dir string, name string, cert *testCertificate) string {
+	der, err := x509.MarshalECPrivateKey(cert.key)
+	test.VerifyError(t, err)
+
+	path := filepath.Join(dir, name)
+	data := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+
+	err = ioutil.WriteFile(path, data, 0600)
+	test.VerifyError(t, err)
+
+	return path
+}
@@ -0,0 +1,46 @@
+package httpclient
+
+import (
+	"encoding/hex"
+	"net"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+	"github.com/valyala/fasthttp"
+)
+
+const unixSchema = "unix"
+
+// The socket path cannot be used as the request host, since it
+// contains slashes, hence it is hex encoded into a valid host name
+// and decoded back by the dialer of the clients using the unix schema.
+func encodeUnixHost(socketPath string) string {
+	return hex.EncodeToString([]byte(socketPath))
+}
+
+func newUnixDialer(timeout time.Duration) fasthttp.DialFunc {
+	return func(addr string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		socketPath, err := hex.DecodeString(host)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid unix socket host %s", host)
+		}
+
+		return net.DialTimeout("unix", string(socketPath), timeout)
+	}
+}
+
+// requestURL returns the URL called by the request, showing
+// the socket path instead of its encoded form for unix upstreams.
+func requestURL(request restql.HTTPRequest, req *fasthttp.Request) string {
+	if request.Schema != unixSchema {
+		return req.URI().String()
+	}
+
+	return unixSchema + "://" + request.Host + ":" + string(req.URI().RequestURI())
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/redact"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"github.com/rs/zerolog"
 )
@@ -15,10 +16,12 @@ type LogOptions struct {
 	TimestampFieldFormat string
 	Level                string
 	Format               string
+	Redactor             *redact.Redactor
 }
 
 type zeroLogger struct {
-	zLogger zerolog.Logger
+	zLogger  zerolog.Logger
+	redactor *redact.Redactor
 }
 
 // New constructs a zeroLogger instance.
@@ -48,56 +51,57 @@ func New(w io.Writer, options LogOptions) restql.Logger {
 		logger.Level(zerolog.Disabled)
 	}
 
-	return &zeroLogger{zLogger: logger}
+	return &zeroLogger{zLogger: logger, redactor: options.Redactor}
 }
 
 func (zl *zeroLogger) Panic(msg string, fields ...interface{}) {
 	entry := zl.zLogger.Panic()
-	fieldMap := makeFieldMap(fields)
+	fieldMap := zl.makeFieldMap(fields)
 
 	entry.Fields(fieldMap).Msg(msg)
 }
 
 func (zl *zeroLogger) Fatal(msg string, fields ...interface{}) {
-	fieldMap := makeFieldMap(fields)
+	fieldMap := zl.makeFieldMap(fields)
 
 	zl.zLogger.Fatal().Fields(fieldMap).Msg(msg)
 }
 
 func (zl *zeroLogger) Error(msg string, err error, fields ...interface{}) {
-	fieldMap := makeFieldMap(fields)
+	fieldMap := zl.makeFieldMap(fields)
 
-	zl.zLogger.Error().Err(err).Fields(fieldMap).Msg(msg)
+	zl.zLogger.Error().Err(zl.redactor.Error(err)).Fields(fieldMap).Msg(msg)
 }
 
 func (zl *zeroLogger) Warn(msg string, fields ...interface{}) {
-	fieldMap := makeFieldMap(fields)
+	fieldMap := zl.makeFieldMap(fields)
 
 	zl.zLogger.Warn().Fields(fieldMap).Msg(msg)
 }
 
 func (zl *zeroLogger) Info(msg string, fields ...interface{}) {
-	fieldMap := makeFieldMap(fields)
+	fieldMap := zl.makeFieldMap(fields)
 
 	zl.zLogger.Info().Fields(fieldMap).Msg(msg)
 }
 
 func (zl *zeroLogger) Debug(msg string, fields ...interface{}) {
-	fieldMap := makeFieldMap(fields)
+	fieldMap := zl.makeFieldMap(fields)
 
 	zl.zLogger.Debug().Fields(fieldMap).Msg(msg)
 }
 
 func (zl *zeroLogger) With(key string, value interface{}) restql.Logger {
-	cl := zl.zLogger.With().Str(key, fmt.Sprintf("%v", value)).Logger()
-	return &zeroLogger{zLogger: cl}
+	cl := zl.zLogger.With().Str(key, zl.redactor.String(fmt.Sprintf("%v", value))).Logger()
+	return &zeroLogger{zLogger: cl, redactor: zl.redactor}
 }
 
-func makeFieldMap(fields []interface{}) map[string]interface{} {
+// makeFieldMap pairs the fields into a map, redacting the sensitive data of the values.
+func (zl *zeroLogger) makeFieldMap(fields []interface{}) map[string]interface{} {
 	fieldMap := make(map[string]interface{})
 	for i := 0; i <= len(fields)-2; i += 2 {
 		key := fmt.Sprintf("%v", fields[i])
-		value := fields[i+1]
+		value := zl.redactor.Value(fields[i+1])
 
 		fieldMap[key] = value
 	}
@@ -0,0 +1,154 @@
+// Package metrics holds the Prometheus collectors exposed by restQL
+// and the functions used by the other packages to update them.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "restql"
+
+// AdHocQuery is the label value used for the namespace
+// and name of queries that are not saved.
+const AdHocQuery = "ad-hoc"
+
+var registry = prometheus.NewRegistry()
+
+var (
+	queryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "query_duration_seconds",
+		Help:      "Time taken to evaluate queries, by namespace, query name and result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"namespace", "query", "result"})
+
+	statementDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "statement_duration_seconds",
+		Help:      "Time taken to execute statements, by tenant, resource and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"tenant", "resource", "status"})
+
+	upstreamInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "upstream_requests_in_flight",
+		Help:      "Number of requests to upstream APIs currently being executed.",
+	})
+
+	limiterInUse = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "limiter_in_use",
+		Help:      "Number of tokens currently acquired from the limiter.",
+	}, []string{"limiter"})
+
+	limiterCapacity = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "limiter_capacity",
+		Help:      "Maximum number of tokens available in the limiter.",
+	}, []string{"limiter"})
+
+	limiterRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "limiter_rejected_total",
+		Help:      "Number of executions rejected by the limiter.",
+	}, []string{"limiter"})
+
+	rateLimitRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limit_rejected_total",
+		Help:      "Number of requests rejected by the rate limiter, by rule.",
+	}, []string{"rule"})
+
+	cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Number of cache lookups, by cache and result.",
+	}, []string{"cache", "result"})
+)
+
+func init() {
+	registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		queryDuration,
+		statementDuration,
+		upstreamInFlight,
+		limiterInUse,
+		limiterCapacity,
+		limiterRejected,
+		cacheRequests,
+		rateLimitRejected,
+	)
+}
+
+// Handler returns the HTTP handler that exposes
+// the metrics in the Prometheus format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ObserveQuery records the evaluation of a query.
+func ObserveQuery(namespace string, query string, err error, duration time.Duration) {
+	if namespace == "" && query == "" {
+		namespace, query = AdHocQuery, AdHocQuery
+	}
+
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+
+	queryDuration.WithLabelValues(namespace, query, result).Observe(duration.Seconds())
+}
+
+// ObserveStatement records the execution of a statement.
+func ObserveStatement(tenant string, resource string, status int, duration time.Duration) {
+	statementDuration.WithLabelValues(tenant, resource, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+// UpstreamRequestStarted records a request to an upstream API that has
+// started, returning the function to be called once it is done.
+func UpstreamRequestStarted() (done func()) {
+	upstreamInFlight.Inc()
+	return upstreamInFlight.Dec
+}
+
+// SetLimiterCapacity records the maximum number of tokens of a limiter.
+func SetLimiterCapacity(limiter string, capacity int) {
+	limiterCapacity.WithLabelValues(limiter).Set(float64(capacity))
+}
+
+// LimiterAcquired records a token acquired from a limiter.
+func LimiterAcquired(limiter string) {
+	limiterInUse.WithLabelValues(limiter).Inc()
+}
+
+// LimiterReleased records a token returned to a limiter.
+func LimiterReleased(limiter string) {
+	limiterInUse.WithLabelValues(limiter).Dec()
+}
+
+// LimiterRejected records an execution rejected by a limiter.
+func LimiterRejected(limiter string) {
+	limiterRejected.WithLabelValues(limiter).Inc()
+}
+
+// CacheHit records a lookup that found the value in cache.
+func CacheHit(cache string) {
+	cacheRequests.WithLabelValues(cache, "hit").Inc()
+}
+
+// CacheMiss records a lookup that did not find the value in cache.
+func CacheMiss(cache string) {
+	cacheRequests.WithLabelValues(cache, "miss").Inc()
+}
+
+// RateLimitRejected records a request rejected by a rate limit rule.
+func RateLimitRejected(rule string) {
+	rateLimitRejected.WithLabelValues(rule).Inc()
+}
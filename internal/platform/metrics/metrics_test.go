@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestObserveQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		query     string
+		err       error
+		expected  map[string]string
+	}{
+		{
+			name:      "saved query with success",
+			namespace: "ns",
+			query:     "q",
+			expected:  map[string]string{"namespace": "ns", "query": "q", "result": "success"},
+		},
+		{
+			name:      "saved query with error",
+			namespace: "ns",
+			query:     "q",
+			err:       errors.New("some error"),
+			expected:  map[string]string{"namespace": "ns", "query": "q", "result": "error"},
+		},
+		{
+			name:     "ad-hoc query",
+			expected: map[string]string{"namespace": AdHocQuery, "query": AdHocQuery, "result": "success"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queryDuration.Reset()
+
+			ObserveQuery(tt.namespace, tt.query, tt.err, time.Millisecond)
+
+			families, err := registry.Gather()
+			test.VerifyError(t, err)
+
+			labels := make(map[string]string)
+			for _, family := range families {
+				if family.GetName() != "restql_query_duration_seconds" {
+					continue
+				}
+
+				test.Equal(t, len(family.GetMetric()), 1)
+				for _, label := range family.GetMetric()[0].GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+			}
+
+			test.Equal(t, labels, tt.expected)
+		})
+	}
+}
+
+func TestLimiterMetrics(t *testing.T) {
+	limiterInUse.Reset()
+	limiterRejected.Reset()
+
+	SetLimiterCapacity("test", 10)
+	LimiterAcquired("test")
+	LimiterAcquired("test")
+	LimiterReleased("test")
+	LimiterRejected("test")
+
+	test.Equal(t, testutil.ToFloat64(limiterCapacity.WithLabelValues("test")), float64(10))
+	test.Equal(t, testutil.ToFloat64(limiterInUse.WithLabelValues("test")), float64(1))
+	test.Equal(t, testutil.ToFloat64(limiterRejected.WithLabelValues("test")), float64(1))
+}
+
+func TestCacheMetrics(t *testing.T) {
+	cacheRequests.Reset()
+
+	CacheHit("test")
+	CacheHit("test")
+	CacheMiss("test")
+
+	test.Equal(t, testutil.ToFloat64(cacheRequests.WithLabelValues("test", "hit")), float64(2))
+	test.Equal(t, testutil.ToFloat64(cacheRequests.WithLabelValues("test", "miss")), float64(1))
+}
+
+func TestUpstreamRequestStarted(t *testing.T) {
+	first := UpstreamRequestStarted()
+	second := UpstreamRequestStarted()
+	test.Equal(t, testutil.ToFloat64(upstreamInFlight), float64(2))
+
+	first()
+	second()
+	test.Equal(t, testutil.ToFloat64(upstreamInFlight), float64(0))
+}
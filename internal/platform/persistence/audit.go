@@ -0,0 +1,127 @@
+package persistence
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+const defaultAuditLimit = 100
+
+// AuditLog stores the changes made through the administrative API,
+// appending them to a local file or sending them to the database,
+// as long as the database plugin supports it. All methods are
+// safe to call on a nil AuditLog, which is used when there
+// is no storage available.
+type AuditLog struct {
+	file string
+	db   restql.AuditLogDatabase
+
+	mu sync.Mutex
+}
+
+// NewAuditLog constructs an AuditLog that writes to the given file,
+// when it is set, or to the database, returning nil if neither is usable.
+func NewAuditLog(log restql.Logger, file string, db Database) *AuditLog {
+	if file != "" {
+		log.Info("admin audit log stored on file", "file", file)
+		return &AuditLog{file: file}
+	}
+
+	if auditDB, ok := db.(restql.AuditLogDatabase); ok {
+		log.Info("admin audit log stored on database")
+		return &AuditLog{db: auditDB}
+	}
+
+	return nil
+}
+
+// Record appends an entry to the audit log.
+func (a *AuditLog) Record(ctx context.Context, entry restql.AuditEntry) error {
+	if a == nil {
+		return nil
+	}
+
+	if a.db != nil {
+		return a.db.CreateAuditEntry(ctx, entry)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	f, err := os.OpenFile(a.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "failed to open audit log file")
+	}
+
+	_, err = f.Write(append(data, '\n'))
+	if err != nil {
+		f.Close()
+		return errors.Wrap(err, "failed to write audit log file")
+	}
+
+	return f.Close()
+}
+
+// Find returns the entries matching the filter, newest first.
+func (a *AuditLog) Find(ctx context.Context, filter restql.AuditFilter) ([]restql.AuditEntry, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditLimit
+	}
+
+	if a.db != nil {
+		return a.db.FindAuditEntries(ctx, filter)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	f, err := os.Open(a.file)
+	if os.IsNotExist(err) {
+		return []restql.AuditEntry{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open audit log file")
+	}
+	defer f.Close()
+
+	var matched []restql.AuditEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry restql.AuditEntry
+		err := json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			restql.GetLogger(ctx).Warn("ignoring invalid audit log line", "error", err.Error())
+			continue
+		}
+
+		if filter.Match(entry) {
+			matched = append(matched, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read audit log file")
+	}
+
+	entries := make([]restql.AuditEntry, 0, filter.Limit)
+	for i := len(matched) - 1; i >= 0 && len(entries) < filter.Limit; i-- {
+		entries = append(entries, matched[i])
+	}
+
+	return entries, nil
+}
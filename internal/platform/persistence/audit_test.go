@@ -0,0 +1,71 @@
+package persistence
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestAuditLogFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "restql-audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	audit := NewAuditLog(test.NoOpLogger, filepath.Join(dir, "audit.log"), noOpDatabase{})
+	ctx := context.Background()
+
+	entries, err := audit.Find(ctx, restql.AuditFilter{})
+	test.VerifyError(t, err)
+	test.Equal(t, entries, []restql.AuditEntry{})
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	records := []restql.AuditEntry{
+		{Time: start, Actor: "mapper", Action: restql.AuditActionMapResource, Tenant: "DC", Target: "hero", After: "http://hero.api"},
+		{Time: start.Add(time.Minute), Actor: "author", Action: restql.AuditActionCreateQueryRevision, Namespace: "dc", Target: "heroes"},
+		{Time: start.Add(2 * time.Minute), Actor: "author", Action: restql.AuditActionUpdateQueryArchiving, Namespace: "dc", Target: "heroes"},
+	}
+	for _, r := range records {
+		test.VerifyError(t, audit.Record(ctx, r))
+	}
+
+	tests := []struct {
+		name     string
+		filter   restql.AuditFilter
+		expected []string
+	}{
+		{"returns newest first", restql.AuditFilter{}, []string{restql.AuditActionUpdateQueryArchiving, restql.AuditActionCreateQueryRevision, restql.AuditActionMapResource}},
+		{"filters by actor", restql.AuditFilter{Actor: "mapper"}, []string{restql.AuditActionMapResource}},
+		{"filters by namespace", restql.AuditFilter{Namespace: "dc"}, []string{restql.AuditActionUpdateQueryArchiving, restql.AuditActionCreateQueryRevision}},
+		{"filters by time", restql.AuditFilter{Since: start.Add(90 * time.Second)}, []string{restql.AuditActionUpdateQueryArchiving}},
+		{"limits entries", restql.AuditFilter{Limit: 1}, []string{restql.AuditActionUpdateQueryArchiving}},
+		{"limits entries in scope", restql.AuditFilter{NamespaceScope: []string{"marvel"}, Limit: 1}, []string{restql.AuditActionMapResource}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := audit.Find(ctx, tt.filter)
+			test.VerifyError(t, err)
+
+			var actions []string
+			for _, e := range entries {
+				actions = append(actions, e.Action)
+			}
+			test.Equal(t, actions, tt.expected)
+		})
+	}
+}
+
+func TestAuditLogWithoutStorage(t *testing.T) {
+	audit := NewAuditLog(test.NoOpLogger, "", noOpDatabase{})
+
+	test.Equal(t, audit == nil, true)
+	test.VerifyError(t, audit.Record(context.Background(), restql.AuditEntry{}))
+}
@@ -41,6 +41,20 @@ func NewDatabase(log restql.Logger, disabled bool) (Database, error) {
 	return database, nil
 }
 
+// ErrPingNotSupported is returned when the database
+// plugin does not implement restql.PingableDatabase.
+var ErrPingNotSupported = errors.New("database does not support ping")
+
+// PingDatabase verifies the connection with the database.
+func PingDatabase(ctx context.Context, db Database) error {
+	pingable, ok := db.(restql.PingableDatabase)
+	if !ok {
+		return ErrPingNotSupported
+	}
+
+	return pingable.Ping(ctx)
+}
+
 var errNoDatabase = errors.New("no op database")
 
 type noOpDatabase struct{}
@@ -0,0 +1,82 @@
+package persistence
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+// ErrPersistedQueryNotFound is returned when there is no
+// query stored for the given hash.
+var ErrPersistedQueryNotFound = errors.New("persisted query not found")
+
+// PersistedQueryHash returns the hash that identifies a persisted query,
+// which is the SHA-256 of its text in lowercase hexadecimal form.
+func PersistedQueryHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// PersistedQueryStore get and register persisted queries from
+// the local configuration file or a database instance, as
+// long as the database plugin supports them.
+type PersistedQueryStore struct {
+	log   restql.Logger
+	local map[string]string
+	db    Database
+}
+
+// NewPersistedQueryStore constructs a PersistedQueryStore from
+// the query texts in the configuration and the database.
+func NewPersistedQueryStore(log restql.Logger, local []string, db Database) PersistedQueryStore {
+	l := make(map[string]string, len(local))
+	for _, text := range local {
+		l[PersistedQueryHash(text)] = text
+	}
+
+	return PersistedQueryStore{log: log, local: l, db: db}
+}
+
+// Get retrieves a query text by its hash, it first search
+// the configuration file and, if not found, the database.
+func (s PersistedQueryStore) Get(ctx context.Context, hash string) (string, error) {
+	hash = strings.ToLower(hash)
+
+	if text, found := s.local[hash]; found {
+		return text, nil
+	}
+
+	db, ok := s.db.(restql.PersistedQueryDatabase)
+	if !ok {
+		return "", ErrPersistedQueryNotFound
+	}
+
+	text, err := db.FindPersistedQuery(ctx, hash)
+	switch {
+	case err == restql.ErrQueryNotFoundInDatabase:
+		return "", ErrPersistedQueryNotFound
+	case err != nil:
+		log := restql.GetLogger(ctx)
+		log.Error("database error when fetching persisted query", err, "hash", hash)
+		return "", err
+	case text == "":
+		return "", ErrPersistedQueryNotFound
+	}
+
+	return text, nil
+}
+
+// Register stores the query text on the database, when
+// it supports persisted queries, otherwise it does nothing.
+func (s PersistedQueryStore) Register(ctx context.Context, hash string, text string) error {
+	db, ok := s.db.(restql.PersistedQueryDatabase)
+	if !ok {
+		return nil
+	}
+
+	return db.CreatePersistedQuery(ctx, strings.ToLower(hash), text)
+}
@@ -0,0 +1,79 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestPersistedQueryHash(t *testing.T) {
+	test.Equal(t, PersistedQueryHash("from hero"), "e5e49ba8f857fe0f02653c11b5ed8b623e5cbccd9cd59f96cc069c3201ad0b26")
+}
+
+func TestPersistedQueryStore(t *testing.T) {
+	local := []string{"from hero"}
+	db := &stubPersistedQueryDatabase{queries: map[string]string{PersistedQueryHash("from sidekick"): "from sidekick"}}
+	store := NewPersistedQueryStore(noOpLogger, local, db)
+
+	t.Run("should get query from local configuration", func(t *testing.T) {
+		got, err := store.Get(context.Background(), PersistedQueryHash("from hero"))
+		test.VerifyError(t, err)
+		test.Equal(t, got, "from hero")
+	})
+
+	t.Run("should get query from database", func(t *testing.T) {
+		got, err := store.Get(context.Background(), PersistedQueryHash("from sidekick"))
+		test.VerifyError(t, err)
+		test.Equal(t, got, "from sidekick")
+	})
+
+	t.Run("should fail when query is unknown", func(t *testing.T) {
+		_, err := store.Get(context.Background(), PersistedQueryHash("from villain"))
+		if err != ErrPersistedQueryNotFound {
+			t.Fatalf("expected persisted query not found error, got %v", err)
+		}
+	})
+
+	t.Run("should register query on database", func(t *testing.T) {
+		hash := PersistedQueryHash("from villain")
+		err := store.Register(context.Background(), hash, "from villain")
+		test.VerifyError(t, err)
+
+		got, err := store.Get(context.Background(), hash)
+		test.VerifyError(t, err)
+		test.Equal(t, got, "from villain")
+	})
+
+	t.Run("should ignore registration when database does not support it", func(t *testing.T) {
+		store := NewPersistedQueryStore(noOpLogger, local, noOpDatabase{})
+
+		err := store.Register(context.Background(), PersistedQueryHash("from villain"), "from villain")
+		test.VerifyError(t, err)
+
+		_, err = store.Get(context.Background(), PersistedQueryHash("from villain"))
+		if err != ErrPersistedQueryNotFound {
+			t.Fatalf("expected persisted query not found error, got %v", err)
+		}
+	})
+}
+
+type stubPersistedQueryDatabase struct {
+	stubDatabase
+	queries map[string]string
+}
+
+func (s *stubPersistedQueryDatabase) FindPersistedQuery(ctx context.Context, hash string) (string, error) {
+	text, found := s.queries[hash]
+	if !found {
+		return "", restql.ErrQueryNotFoundInDatabase
+	}
+
+	return text, nil
+}
+
+func (s *stubPersistedQueryDatabase) CreatePersistedQuery(ctx context.Context, hash string, text string) error {
+	s.queries[hash] = text
+	return nil
+}
@@ -3,6 +3,7 @@ package plugins
 import (
 	"context"
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/redact"
 	"net/http"
 	"net/url"
 	"runtime/debug"
@@ -24,61 +25,67 @@ type Lifecycle interface {
 
 type pluginExecutor func(ctx context.Context, p restql.LifecyclePlugin) context.Context
 
+// manager runs the hooks of every lifecycle plugin, which
+// receive the values with the sensitive data redacted.
 type manager struct {
 	log              restql.Logger
 	availablePlugins []restql.LifecyclePlugin
+	redactor         *redact.Redactor
 }
 
 // NewLifecycle constructs a Lifecycle instance.
-func NewLifecycle(log restql.Logger) (Lifecycle, error) {
+func NewLifecycle(log restql.Logger, redactor *redact.Redactor) (Lifecycle, error) {
 	ps := loadLifecyclePlugins(log)
 	if len(ps) == 0 {
 		log.Info("no lifecycle hook provided")
 		return NoOpLifecycle, nil
 	}
 
-	return manager{log: log, availablePlugins: ps}, nil
+	return manager{log: log, availablePlugins: ps, redactor: redactor}, nil
 }
 
 func (m manager) BeforeTransaction(ctx context.Context, requestCtx *fasthttp.RequestCtx) context.Context {
+	tr := m.newTransactionRequest(restql.GetLogger(ctx), requestCtx)
 	return m.executeAllPluginsWithContext(ctx, "BeforeTransaction", func(currentCtx context.Context, p restql.LifecyclePlugin) context.Context {
-		log := restql.GetLogger(ctx)
-		tr := m.newTransactionRequest(log, requestCtx)
 		return p.BeforeTransaction(currentCtx, tr)
 	})
 }
 
 func (m manager) AfterTransaction(ctx context.Context, requestCtx *fasthttp.RequestCtx) context.Context {
+	tr := m.newTransactionResponse(requestCtx)
 	return m.executeAllPluginsWithContext(ctx, "AfterTransaction", func(currentCtx context.Context, p restql.LifecyclePlugin) context.Context {
-		tr := m.newTransactionResponse(requestCtx)
 		return p.AfterTransaction(currentCtx, tr)
 	})
 }
 
 func (m manager) BeforeQuery(ctx context.Context, query string, queryCtx restql.QueryContext) context.Context {
+	queryCtx.Input = m.redactor.QueryInput(queryCtx.Input)
 	return m.executeAllPluginsWithContext(ctx, "BeforeQuery", func(currentCtx context.Context, p restql.LifecyclePlugin) context.Context {
 		return p.BeforeQuery(currentCtx, query, queryCtx)
 	})
 }
 
 func (m manager) AfterQuery(ctx context.Context, query string, result domain.Resources) context.Context {
-	return m.executeAllPluginsWithContext(ctx, "AfterQuery", func(currentCtx context.Context, p restql.LifecyclePlugin) context.Context {
-		r := make(map[string]interface{})
-		for id, resource := range result {
-			r[string(id)] = resource
-		}
+	r := make(map[string]interface{})
+	for id, resource := range result {
+		r[string(id)] = m.redactor.Value(resource)
+	}
 
+	return m.executeAllPluginsWithContext(ctx, "AfterQuery", func(currentCtx context.Context, p restql.LifecyclePlugin) context.Context {
 		return p.AfterQuery(currentCtx, query, r)
 	})
 }
 
 func (m manager) BeforeRequest(ctx context.Context, request restql.HTTPRequest) context.Context {
+	request = m.redactor.HTTPRequest(request)
 	return m.executeAllPluginsWithContext(ctx, "BeforeRequest", func(currentCtx context.Context, p restql.LifecyclePlugin) context.Context {
 		return p.BeforeRequest(currentCtx, request)
 	})
 }
 
 func (m manager) AfterRequest(ctx context.Context, request restql.HTTPRequest, response restql.HTTPResponse, err error) context.Context {
+	request = m.redactor.HTTPRequest(request)
+	response = m.redactor.HTTPResponse(response)
 	return m.executeAllPluginsWithContext(ctx, "AfterRequest", func(currentCtx context.Context, p restql.LifecyclePlugin) context.Context {
 		return p.AfterRequest(currentCtx, request, response, err)
 	})
@@ -122,10 +129,17 @@ func (m manager) newTransactionRequest(log restql.Logger, ctx *fasthttp.RequestC
 
 	//todo: add header to ctx
 
+	if uri != nil {
+		redacted, err := url.ParseRequestURI(m.redactor.URL(uri.String()))
+		if err == nil {
+			uri = redacted
+		}
+	}
+
 	return restql.TransactionRequest{
 		Url:    uri,
 		Method: string(ctx.Method()),
-		Header: header,
+		Header: m.redactor.HTTPHeader(header),
 	}
 }
 
@@ -137,7 +151,7 @@ func (m manager) newTransactionResponse(ctx *fasthttp.RequestCtx) restql.Transac
 
 	return restql.TransactionResponse{
 		Status: ctx.Response.StatusCode(),
-		Header: header,
+		Header: m.redactor.HTTPHeader(header),
 		Body:   ctx.Response.Body(),
 	}
 }
@@ -0,0 +1,408 @@
+// Package redact removes sensitive data, like credentials and personal
+// information, from the values exposed on debug responses, logs and
+// lifecycle plugins.
+package redact
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+// Mask replaces the redacted values.
+const Mask = "[REDACTED]"
+
+var (
+	errInvalidNamePattern = errors.New("invalid redaction name pattern")
+	errInvalidJSONPath    = errors.New("invalid redaction json path")
+	errInvalidRegex       = errors.New("invalid redaction regex")
+)
+
+// Redactor replaces with Mask the values of headers and parameters
+// whose names match the configured patterns, where * matches any
+// sequence of characters ignoring case, the body fields at the
+// configured JSON paths and the text matching the configured
+// regular expressions. Values are copied before being redacted.
+// All methods are safe to call on a nil Redactor, which
+// returns the values unchanged.
+type Redactor struct {
+	headers  []string
+	params   []string
+	paths    [][]string
+	patterns []*regexp.Regexp
+}
+
+// New constructs a Redactor from the configuration,
+// returning nil if there is no redaction rule. The
+// headers injected on upstream requests are always
+// redacted, since they usually carry credentials.
+func New(cfg *conf.Config) (*Redactor, error) {
+	redactionCfg := cfg.Redaction
+
+	headers, err := parseNamePatterns(redactionCfg.Headers)
+	if err != nil {
+		return nil, err
+	}
+	headers = append(headers, injectedHeaders(cfg)...)
+
+	params, err := parseNamePatterns(redactionCfg.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths [][]string
+	for _, p := range redactionCfg.JSONPaths {
+		segments, err := parseJSONPath(p)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, segments)
+	}
+
+	var patterns []*regexp.Regexp
+	for _, p := range redactionCfg.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, errors.Wrapf(errInvalidRegex, "%q: %s", p, err)
+		}
+		patterns = append(patterns, re)
+	}
+
+	if len(headers) == 0 && len(params) == 0 && len(paths) == 0 && len(patterns) == 0 {
+		return nil, nil
+	}
+
+	return &Redactor{headers: headers, params: params, paths: paths, patterns: patterns}, nil
+}
+
+// injectedHeaders returns the names of the headers injected by
+// every header policy, escaped to be matched literally.
+func injectedHeaders(cfg *conf.Config) []string {
+	headersCfg := cfg.HTTP.Headers
+
+	levels := []conf.HeaderPolicyConf{headersCfg.HeaderPolicyConf}
+	for _, resourceCfg := range headersCfg.Resources {
+		levels = append(levels, resourceCfg)
+	}
+	for _, resources := range headersCfg.Tenants {
+		for _, tenantResourceCfg := range resources {
+			levels = append(levels, tenantResourceCfg)
+		}
+	}
+
+	var names []string
+	for _, level := range levels {
+		for _, h := range level.Inject {
+			names = append(names, patternEscaper.Replace(strings.ToLower(h.Name)))
+		}
+	}
+
+	return names
+}
+
+var patternEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`)
+
+func parseNamePatterns(names []string) ([]string, error) {
+	patterns := make([]string, 0, len(names))
+	for _, n := range names {
+		p := strings.ToLower(strings.TrimSpace(n))
+		if _, err := path.Match(p, ""); err != nil || p == "" {
+			return nil, errors.Wrapf(errInvalidNamePattern, "%q", n)
+		}
+		patterns = append(patterns, p)
+	}
+
+	return patterns, nil
+}
+
+// parseJSONPath splits paths like $.user.cards[*].number
+// into the keys, indexes or wildcards of each level.
+func parseJSONPath(p string) ([]string, error) {
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(p, "$"), ".")
+	trimmed = strings.NewReplacer("[", ".", "]", "").Replace(trimmed)
+	if trimmed == "" {
+		return nil, errors.Wrapf(errInvalidJSONPath, "%q", p)
+	}
+
+	segments := strings.Split(trimmed, ".")
+	for _, s := range segments {
+		if s == "" {
+			return nil, errors.Wrapf(errInvalidJSONPath, "%q", p)
+		}
+	}
+
+	return segments, nil
+}
+
+// Headers redacts the values of the headers with sensitive names.
+func (r *Redactor) Headers(headers map[string]string) map[string]string {
+	if r == nil || headers == nil {
+		return headers
+	}
+
+	result := make(map[string]string, len(headers))
+	for k, v := range headers {
+		if matchName(r.headers, k) {
+			result[k] = Mask
+			continue
+		}
+		result[k] = r.String(v)
+	}
+
+	return result
+}
+
+// HTTPHeader redacts the values of the headers with sensitive names.
+func (r *Redactor) HTTPHeader(header http.Header) http.Header {
+	if r == nil || header == nil {
+		return header
+	}
+
+	result := make(http.Header, len(header))
+	for k, values := range header {
+		redacted := make([]string, len(values))
+		for i, v := range values {
+			if matchName(r.headers, k) {
+				redacted[i] = Mask
+			} else {
+				redacted[i] = r.String(v)
+			}
+		}
+		result[k] = redacted
+	}
+
+	return result
+}
+
+// Params redacts the values of the parameters with sensitive names.
+func (r *Redactor) Params(params map[string]interface{}) map[string]interface{} {
+	if r == nil || params == nil {
+		return params
+	}
+
+	result := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		if matchName(r.params, k) {
+			result[k] = Mask
+			continue
+		}
+		result[k] = r.body(v, nil)
+	}
+
+	return result
+}
+
+// Body redacts the fields at the configured JSON paths
+// and the text matching the configured expressions.
+func (r *Redactor) Body(body interface{}) interface{} {
+	if r == nil {
+		return body
+	}
+
+	return r.body(body, r.paths)
+}
+
+func (r *Redactor) body(value interface{}, paths [][]string) interface{} {
+	for _, p := range paths {
+		if len(p) == 0 {
+			return Mask
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			result[k] = r.body(item, descend(paths, k))
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = r.body(item, descend(paths, strconv.Itoa(i)))
+		}
+		return result
+	case string:
+		return r.String(v)
+	default:
+		return v
+	}
+}
+
+func descend(paths [][]string, key string) [][]string {
+	var next [][]string
+	for _, p := range paths {
+		if p[0] == "*" || p[0] == key {
+			next = append(next, p[1:])
+		}
+	}
+
+	return next
+}
+
+// String redacts the text matching the configured expressions.
+func (r *Redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+
+	for _, re := range r.patterns {
+		s = re.ReplaceAllString(s, Mask)
+	}
+
+	return s
+}
+
+// URL redacts the query parameters with sensitive names
+// and the text matching the configured expressions.
+func (r *Redactor) URL(rawURL string) string {
+	if r == nil {
+		return rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.RawQuery == "" {
+		return r.String(rawURL)
+	}
+
+	query := u.Query()
+	changed := false
+	for k, values := range query {
+		if matchName(r.params, k) {
+			for i := range values {
+				values[i] = Mask
+			}
+			changed = true
+		}
+	}
+	if !changed {
+		return r.String(rawURL)
+	}
+	u.RawQuery = query.Encode()
+
+	return r.String(u.String())
+}
+
+// Error redacts the error message, keeping the
+// error unchanged when nothing is redacted.
+func (r *Redactor) Error(err error) error {
+	if r == nil || err == nil {
+		return err
+	}
+
+	msg := err.Error()
+	if redacted := r.String(msg); redacted != msg {
+		return errors.New(redacted)
+	}
+
+	return err
+}
+
+// HTTPRequest redacts the headers, parameters and body of an upstream request.
+func (r *Redactor) HTTPRequest(request restql.HTTPRequest) restql.HTTPRequest {
+	if r == nil {
+		return request
+	}
+
+	request.Headers = r.Headers(request.Headers)
+	request.Query = r.Params(request.Query)
+	request.Body = r.Body(request.Body)
+
+	return request
+}
+
+// HTTPResponse redacts the URL and headers of an upstream response.
+func (r *Redactor) HTTPResponse(response restql.HTTPResponse) restql.HTTPResponse {
+	if r == nil {
+		return response
+	}
+
+	response.URL = r.URL(response.URL)
+	response.Headers = r.Headers(response.Headers)
+
+	return response
+}
+
+// QueryInput redacts the parameters, headers and body sent by the client.
+func (r *Redactor) QueryInput(input restql.QueryInput) restql.QueryInput {
+	if r == nil {
+		return input
+	}
+
+	input.Params = r.Params(input.Params)
+	input.Headers = r.Headers(input.Headers)
+	input.Body = r.Body(input.Body)
+
+	return input
+}
+
+// DoneResource redacts the request and response data of a statement result.
+func (r *Redactor) DoneResource(resource restql.DoneResource) restql.DoneResource {
+	if r == nil {
+		return resource
+	}
+
+	resource.URL = r.URL(resource.URL)
+	resource.RequestParams = r.Params(resource.RequestParams)
+	resource.RequestHeaders = r.Headers(resource.RequestHeaders)
+	resource.RequestBody = r.Body(resource.RequestBody)
+	resource.ResponseHeaders = r.Headers(resource.ResponseHeaders)
+	resource.ExposedHeaders = r.Headers(resource.ExposedHeaders)
+
+	return resource
+}
+
+// Value redacts any of the values known to carry sensitive data,
+// returning the other ones unchanged.
+func (r *Redactor) Value(value interface{}) interface{} {
+	if r == nil {
+		return value
+	}
+
+	switch v := value.(type) {
+	case restql.HTTPRequest:
+		return r.HTTPRequest(v)
+	case restql.HTTPResponse:
+		return r.HTTPResponse(v)
+	case restql.QueryInput:
+		return r.QueryInput(v)
+	case restql.DoneResource:
+		return r.DoneResource(v)
+	case restql.DoneResources:
+		result := make(restql.DoneResources, len(v))
+		for i, item := range v {
+			result[i] = r.Value(item)
+		}
+		return result
+	case map[string]string:
+		return r.Headers(v)
+	case http.Header:
+		return r.HTTPHeader(v)
+	case map[string]interface{}, []interface{}:
+		return r.Body(v)
+	case string:
+		return r.URL(v)
+	case error:
+		return r.Error(v)
+	default:
+		return value
+	}
+}
+
+func matchName(patterns []string, name string) bool {
+	name = strings.ToLower(name)
+	for _, p := range patterns {
+		if matched, _ := path.Match(p, name); matched {
+			return true
+		}
+	}
+
+	return false
+}
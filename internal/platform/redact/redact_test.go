@@ -0,0 +1,188 @@
+package redact
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"gopkg.in/yaml.v2"
+)
+
+func newTestRedactor(t *testing.T) *Redactor {
+	cfg := &conf.Config{}
+	cfg.Redaction.Headers = []string{"Authorization", "X-*-Token"}
+	cfg.Redaction.Params = []string{"cpf", "card*"}
+	cfg.Redaction.JSONPaths = []string{"$.user.password", "$.cards[*].number", "items[0].secret"}
+	cfg.Redaction.Patterns = []string{`\d{3}\.\d{3}\.\d{3}-\d{2}`}
+
+	r, err := New(cfg)
+	test.VerifyError(t, err)
+
+	return r
+}
+
+func TestRedactorHeaders(t *testing.T) {
+	r := newTestRedactor(t)
+
+	headers := map[string]string{
+		"authorization":  "Bearer abcdefgh",
+		"X-Client-Token": "token",
+		"X-Tid":          "1234567890",
+		"X-Document":     "123.456.789-00",
+	}
+	expected := map[string]string{
+		"authorization":  Mask,
+		"X-Client-Token": Mask,
+		"X-Tid":          "1234567890",
+		"X-Document":     Mask,
+	}
+
+	test.Equal(t, r.Headers(headers), expected)
+	test.Equal(t, headers["authorization"], "Bearer abcdefgh")
+
+	header := http.Header{"Authorization": {"Bearer abcdefgh"}, "Accept": {"*/*"}}
+	test.Equal(t, r.HTTPHeader(header), http.Header{"Authorization": {Mask}, "Accept": {"*/*"}})
+}
+
+func TestRedactorInjectedHeaders(t *testing.T) {
+	cfg := &conf.Config{}
+	err := yaml.Unmarshal([]byte(`
+http:
+  headers:
+    resources:
+      hero:
+        inject:
+          - name: X-Service-Key
+            env: HERO_SERVICE_KEY
+    tenants:
+      DC:
+        sidekick:
+          inject:
+            - name: X-Partner-*
+              env: SIDEKICK_PARTNER_KEY
+`), cfg)
+	test.VerifyError(t, err)
+
+	r, err := New(cfg)
+	test.VerifyError(t, err)
+
+	headers := map[string]string{"x-service-key": "abcdef", "X-Partner-*": "abcdef", "X-Partner-Id": "1"}
+	expected := map[string]string{"x-service-key": Mask, "X-Partner-*": Mask, "X-Partner-Id": "1"}
+
+	test.Equal(t, r.Headers(headers), expected)
+}
+
+func TestRedactorParams(t *testing.T) {
+	r := newTestRedactor(t)
+
+	params := map[string]interface{}{
+		"cpf":        "12345678900",
+		"cardNumber": "4111111111111111",
+		"id":         "1",
+		"filter":     map[string]interface{}{"document": "123.456.789-00"},
+	}
+	expected := map[string]interface{}{
+		"cpf":        Mask,
+		"cardNumber": Mask,
+		"id":         "1",
+		"filter":     map[string]interface{}{"document": Mask},
+	}
+
+	test.Equal(t, r.Params(params), expected)
+}
+
+func TestRedactorBody(t *testing.T) {
+	r := newTestRedactor(t)
+
+	body := test.Unmarshal(`{
+		"user": {"name": "Bruce", "password": "alfred"},
+		"cards": [{"number": "4111", "brand": "visa"}, {"number": "5555", "brand": "master"}],
+		"items": [{"secret": "a"}, {"secret": "b"}]
+	}`)
+	expected := test.Unmarshal(`{
+		"user": {"name": "Bruce", "password": "[REDACTED]"},
+		"cards": [{"number": "[REDACTED]", "brand": "visa"}, {"number": "[REDACTED]", "brand": "master"}],
+		"items": [{"secret": "[REDACTED]"}, {"secret": "b"}]
+	}`)
+
+	test.Equal(t, r.Body(body), expected)
+}
+
+func TestRedactorURL(t *testing.T) {
+	r := newTestRedactor(t)
+
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{"keeps url without query", "http://hero.io/api", "http://hero.io/api"},
+		{"keeps url without sensitive params", "http://hero.io/api?id=1&b=2", "http://hero.io/api?id=1&b=2"},
+		{"redacts sensitive params", "http://hero.io/api?cpf=12345678900&id=1", "http://hero.io/api?cpf=%5BREDACTED%5D&id=1"},
+		{"redacts matching text", "http://hero.io/api/123.456.789-00", "http://hero.io/api/[REDACTED]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.Equal(t, r.URL(tt.url), tt.expected)
+		})
+	}
+}
+
+func TestRedactorValue(t *testing.T) {
+	r := newTestRedactor(t)
+
+	request := restql.HTTPRequest{
+		Host:    "hero.io",
+		Query:   map[string]interface{}{"cpf": "12345678900"},
+		Headers: map[string]string{"Authorization": "Bearer abcdefgh"},
+	}
+	expected := restql.HTTPRequest{
+		Host:    "hero.io",
+		Query:   map[string]interface{}{"cpf": Mask},
+		Headers: map[string]string{"Authorization": Mask},
+	}
+	test.Equal(t, r.Value(request), expected)
+
+	err := errors.New("invalid document 123.456.789-00")
+	test.Equal(t, r.Value(err).(error).Error(), "invalid document [REDACTED]")
+
+	test.Equal(t, r.Value(10), 10)
+}
+
+func TestNilRedactor(t *testing.T) {
+	r, err := New(&conf.Config{})
+	test.VerifyError(t, err)
+	test.Equal(t, r == nil, true)
+
+	headers := map[string]string{"Authorization": "Bearer abcdefgh"}
+	test.Equal(t, r.Headers(headers), headers)
+	test.Equal(t, r.URL("http://hero.io/api?cpf=1"), "http://hero.io/api?cpf=1")
+	test.Equal(t, r.Value("text"), "text")
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      func(cfg *conf.Config)
+		expected error
+	}{
+		{"fails on invalid name pattern", func(cfg *conf.Config) { cfg.Redaction.Headers = []string{"X-[Token"} }, errInvalidNamePattern},
+		{"fails on empty json path", func(cfg *conf.Config) { cfg.Redaction.JSONPaths = []string{"$."} }, errInvalidJSONPath},
+		{"fails on malformed json path", func(cfg *conf.Config) { cfg.Redaction.JSONPaths = []string{"$.user..password"} }, errInvalidJSONPath},
+		{"fails on invalid regex", func(cfg *conf.Config) { cfg.Redaction.Patterns = []string{"(abc"} }, errInvalidRegex},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &conf.Config{}
+			tt.cfg(cfg)
+
+			_, err := New(cfg)
+			test.Equal(t, errors.Is(err, tt.expected), true)
+		})
+	}
+}
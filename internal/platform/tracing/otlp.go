@@ -0,0 +1,331 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+)
+
+const (
+	otlpTracesPath        = "/v1/traces"
+	defaultExportInterval = 5 * time.Second
+	defaultBatchSize      = 512
+	defaultQueueSize      = 2048
+)
+
+// Status codes as defined by OpenTelemetry.
+const (
+	statusCodeUnset = 0
+	statusCodeError = 2
+)
+
+// ExporterOptions defines how the spans are sent to the collector.
+type ExporterOptions struct {
+	Endpoint      string
+	ServiceName   string
+	Headers       map[string]string
+	BatchSize     int
+	QueueSize     int
+	Interval      time.Duration
+	ExportTimeout time.Duration
+}
+
+// Exporter sends spans in batches to an OpenTelemetry collector
+// using the OTLP/HTTP protocol with JSON encoding. Spans are dropped
+// when the queue is full, so tracing never blocks queries.
+type Exporter struct {
+	log     restql.Logger
+	url     string
+	options ExporterOptions
+	client  *http.Client
+
+	queue   chan *Span
+	flushCh chan chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// NewExporter constructs an Exporter instance
+// and starts the background batching routine.
+// Non positive interval, batch and queue sizes
+// are replaced by the default ones.
+func NewExporter(log restql.Logger, options ExporterOptions) *Exporter {
+	if options.Interval <= 0 {
+		options.Interval = defaultExportInterval
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultBatchSize
+	}
+	if options.QueueSize <= 0 {
+		options.QueueSize = defaultQueueSize
+	}
+
+	e := &Exporter{
+		log:     log,
+		url:     strings.TrimSuffix(options.Endpoint, "/") + otlpTracesPath,
+		options: options,
+		client:  &http.Client{Timeout: options.ExportTimeout},
+		queue:   make(chan *Span, options.QueueSize),
+		flushCh: make(chan chan struct{}),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	go e.run()
+
+	return e
+}
+
+// Enqueue adds a finished span to the next batch.
+func (e *Exporter) Enqueue(span *Span) {
+	select {
+	case e.queue <- span:
+	default:
+		e.log.Debug("tracing queue is full, dropping span", "span", span.name)
+	}
+}
+
+// Flush exports the spans in queue, waiting for it to finish.
+func (e *Exporter) Flush(ctx context.Context) error {
+	flushed := make(chan struct{})
+
+	select {
+	case e.flushCh <- flushed:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown exports the remaining spans and stops the exporter.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	close(e.done)
+
+	select {
+	case <-e.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *Exporter) run() {
+	defer close(e.stopped)
+
+	ticker := time.NewTicker(e.options.Interval)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, e.options.BatchSize)
+	export := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		err := e.export(batch)
+		if err != nil {
+			e.log.Error("failed to export spans", err, "count", len(batch))
+		}
+		batch = make([]*Span, 0, e.options.BatchSize)
+	}
+	drain := func() {
+		for {
+			select {
+			case span := <-e.queue:
+				batch = append(batch, span)
+				if len(batch) >= e.options.BatchSize {
+					export()
+				}
+			default:
+				export()
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case span := <-e.queue:
+			batch = append(batch, span)
+			if len(batch) >= e.options.BatchSize {
+				export()
+			}
+		case <-ticker.C:
+			export()
+		case flushed := <-e.flushCh:
+			drain()
+			close(flushed)
+		case <-e.done:
+			drain()
+			return
+		}
+	}
+}
+
+func (e *Exporter) export(spans []*Span) error {
+	body, err := json.Marshal(e.makeRequest(spans))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.options.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("collector responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              SpanKind        `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+func (e *Exporter) makeRequest(spans []*Span) otlpRequest {
+	otlpSpans := make([]otlpSpan, len(spans))
+	for i, span := range spans {
+		otlpSpans[i] = makeOTLPSpan(span)
+	}
+
+	return otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: []otlpAttribute{makeOTLPAttribute("service.name", e.options.ServiceName)},
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "restql"},
+				Spans: otlpSpans,
+			}},
+		}},
+	}
+}
+
+func makeOTLPSpan(span *Span) otlpSpan {
+	span.mu.Lock()
+	defer span.mu.Unlock()
+
+	s := otlpSpan{
+		TraceID:           hex.EncodeToString(span.context.TraceID[:]),
+		SpanID:            hex.EncodeToString(span.context.SpanID[:]),
+		Name:              span.name,
+		Kind:              span.kind,
+		StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+		Status:            otlpStatus{Code: statusCodeUnset},
+	}
+
+	if span.parentID != (SpanID{}) {
+		s.ParentSpanID = hex.EncodeToString(span.parentID[:])
+	}
+
+	for _, attr := range span.attributes {
+		s.Attributes = append(s.Attributes, makeOTLPAttribute(attr.Key, attr.Value))
+	}
+
+	if span.failed {
+		s.Status = otlpStatus{Code: statusCodeError, Message: span.errMessage}
+	}
+
+	return s
+}
+
+func makeOTLPAttribute(key string, value interface{}) otlpAttribute {
+	var v otlpValue
+
+	switch value := value.(type) {
+	case string:
+		v.StringValue = &value
+	case bool:
+		v.BoolValue = &value
+	case int:
+		i := strconv.Itoa(value)
+		v.IntValue = &i
+	case int64:
+		i := strconv.FormatInt(value, 10)
+		v.IntValue = &i
+	case float64:
+		v.DoubleValue = &value
+	default:
+		s := fmt.Sprintf("%v", value)
+		v.StringValue = &s
+	}
+
+	return otlpAttribute{Key: key, Value: v}
+}
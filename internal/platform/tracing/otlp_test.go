@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestExporter(t *testing.T) {
+	requests := make(chan otlpRequest, 10)
+	headers := make(chan http.Header, 10)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		test.Equal(t, r.URL.Path, "/v1/traces")
+
+		body, err := ioutil.ReadAll(r.Body)
+		test.VerifyError(t, err)
+
+		var req otlpRequest
+		test.VerifyError(t, json.Unmarshal(body, &req))
+
+		requests <- req
+		headers <- r.Header
+	}))
+	defer collector.Close()
+
+	exporter := NewExporter(test.NoOpLogger, ExporterOptions{
+		Endpoint:      collector.URL,
+		ServiceName:   "restql-test",
+		Headers:       map[string]string{"Authorization": "Bearer token"},
+		BatchSize:     10,
+		QueueSize:     10,
+		Interval:      time.Hour,
+		ExportTimeout: time.Second,
+	})
+	SetTracer(NewTracer(exporter, 1))
+	defer SetTracer(nil)
+
+	ctx, parent := Start(context.Background(), "transaction", SpanKindServer)
+	_, child := Start(ctx, "upstream request", SpanKindClient)
+	child.SetAttribute("http.status_code", 500)
+	child.SetError(errors.New("upstream failed"))
+	child.End()
+	parent.End()
+
+	err := exporter.Shutdown(context.Background())
+	test.VerifyError(t, err)
+
+	req := <-requests
+	test.Equal(t, (<-headers).Get("Authorization"), "Bearer token")
+
+	test.Equal(t, len(req.ResourceSpans), 1)
+	test.Equal(t, *req.ResourceSpans[0].Resource.Attributes[0].Value.StringValue, "restql-test")
+
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	test.Equal(t, len(spans), 2)
+
+	childSpan, parentSpan := spans[0], spans[1]
+	test.Equal(t, childSpan.Name, "upstream request")
+	test.Equal(t, childSpan.Kind, SpanKindClient)
+	test.Equal(t, childSpan.TraceID, parentSpan.TraceID)
+	test.Equal(t, childSpan.ParentSpanID, parentSpan.SpanID)
+	test.Equal(t, childSpan.Status, otlpStatus{Code: statusCodeError, Message: "upstream failed"})
+	test.Equal(t, childSpan.Attributes[0].Key, "http.status_code")
+	test.Equal(t, *childSpan.Attributes[0].Value.IntValue, "500")
+	test.Equal(t, parentSpan.ParentSpanID, "")
+	test.Equal(t, parentSpan.Status, otlpStatus{Code: statusCodeUnset})
+}
+
+func TestExporterDefaults(t *testing.T) {
+	exporter := NewExporter(test.NoOpLogger, ExporterOptions{Endpoint: "http://localhost", BatchSize: -1})
+
+	test.Equal(t, exporter.options.Interval, defaultExportInterval)
+	test.Equal(t, exporter.options.BatchSize, defaultBatchSize)
+	test.Equal(t, exporter.options.QueueSize, defaultQueueSize)
+	test.Equal(t, cap(exporter.queue), defaultQueueSize)
+	test.VerifyError(t, exporter.Shutdown(context.Background()))
+}
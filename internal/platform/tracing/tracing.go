@@ -0,0 +1,321 @@
+// Package tracing creates OpenTelemetry compatible spans for the
+// work done by restQL, propagating the W3C trace context to the
+// upstream APIs and exporting the spans through OTLP/HTTP.
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TraceParentHeader is the W3C header that carries the trace context.
+const TraceParentHeader = "traceparent"
+
+// SpanKind describes the relationship between the
+// span, its parent and its children in a trace.
+type SpanKind int
+
+// Span kinds as defined by OpenTelemetry.
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindServer   SpanKind = 2
+	SpanKindClient   SpanKind = 3
+)
+
+// TraceID identifies a trace.
+type TraceID [16]byte
+
+// SpanID identifies a span inside a trace.
+type SpanID [8]byte
+
+// SpanContext is the part of a span propagated
+// across process boundaries.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// IsValid reports whether both trace and span ids are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != TraceID{} && sc.SpanID != SpanID{}
+}
+
+// TraceParent formats the span context as a traceparent header value.
+func (sc SpanContext) TraceParent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+
+	return fmt.Sprintf("00-%s-%s-%s", hex.EncodeToString(sc.TraceID[:]), hex.EncodeToString(sc.SpanID[:]), flags)
+}
+
+// ParseTraceParent reads a traceparent header value,
+// returning false if it is not a valid one.
+func ParseTraceParent(value string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return SpanContext{}, false
+	}
+	if parts[0] == "00" && len(parts) != 4 {
+		return SpanContext{}, false
+	}
+
+	var sc SpanContext
+	if !decodeHex(parts[1], sc.TraceID[:]) || !decodeHex(parts[2], sc.SpanID[:]) || !sc.IsValid() {
+		return SpanContext{}, false
+	}
+
+	var flags [1]byte
+	if len(parts[3]) != 2 || !decodeHex(parts[3], flags[:]) {
+		return SpanContext{}, false
+	}
+	sc.Sampled = flags[0]&1 == 1
+
+	return sc, true
+}
+
+func decodeHex(s string, dst []byte) bool {
+	if len(s) != hex.EncodedLen(len(dst)) || strings.ToLower(s) != s {
+		return false
+	}
+
+	_, err := hex.Decode(dst, []byte(s))
+	return err == nil
+}
+
+// Attribute is a key-value pair describing a span.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Span represents a unit of work. All methods are safe
+// to call on a nil Span, which is returned when tracing
+// is disabled.
+type Span struct {
+	tracer   *Tracer
+	name     string
+	kind     SpanKind
+	context  SpanContext
+	parentID SpanID
+	start    time.Time
+
+	mu         sync.Mutex
+	end        time.Time
+	attributes []Attribute
+	errMessage string
+	failed     bool
+	ended      bool
+}
+
+// Context returns the span context.
+func (s *Span) Context() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+
+	return s.context
+}
+
+// SetAttribute adds an attribute to the span.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attributes = append(s.attributes, Attribute{Key: key, Value: value})
+}
+
+// SetError marks the span as failed.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failed = true
+	s.errMessage = err.Error()
+}
+
+// End finishes the span, sending it to the exporter if it is sampled.
+// Only the first call has effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+
+	if s.context.Sampled && s.tracer.exporter != nil {
+		s.tracer.exporter.Enqueue(s)
+	}
+}
+
+// SpanExporter receives the finished spans.
+type SpanExporter interface {
+	Enqueue(span *Span)
+}
+
+// Tracer creates spans, sampling the ones starting a
+// new trace by the given ratio, between 0 and 1.
+type Tracer struct {
+	exporter    SpanExporter
+	sampleRatio float64
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewTracer constructs a Tracer instance.
+func NewTracer(exporter SpanExporter, sampleRatio float64) *Tracer {
+	return &Tracer{
+		exporter:    exporter,
+		sampleRatio: sampleRatio,
+		rnd:         rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+var (
+	globalMu     sync.RWMutex
+	globalTracer *Tracer
+)
+
+// SetTracer defines the tracer used by Start.
+// A nil tracer disables tracing.
+func SetTracer(t *Tracer) {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+
+	globalTracer = t
+}
+
+func getTracer() *Tracer {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+
+	return globalTracer
+}
+
+type spanKey struct{}
+
+type remoteKey struct{}
+
+// Start creates a span as child of the one present in the context,
+// or of the remote parent, returning a context holding the new span.
+// When tracing is disabled the context is returned unchanged
+// along with a nil span.
+func Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	t := getTracer()
+	if t == nil {
+		return ctx, nil
+	}
+
+	span := &Span{tracer: t, name: name, kind: kind, start: time.Now()}
+
+	parent, hasParent := parentFromContext(ctx)
+	if hasParent {
+		span.context.TraceID = parent.TraceID
+		span.context.Sampled = parent.Sampled
+		span.parentID = parent.SpanID
+	} else {
+		span.context.TraceID = t.newTraceID()
+		span.context.Sampled = t.sample()
+	}
+	span.context.SpanID = t.newSpanID()
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// WithRemoteParent returns a context that makes the next span
+// started a child of a span created by another process.
+func WithRemoteParent(ctx context.Context, sc SpanContext) context.Context {
+	if !sc.IsValid() {
+		return ctx
+	}
+
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// SpanFromContext returns the current span, if any.
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// TraceParent returns the traceparent header value that makes
+// a remote span child of the current one, or an empty string
+// if there is no span in the context.
+func TraceParent(ctx context.Context) string {
+	span := SpanFromContext(ctx)
+	if span == nil {
+		return ""
+	}
+
+	return span.context.TraceParent()
+}
+
+func parentFromContext(ctx context.Context) (SpanContext, bool) {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.context, true
+	}
+
+	sc, ok := ctx.Value(remoteKey{}).(SpanContext)
+	return sc, ok
+}
+
+func (t *Tracer) sample() bool {
+	if t.sampleRatio >= 1 {
+		return true
+	}
+	if t.sampleRatio <= 0 {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.rnd.Float64() < t.sampleRatio
+}
+
+func (t *Tracer) newTraceID() TraceID {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var id TraceID
+	for id == (TraceID{}) {
+		t.rnd.Read(id[:])
+	}
+
+	return id
+}
+
+func (t *Tracer) newSpanID() SpanID {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var id SpanID
+	for id == (SpanID{}) {
+		t.rnd.Read(id[:])
+	}
+
+	return id
+}
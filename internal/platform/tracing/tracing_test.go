@@ -0,0 +1,120 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected SpanContext
+		ok       bool
+	}{
+		{
+			name:  "sampled trace parent",
+			value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expected: SpanContext{
+				TraceID: TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+				SpanID:  SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+				Sampled: true,
+			},
+			ok: true,
+		},
+		{
+			name:  "not sampled trace parent",
+			value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+			expected: SpanContext{
+				TraceID: TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+				SpanID:  SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+			},
+			ok: true,
+		},
+		{name: "empty value", value: ""},
+		{name: "invalid version", value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		{name: "zero trace id", value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+		{name: "zero span id", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01"},
+		{name: "upper case id", value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01"},
+		{name: "short trace id", value: "00-4bf92f3577b34da6-00f067aa0ba902b7-01"},
+		{name: "extra fields on version 00", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseTraceParent(tt.value)
+
+			test.Equal(t, ok, tt.ok)
+			test.Equal(t, got, tt.expected)
+			if ok {
+				test.Equal(t, got.TraceParent(), tt.value)
+			}
+		})
+	}
+}
+
+func TestStart(t *testing.T) {
+	t.Run("returns nil span when tracing is disabled", func(t *testing.T) {
+		SetTracer(nil)
+
+		ctx, span := Start(context.Background(), "test", SpanKindInternal)
+
+		test.Equal(t, span == nil, true)
+		test.Equal(t, TraceParent(ctx), "")
+		span.SetAttribute("key", "value")
+		span.End()
+	})
+
+	t.Run("creates child spans on the same trace", func(t *testing.T) {
+		exporter := &recordingExporter{}
+		SetTracer(NewTracer(exporter, 1))
+		defer SetTracer(nil)
+
+		ctx, parent := Start(context.Background(), "parent", SpanKindServer)
+		childCtx, child := Start(ctx, "child", SpanKindClient)
+		child.End()
+		parent.End()
+
+		test.Equal(t, child.Context().TraceID, parent.Context().TraceID)
+		test.Equal(t, child.parentID, parent.Context().SpanID)
+		test.Equal(t, TraceParent(childCtx), child.Context().TraceParent())
+		test.Equal(t, len(exporter.spans), 2)
+	})
+
+	t.Run("continues remote trace", func(t *testing.T) {
+		exporter := &recordingExporter{}
+		SetTracer(NewTracer(exporter, 1))
+		defer SetTracer(nil)
+
+		remote, _ := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+		_, span := Start(WithRemoteParent(context.Background(), remote), "transaction", SpanKindServer)
+		span.End()
+
+		test.Equal(t, span.Context().TraceID, remote.TraceID)
+		test.Equal(t, span.parentID, remote.SpanID)
+		test.Equal(t, span.Context().Sampled, false)
+		test.Equal(t, len(exporter.spans), 0)
+	})
+
+	t.Run("does not export unsampled root spans", func(t *testing.T) {
+		exporter := &recordingExporter{}
+		SetTracer(NewTracer(exporter, 0))
+		defer SetTracer(nil)
+
+		_, span := Start(context.Background(), "root", SpanKindInternal)
+		span.End()
+
+		test.Equal(t, span.Context().IsValid(), true)
+		test.Equal(t, len(exporter.spans), 0)
+	})
+}
+
+type recordingExporter struct {
+	spans []*Span
+}
+
+func (r *recordingExporter) Enqueue(span *Span) {
+	r.spans = append(r.spans, span)
+}
@@ -2,12 +2,22 @@ package web
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/cache"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web/middleware"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
 	"github.com/valyala/fasthttp"
 	"strconv"
+	"time"
+)
+
+var (
+	errPurgeWithoutNamespace = errors.New("invalid purge : a query can only be purged along with its namespace")
+	errAdminForbidden        = errors.New("forbidden : token not allowed to perform the operation")
+	errInvalidAuditSince     = errors.New("invalid since parameter : it must be a RFC 3339 timestamp")
 )
 
 type queryRevision struct {
@@ -31,25 +41,40 @@ type mapping struct {
 }
 
 type administrator struct {
-	log               restql.Logger
-	mr                persistence.MappingsReader
-	mw                persistence.MappingsWriter
-	qr                persistence.QueryReader
-	queryWriter       persistence.QueryWriter
-	authorizationCode []byte
+	log         restql.Logger
+	mr          persistence.MappingsReader
+	mw          persistence.MappingsWriter
+	qr          persistence.QueryReader
+	queryWriter persistence.QueryWriter
+	responses   *cache.ResponseCache
+	rateLimiter *middleware.RateLimiter
+	access      adminAccess
+	audit       *persistence.AuditLog
 }
 
-func newAdmin(log restql.Logger, mr persistence.MappingsReader, mw persistence.MappingsWriter, qr persistence.QueryReader, qw persistence.QueryWriter, authorizationCode string) *administrator {
-	return &administrator{log: log, mr: mr, mw: mw, qr: qr, queryWriter: qw, authorizationCode: []byte(authorizationCode)}
+func newAdmin(log restql.Logger, mr persistence.MappingsReader, mw persistence.MappingsWriter, qr persistence.QueryReader, qw persistence.QueryWriter, rc *cache.ResponseCache, rl *middleware.RateLimiter, access adminAccess, audit *persistence.AuditLog) *administrator {
+	return &administrator{log: log, mr: mr, mw: mw, qr: qr, queryWriter: qw, responses: rc, rateLimiter: rl, access: access, audit: audit}
 }
 
 func (adm *administrator) AllTenants(ctx *fasthttp.RequestCtx) error {
+	identity, ok := adm.identify(ctx)
+	if !ok {
+		return nil
+	}
+
 	tenants, err := adm.mr.ListTenants(ctx)
 	if err != nil {
 		return RespondError(ctx, err, errToStatusCode)
 	}
 
-	data := map[string]interface{}{"tenants": tenants}
+	allowed := make([]string, 0, len(tenants))
+	for _, t := range tenants {
+		if identity.canOnTenant(adminRoleReadOnly, t) {
+			allowed = append(allowed, t)
+		}
+	}
+
+	data := map[string]interface{}{"tenants": allowed}
 	return Respond(ctx, data, fasthttp.StatusOK, nil)
 }
 
@@ -63,6 +88,14 @@ func (adm *administrator) TenantMappings(reqCtx *fasthttp.RequestCtx) error {
 		return err
 	}
 
+	identity, ok := adm.identify(reqCtx)
+	if !ok {
+		return nil
+	}
+	if !identity.canOnTenant(adminRoleReadOnly, tenantName) {
+		return respondAdminForbidden(reqCtx, identity)
+	}
+
 	sourceFilter := restql.Source(reqCtx.QueryArgs().Peek("source"))
 
 	mappings, err := adm.mr.FromTenant(ctx, tenantName)
@@ -91,12 +124,24 @@ func (adm administrator) AllNamespaces(reqCtx *fasthttp.RequestCtx) error {
 	ctx := middleware.GetNativeContext(reqCtx)
 	ctx = restql.WithLogger(ctx, adm.log)
 
+	identity, ok := adm.identify(reqCtx)
+	if !ok {
+		return nil
+	}
+
 	namespaces, err := adm.qr.ListNamespaces(ctx)
 	if err != nil {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
-	data := map[string]interface{}{"namespaces": namespaces}
+	allowed := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		if identity.canOnNamespace(adminRoleReadOnly, ns) {
+			allowed = append(allowed, ns)
+		}
+	}
+
+	data := map[string]interface{}{"namespaces": allowed}
 	return Respond(reqCtx, data, fasthttp.StatusOK, nil)
 }
 
@@ -110,6 +155,14 @@ func (adm administrator) NamespaceQueries(reqCtx *fasthttp.RequestCtx) error {
 		return err
 	}
 
+	identity, ok := adm.identify(reqCtx)
+	if !ok {
+		return nil
+	}
+	if !identity.canOnNamespace(adminRoleReadOnly, namespace) {
+		return respondAdminForbidden(reqCtx, identity)
+	}
+
 	sourceFilter := restql.Source(reqCtx.QueryArgs().Peek("source"))
 	archivedFilter, err := strconv.ParseBool(string(reqCtx.QueryArgs().Peek("archived")))
 	if err != nil {
@@ -159,6 +212,14 @@ func (adm *administrator) QueryRevisions(reqCtx *fasthttp.RequestCtx) error {
 		return err
 	}
 
+	identity, ok := adm.identify(reqCtx)
+	if !ok {
+		return nil
+	}
+	if !identity.canOnNamespace(adminRoleReadOnly, namespace) {
+		return respondAdminForbidden(reqCtx, identity)
+	}
+
 	queryName, err := pathParamString(reqCtx, "queryId")
 	if err != nil {
 		adm.log.Error("failed to load query name path param", err)
@@ -202,6 +263,14 @@ func (adm *administrator) Query(reqCtx *fasthttp.RequestCtx) error {
 		return err
 	}
 
+	identity, ok := adm.identify(reqCtx)
+	if !ok {
+		return nil
+	}
+	if !identity.canOnNamespace(adminRoleReadOnly, namespace) {
+		return respondAdminForbidden(reqCtx, identity)
+	}
+
 	queryName, err := pathParamString(reqCtx, "queryId")
 	if err != nil {
 		adm.log.Error("failed to load query name path param", err)
@@ -238,11 +307,6 @@ func (adm *administrator) MapResource(reqCtx *fasthttp.RequestCtx) error {
 	ctx := middleware.GetNativeContext(reqCtx)
 	ctx = restql.WithLogger(ctx, adm.log)
 
-	if !isAuthorized(reqCtx, adm.authorizationCode) {
-		reqCtx.Response.SetStatusCode(fasthttp.StatusUnauthorized)
-		return nil
-	}
-
 	tenantName, err := pathParamString(reqCtx, "tenantName")
 	if err != nil {
 		adm.log.Error("failed to load tenant name path param", err)
@@ -255,6 +319,14 @@ func (adm *administrator) MapResource(reqCtx *fasthttp.RequestCtx) error {
 		return err
 	}
 
+	identity, ok := adm.identify(reqCtx)
+	if !ok {
+		return nil
+	}
+	if !identity.canOnTenant(adminRoleMappingAdmin, tenantName) {
+		return respondAdminForbidden(reqCtx, identity)
+	}
+
 	var mrb mapResourceBody
 
 	bytesBody := reqCtx.PostBody()
@@ -263,25 +335,170 @@ func (adm *administrator) MapResource(reqCtx *fasthttp.RequestCtx) error {
 		return err
 	}
 
+	var before interface{}
+	if mappings, err := adm.mr.FromTenant(ctx, tenantName); err == nil {
+		if m, found := mappings[resourceName]; found {
+			before = mapping{URL: m.URL(), Source: string(m.Source)}
+		}
+	}
+
 	err = adm.mw.Write(ctx, tenantName, resourceName, mrb.Url)
 	if err != nil {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
+	adm.record(ctx, identity, restql.AuditEntry{
+		Action: restql.AuditActionMapResource,
+		Tenant: tenantName,
+		Target: resourceName,
+		Before: before,
+		After:  mapping{URL: mrb.Url, Source: string(restql.DatabaseSource)},
+	})
+
 	return Respond(reqCtx, nil, fasthttp.StatusCreated, nil)
 }
 
-func isAuthorized(ctx *fasthttp.RequestCtx, authorizationCode []byte) bool {
+func (adm *administrator) PurgeResponseCache(reqCtx *fasthttp.RequestCtx) error {
+	namespace := string(reqCtx.QueryArgs().Peek("namespace"))
+	queryName := string(reqCtx.QueryArgs().Peek("query"))
+	if namespace == "" && queryName != "" {
+		return RespondError(reqCtx, errPurgeWithoutNamespace, errToStatusCode)
+	}
+
+	// purging was restricted to the authorization code
+	// before tokens existed, so it is never anonymous
+	identity, ok := adm.identify(reqCtx)
+	if !ok {
+		return nil
+	}
+	if identity.anonymous || !identity.canOnNamespace(adminRoleQueryAuthor, namespace) {
+		return respondAdminForbidden(reqCtx, identity)
+	}
+
+	purged := adm.responses.Purge(namespace, queryName)
+	adm.log.Info("response cache purged", "namespace", namespace, "query", queryName, "purged", purged)
+
+	adm.record(middleware.GetNativeContext(reqCtx), identity, restql.AuditEntry{
+		Action:    restql.AuditActionPurgeResponseCache,
+		Namespace: namespace,
+		Target:    queryName,
+		After:     map[string]interface{}{"purged": purged},
+	})
+
+	data := map[string]interface{}{"purged": purged}
+	return Respond(reqCtx, data, fasthttp.StatusOK, nil)
+}
+
+func (adm *administrator) RateLimitUsage(reqCtx *fasthttp.RequestCtx) error {
+	identity, ok := adm.identify(reqCtx)
+	if !ok {
+		return nil
+	}
+	if !identity.canSeeRateLimitUsage() {
+		return respondAdminForbidden(reqCtx, identity)
+	}
+
+	data := map[string]interface{}{"rules": adm.rateLimiter.Usage()}
+	return Respond(reqCtx, data, fasthttp.StatusOK, nil)
+}
+
+// AuditLog returns the changes made through the administrative API,
+// omitting the ones about tenants and namespaces out of the token scope.
+func (adm *administrator) AuditLog(reqCtx *fasthttp.RequestCtx) error {
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, adm.log)
+
+	identity, ok := adm.identify(reqCtx)
+	if !ok {
+		return nil
+	}
+
+	args := reqCtx.QueryArgs()
+	filter := restql.AuditFilter{
+		Actor:     string(args.Peek("actor")),
+		Action:    string(args.Peek("action")),
+		Tenant:    string(args.Peek("tenant")),
+		Namespace: string(args.Peek("namespace")),
+	}
+
+	if since := string(args.Peek("since")); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return RespondError(reqCtx, errInvalidAuditSince, errToStatusCode)
+		}
+		filter.Since = t
+	}
+
+	if limit, err := strconv.Atoi(string(args.Peek("limit"))); err == nil {
+		filter.Limit = limit
+	}
+
+	// the scope is part of the filter so the limit applies to the entries
+	// in scope, which are checked again in case the storage ignores it
+	filter.TenantScope = identity.tenants
+	filter.NamespaceScope = identity.namespaces
+
+	entries, err := adm.audit.Find(ctx, filter)
+	if err != nil {
+		adm.log.Error("failed to read audit log", err)
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	visible := make([]restql.AuditEntry, 0, len(entries))
+	for _, e := range entries {
+		if filter.Match(e) {
+			visible = append(visible, e)
+		}
+	}
+
+	data := map[string]interface{}{"entries": visible}
+	return Respond(reqCtx, data, fasthttp.StatusOK, nil)
+}
+
+// identify responds with 401 when the request has no valid token.
+func (adm *administrator) identify(reqCtx *fasthttp.RequestCtx) (adminIdentity, bool) {
+	identity, ok := adm.access.identify(reqCtx)
+	if !ok {
+		reqCtx.Response.SetStatusCode(fasthttp.StatusUnauthorized)
+		return adminIdentity{}, false
+	}
+
+	return identity, true
+}
+
+// respondAdminForbidden denies the operation, with 401 for requests
+// without token, since sending one may allow it.
+func respondAdminForbidden(reqCtx *fasthttp.RequestCtx, identity adminIdentity) error {
+	if identity.anonymous {
+		reqCtx.Response.SetStatusCode(fasthttp.StatusUnauthorized)
+		return nil
+	}
+
+	return RespondError(reqCtx, errAdminForbidden, errToStatusCode)
+}
+
+// record appends a change to the audit log. Failures are only logged,
+// since the change was already made.
+func (adm *administrator) record(ctx context.Context, identity adminIdentity, entry restql.AuditEntry) {
+	entry.Time = time.Now().UTC()
+	entry.Actor = identity.name
+
+	err := adm.audit.Record(ctx, entry)
+	if err != nil {
+		adm.log.Error("failed to record admin audit entry", err, "action", entry.Action, "actor", entry.Actor)
+	}
+}
+
+func bearerCode(ctx *fasthttp.RequestCtx) []byte {
 	bearerCode := getBearerToken(ctx)
 	if len(bearerCode) == 0 {
-		return false
+		return nil
 	}
 
 	bearerCode = bytes.TrimPrefix(bearerCode, []byte("Bearer"))
 	bearerCode = bytes.TrimPrefix(bearerCode, []byte("bearer"))
-	bearerCode = bytes.TrimSpace(bearerCode)
 
-	return bytes.Equal(bearerCode, authorizationCode)
+	return bytes.TrimSpace(bearerCode)
 }
 
 func getBearerToken(ctx *fasthttp.RequestCtx) []byte {
@@ -315,17 +532,38 @@ func (adm *administrator) CreateQueryRevision(reqCtx *fasthttp.RequestCtx) error
 		return err
 	}
 
+	identity, ok := adm.identify(reqCtx)
+	if !ok {
+		return nil
+	}
+	if !identity.canOnNamespace(adminRoleQueryAuthor, namespace) {
+		return respondAdminForbidden(reqCtx, identity)
+	}
+
 	queryName, err := pathParamString(reqCtx, "queryId")
 	if err != nil {
 		adm.log.Error("failed to load query name path param", err)
 		return err
 	}
 
+	var before interface{}
+	if savedQuery, err := adm.qr.ListQueryRevisions(ctx, namespace, queryName, false); err == nil && len(savedQuery.Revisions) > 0 {
+		before = toQueryRevision(savedQuery.Revisions[len(savedQuery.Revisions)-1])
+	}
+
 	err = adm.queryWriter.Write(ctx, namespace, queryName, crb.Text)
 	if err != nil {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
+	adm.record(ctx, identity, restql.AuditEntry{
+		Action:    restql.AuditActionCreateQueryRevision,
+		Namespace: namespace,
+		Target:    queryName,
+		Before:    before,
+		After:     queryRevision{Text: crb.Text},
+	})
+
 	return Respond(reqCtx, nil, fasthttp.StatusCreated, nil)
 }
 
@@ -343,6 +581,14 @@ func (adm *administrator) UpdateQueryArchiving(reqCtx *fasthttp.RequestCtx) erro
 		return err
 	}
 
+	identity, ok := adm.identify(reqCtx)
+	if !ok {
+		return nil
+	}
+	if !identity.canOnNamespace(adminRoleQueryAuthor, namespace) {
+		return respondAdminForbidden(reqCtx, identity)
+	}
+
 	queryName, err := pathParamString(reqCtx, "queryId")
 	if err != nil {
 		adm.log.Error("failed to load query name path param", err)
@@ -357,11 +603,24 @@ func (adm *administrator) UpdateQueryArchiving(reqCtx *fasthttp.RequestCtx) erro
 		return err
 	}
 
+	var before interface{}
+	if savedQuery, err := adm.qr.ListQueryRevisions(ctx, namespace, queryName, true); err == nil {
+		before = updateArchivingBody{Archived: savedQuery.Archived}
+	}
+
 	err = adm.queryWriter.UpdateQueryArchiving(ctx, namespace, queryName, body.Archived)
 	if err != nil {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
+	adm.record(ctx, identity, restql.AuditEntry{
+		Action:    restql.AuditActionUpdateQueryArchiving,
+		Namespace: namespace,
+		Target:    queryName,
+		Before:    before,
+		After:     body,
+	})
+
 	return Respond(reqCtx, nil, fasthttp.StatusNoContent, nil)
 }
 
@@ -375,6 +634,14 @@ func (adm *administrator) UpdateRevisionArchiving(reqCtx *fasthttp.RequestCtx) e
 		return err
 	}
 
+	identity, ok := adm.identify(reqCtx)
+	if !ok {
+		return nil
+	}
+	if !identity.canOnNamespace(adminRoleQueryAuthor, namespace) {
+		return respondAdminForbidden(reqCtx, identity)
+	}
+
 	queryName, err := pathParamString(reqCtx, "queryId")
 	if err != nil {
 		adm.log.Error("failed to load query name path param", err)
@@ -401,11 +668,24 @@ func (adm *administrator) UpdateRevisionArchiving(reqCtx *fasthttp.RequestCtx) e
 		return err
 	}
 
+	var before interface{}
+	if savedRevision, err := adm.qr.Get(ctx, namespace, queryName, revision); err == nil {
+		before = updateArchivingBody{Archived: savedRevision.Archived}
+	}
+
 	err = adm.queryWriter.UpdateRevisionArchiving(ctx, namespace, queryName, revision, body.Archived)
 	if err != nil {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
+	adm.record(ctx, identity, restql.AuditEntry{
+		Action:    restql.AuditActionUpdateRevisionArchiving,
+		Namespace: namespace,
+		Target:    queryName + "/" + strconv.Itoa(revision),
+		Before:    before,
+		After:     body,
+	})
+
 	return Respond(reqCtx, nil, fasthttp.StatusNoContent, nil)
 }
 
@@ -0,0 +1,143 @@
+package web
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/valyala/fasthttp"
+)
+
+var (
+	errUnknownAdminRole  = errors.New("unknown admin role")
+	errMissingAdminToken = errors.New("admin token not found")
+)
+
+// Administrative roles. Every role allows reading, while
+// query-author allows changing queries and purging their
+// cached responses and mapping-admin allows changing mappings.
+const (
+	adminRoleReadOnly     = "read-only"
+	adminRoleQueryAuthor  = "query-author"
+	adminRoleMappingAdmin = "mapping-admin"
+)
+
+const (
+	authorizationCodeActor = "authorization-code"
+	anonymousActor         = "anonymous"
+)
+
+// adminIdentity is the holder of an administrative token, allowed to
+// act on the tenants and namespaces of its scope, or on all of them
+// when the scope is empty.
+type adminIdentity struct {
+	name       string
+	roles      []string
+	tenants    []string
+	namespaces []string
+	anonymous  bool
+}
+
+func (i adminIdentity) can(role string) bool {
+	for _, r := range i.roles {
+		if r == role || role == adminRoleReadOnly {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (i adminIdentity) canOnTenant(role string, tenant string) bool {
+	return i.can(role) && inScope(i.tenants, tenant)
+}
+
+func (i adminIdentity) canOnNamespace(role string, namespace string) bool {
+	return i.can(role) && inScope(i.namespaces, namespace)
+}
+
+// unrestricted reports whether the identity can act on every tenant and namespace.
+func (i adminIdentity) unrestricted() bool {
+	return len(i.tenants) == 0 && len(i.namespaces) == 0
+}
+
+// canSeeRateLimitUsage tells whether the identity can read the rate
+// limit buckets, which hold client addresses and tenants of every
+// scope, hence requiring a token without restriction.
+func (i adminIdentity) canSeeRateLimitUsage() bool {
+	return !i.anonymous && i.unrestricted()
+}
+
+// inScope checks a value against a scope, where an empty
+// value is only in scope if the scope has no restriction.
+func inScope(scope []string, value string) bool {
+	if len(scope) == 0 {
+		return true
+	}
+
+	for _, s := range scope {
+		if s == "*" || (value != "" && strings.EqualFold(s, value)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type adminToken struct {
+	code     []byte
+	identity adminIdentity
+}
+
+// adminAccess identifies the client of the administrative API by the
+// bearer token sent. The authorization code is accepted as a token
+// with every role. When no other token is configured, requests
+// without one keep the access they had before tokens existed:
+// reading and changing queries.
+type adminAccess struct {
+	tokens    []adminToken
+	anonymous bool
+}
+
+func newAdminAccess(authorizationCode string, tokens []adminToken) adminAccess {
+	access := adminAccess{anonymous: len(tokens) == 0}
+
+	for _, t := range tokens {
+		if len(t.code) > 0 {
+			access.tokens = append(access.tokens, t)
+		}
+	}
+
+	if authorizationCode != "" {
+		access.tokens = append(access.tokens, adminToken{
+			code: []byte(authorizationCode),
+			identity: adminIdentity{
+				name:  authorizationCodeActor,
+				roles: []string{adminRoleReadOnly, adminRoleQueryAuthor, adminRoleMappingAdmin},
+			},
+		})
+	}
+
+	return access
+}
+
+func (a adminAccess) identify(ctx *fasthttp.RequestCtx) (adminIdentity, bool) {
+	code := bearerCode(ctx)
+	if len(code) > 0 {
+		for _, t := range a.tokens {
+			if subtle.ConstantTimeCompare(t.code, code) == 1 {
+				return t.identity, true
+			}
+		}
+	}
+
+	if a.anonymous {
+		return adminIdentity{
+			name:      anonymousActor,
+			roles:     []string{adminRoleReadOnly, adminRoleQueryAuthor},
+			anonymous: true,
+		}, true
+	}
+
+	return adminIdentity{}, false
+}
@@ -0,0 +1,94 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+func TestAdminAccessIdentify(t *testing.T) {
+	authorTokens := []adminToken{
+		{code: []byte("author-token"), identity: adminIdentity{name: "dc-author", roles: []string{adminRoleQueryAuthor}, namespaces: []string{"dc"}}},
+	}
+
+	tests := []struct {
+		name          string
+		access        adminAccess
+		authorization string
+		expectedFound bool
+		expectedName  string
+	}{
+		{
+			name:          "identifies token",
+			access:        newAdminAccess("", authorTokens),
+			authorization: "Bearer author-token",
+			expectedFound: true,
+			expectedName:  "dc-author",
+		},
+		{
+			name:          "identifies authorization code",
+			access:        newAdminAccess("my-code", authorTokens),
+			authorization: "Bearer my-code",
+			expectedFound: true,
+			expectedName:  authorizationCodeActor,
+		},
+		{
+			name:          "rejects unknown token",
+			access:        newAdminAccess("my-code", authorTokens),
+			authorization: "Bearer other-token",
+			expectedFound: false,
+		},
+		{
+			name:          "rejects request without token",
+			access:        newAdminAccess("", authorTokens),
+			expectedFound: false,
+		},
+		{
+			name:          "identifies anonymous client when no token is configured",
+			access:        newAdminAccess("my-code", nil),
+			expectedFound: true,
+			expectedName:  anonymousActor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &fasthttp.RequestCtx{}
+			if tt.authorization != "" {
+				ctx.Request.Header.Set("Authorization", tt.authorization)
+			}
+
+			identity, found := tt.access.identify(ctx)
+
+			test.Equal(t, found, tt.expectedFound)
+			test.Equal(t, identity.name, tt.expectedName)
+		})
+	}
+}
+
+func TestAdminIdentityPermissions(t *testing.T) {
+	reader := adminIdentity{name: "reader", roles: []string{adminRoleReadOnly}}
+	author := adminIdentity{name: "author", roles: []string{adminRoleQueryAuthor}, namespaces: []string{"dc"}}
+	mapper := adminIdentity{name: "mapper", roles: []string{adminRoleMappingAdmin}, tenants: []string{"DC", "VERTIGO"}}
+
+	test.Equal(t, reader.canOnNamespace(adminRoleReadOnly, "marvel"), true)
+	test.Equal(t, reader.canOnNamespace(adminRoleQueryAuthor, "marvel"), false)
+	test.Equal(t, reader.canOnTenant(adminRoleMappingAdmin, "DC"), false)
+
+	test.Equal(t, author.canOnNamespace(adminRoleReadOnly, "dc"), true)
+	test.Equal(t, author.canOnNamespace(adminRoleQueryAuthor, "dc"), true)
+	test.Equal(t, author.canOnNamespace(adminRoleQueryAuthor, "marvel"), false)
+	test.Equal(t, author.canOnNamespace(adminRoleQueryAuthor, ""), false)
+	test.Equal(t, author.canOnTenant(adminRoleMappingAdmin, "DC"), false)
+
+	test.Equal(t, mapper.canOnTenant(adminRoleMappingAdmin, "dc"), true)
+	test.Equal(t, mapper.canOnTenant(adminRoleMappingAdmin, "MARVEL"), false)
+	test.Equal(t, mapper.canOnNamespace(adminRoleReadOnly, "marvel"), true)
+
+	anonymous := adminIdentity{name: anonymousActor, roles: []string{adminRoleReadOnly}, anonymous: true}
+	test.Equal(t, reader.canSeeRateLimitUsage(), true)
+	test.Equal(t, author.canSeeRateLimitUsage(), false)
+	test.Equal(t, mapper.canSeeRateLimitUsage(), false)
+	test.Equal(t, anonymous.canSeeRateLimitUsage(), false)
+}
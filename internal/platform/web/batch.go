@@ -0,0 +1,162 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+var (
+	errEmptyBatch        = errors.New("invalid batch : no query provided")
+	errBatchTooLarge     = errors.New("invalid batch : too many queries")
+	errInvalidBatchQuery = errors.New("invalid batch query : must provide either a query text, a persisted query hash or a saved query reference")
+)
+
+// BatchRequest represents the client format of a request
+// to execute multiple queries at once.
+type BatchRequest struct {
+	Queries []BatchQuery `json:"queries"`
+}
+
+// BatchQuery represents a query to be executed in a batch,
+// either an ad-hoc one, given by its text or persisted query
+// hash, or a saved one, given by its namespace, id and revision.
+type BatchQuery struct {
+	ID        string                 `json:"id,omitempty"`
+	Text      string                 `json:"text,omitempty"`
+	SHA256    string                 `json:"sha256,omitempty"`
+	Namespace string                 `json:"namespace,omitempty"`
+	QueryID   string                 `json:"query-id,omitempty"`
+	Revision  int                    `json:"revision,omitempty"`
+	Params    map[string]interface{} `json:"params,omitempty"`
+	Body      interface{}            `json:"body,omitempty"`
+}
+
+func (bq BatchQuery) isAdHoc() bool {
+	return bq.Text != "" || bq.SHA256 != ""
+}
+
+func (bq BatchQuery) validate() error {
+	isSaved := bq.Namespace != "" && bq.QueryID != "" && bq.Revision > 0
+	if bq.isAdHoc() == isSaved {
+		return errInvalidBatchQuery
+	}
+
+	return nil
+}
+
+// BatchResponse represents the client format of the
+// result of a batch, in the same order as the request.
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
+
+// BatchResult represents the client format of the result of a
+// query executed in a batch, with the status code and headers
+// that would be returned if it were executed alone.
+type BatchResult struct {
+	ID      string            `json:"id,omitempty"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    interface{}       `json:"body"`
+}
+
+type batchEvaluation func(ctx context.Context, query BatchQuery) (domain.Resources, error)
+
+// parseBatchRequest reads and validates the batch, rejecting
+// it when it has more than maxSize queries, if positive.
+func parseBatchRequest(body []byte, maxSize int) (BatchRequest, error) {
+	var request BatchRequest
+	err := json.Unmarshal(body, &request)
+	if err != nil {
+		return BatchRequest{}, fmt.Errorf("%w: %s", errFailedToReadRequestBody, err)
+	}
+
+	if len(request.Queries) == 0 {
+		return BatchRequest{}, errEmptyBatch
+	}
+
+	if maxSize > 0 && len(request.Queries) > maxSize {
+		return BatchRequest{}, fmt.Errorf("%w: %d queries, the maximum is %d", errBatchTooLarge, len(request.Queries), maxSize)
+	}
+
+	for i, q := range request.Queries {
+		if err := q.validate(); err != nil {
+			return BatchRequest{}, fmt.Errorf("%w: query at position %d", err, i)
+		}
+	}
+
+	return request, nil
+}
+
+// batchConcurrency is the number of queries of a batch evaluated at
+// the same time. It never exceeds the maximum concurrent queries,
+// otherwise the runner would deny the queries of large batches.
+func batchConcurrency(cfg *conf.Config) int {
+	concurrency := cfg.HTTP.Server.Batch.MaxConcurrency
+	maxQueries := cfg.HTTP.Client.MaxConcurrentQueries
+	if maxQueries > 0 && (concurrency <= 0 || concurrency > maxQueries) {
+		concurrency = maxQueries
+	}
+
+	return concurrency
+}
+
+// runBatch evaluates the queries concurrently, at most concurrency
+// at the same time if positive, a failure on one of them being
+// reported only on its own result.
+func runBatch(ctx context.Context, queries []BatchQuery, concurrency int, debug debugPolicy, tenant string, evaluate batchEvaluation) []BatchResult {
+	log := restql.GetLogger(ctx)
+	results := make([]BatchResult, len(queries))
+
+	if concurrency <= 0 || concurrency > len(queries) {
+		concurrency = len(queries)
+	}
+	slots := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	wg.Add(len(queries))
+
+	for i, query := range queries {
+		slots <- struct{}{}
+		go func(i int, query BatchQuery) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			result, err := evaluate(ctx, query)
+			debugging := debug.debugging(tenant, restql.QueryInput{Params: query.Params})
+			results[i] = makeBatchResult(log, query, debugging, result, err)
+		}(i, query)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+func makeBatchResult(log restql.Logger, query BatchQuery, debugging Debugging, result domain.Resources, err error) BatchResult {
+	if err != nil {
+		log.Error("failed to evaluate batch query", err, "id", query.ID)
+
+		toStatusCode := errToStatusCode
+		if query.isAdHoc() {
+			toStatusCode = adHocErrToStatusCode()
+		}
+
+		return BatchResult{ID: query.ID, Status: findStatusCode(toStatusCode, err), Body: ErrorResponse{Error: err.Error()}}
+	}
+
+	response, err := MakeQueryResponse(result, debugging)
+	if err != nil {
+		log.Error("failed to make batch query response", err, "id", query.ID)
+		return BatchResult{ID: query.ID, Status: findStatusCode(errToStatusCode, err), Body: ErrorResponse{Error: err.Error()}}
+	}
+
+	return BatchResult{ID: query.ID, Status: response.StatusCode, Headers: response.Headers, Body: response.Body}
+}
@@ -0,0 +1,134 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestParseBatchRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		maxSize  int
+		expected error
+	}{
+		{"should accept ad-hoc and saved queries", `{"queries": [{"text": "from hero"}, {"namespace": "dc", "query-id": "heroes", "revision": 1}]}`, 2, nil},
+		{"should accept any number of queries without maximum", `{"queries": [{"text": "from hero"}, {"text": "from sidekick"}, {"text": "from villain"}]}`, 0, nil},
+		{"should fail on batch larger than maximum", `{"queries": [{"text": "from hero"}, {"text": "from sidekick"}, {"text": "from villain"}]}`, 2, errBatchTooLarge},
+		{"should fail on invalid json", `{"queries": `, 2, errFailedToReadRequestBody},
+		{"should fail on empty batch", `{"queries": []}`, 2, errEmptyBatch},
+		{"should fail on query without text or reference", `{"queries": [{"namespace": "dc"}]}`, 2, errInvalidBatchQuery},
+		{"should fail on query with both text and reference", `{"queries": [{"text": "from hero", "namespace": "dc", "query-id": "heroes", "revision": 1}]}`, 2, errInvalidBatchQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseBatchRequest([]byte(tt.body), tt.maxSize)
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("expected error %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestRunBatch(t *testing.T) {
+	queries := []BatchQuery{
+		{ID: "hero", Text: "from hero"},
+		{ID: "invalid", Text: "form hero"},
+		{ID: "sidekick", Namespace: "dc", QueryID: "sidekicks", Revision: 1},
+		{ID: "denied", Namespace: "dc", QueryID: "villains", Revision: 1},
+	}
+
+	evaluate := func(ctx context.Context, query BatchQuery) (domain.Resources, error) {
+		switch query.ID {
+		case "invalid":
+			return nil, eval.ErrParser
+		case "denied":
+			return nil, runner.ErrMaxQueryDenied
+		default:
+			return domain.Resources{
+				domain.ResourceID(query.ID): restql.DoneResource{
+					Status:       200,
+					ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, map[string]interface{}{"name": query.ID}),
+				},
+			}, nil
+		}
+	}
+
+	results := runBatch(context.Background(), queries, 0, debugPolicy{}, "default", evaluate)
+
+	test.Equal(t, len(results), 4)
+
+	expectedStatus := []int{200, 400, 200, 507}
+	for i, result := range results {
+		test.Equal(t, result.ID, queries[i].ID)
+		test.Equal(t, result.Status, expectedStatus[i])
+	}
+
+	body := results[2].Body.(map[string]StatementResult)
+	test.Equal(t, body["sidekick"].Result, json.RawMessage(`{"name":"sidekick"}`))
+	test.Equal(t, results[3].Body.(ErrorResponse).Error, runner.ErrMaxQueryDenied.Error())
+}
+
+func TestRunBatchConcurrency(t *testing.T) {
+	queries := make([]BatchQuery, 10)
+	for i := range queries {
+		queries[i] = BatchQuery{Text: "from hero"}
+	}
+
+	var running, maxRunning int32
+	evaluate := func(ctx context.Context, query BatchQuery) (domain.Resources, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		return domain.Resources{}, nil
+	}
+
+	results := runBatch(context.Background(), queries, 3, debugPolicy{}, "default", evaluate)
+
+	test.Equal(t, len(results), 10)
+	test.Equal(t, atomic.LoadInt32(&maxRunning) <= 3, true)
+}
+
+func TestBatchConcurrency(t *testing.T) {
+	tests := []struct {
+		name                 string
+		maxConcurrency       int
+		maxConcurrentQueries int
+		expected             int
+	}{
+		{"uses batch concurrency", 5, 100, 5},
+		{"never exceeds max concurrent queries", 5, 2, 2},
+		{"uses max concurrent queries when batch concurrency is not set", 0, 2, 2},
+		{"does not limit when both are not set", 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &conf.Config{}
+			cfg.HTTP.Server.Batch.MaxConcurrency = tt.maxConcurrency
+			cfg.HTTP.Client.MaxConcurrentQueries = tt.maxConcurrentQueries
+
+			test.Equal(t, batchConcurrency(cfg), tt.expected)
+		})
+	}
+}
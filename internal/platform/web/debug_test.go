@@ -0,0 +1,72 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/redact"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestDebugPolicy(t *testing.T) {
+	debugInput := restql.QueryInput{Params: map[string]interface{}{"_debug": "true"}}
+
+	tests := []struct {
+		name            string
+		disable         bool
+		disabledTenants []string
+		tenant          string
+		input           restql.QueryInput
+		expected        bool
+	}{
+		{"should enable debugging when requested", false, nil, "DC", debugInput, true},
+		{"should not enable debugging when not requested", false, nil, "DC", restql.QueryInput{}, false},
+		{"should not enable debugging when disabled", true, nil, "DC", debugInput, false},
+		{"should not enable debugging for disabled tenant", false, []string{"MARVEL", "DC"}, "DC", debugInput, false},
+		{"should enable debugging for other tenants", false, []string{"MARVEL"}, "DC", debugInput, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &conf.Config{}
+			cfg.HTTP.Server.Debug.Disable = tt.disable
+			cfg.HTTP.Server.Debug.DisabledTenants = tt.disabledTenants
+
+			got := newDebugPolicy(cfg, nil).debugging(tt.tenant, tt.input)
+			test.Equal(t, got.Enable, tt.expected)
+		})
+	}
+}
+
+func TestMakeQueryResponseRedactsDebugging(t *testing.T) {
+	cfg := &conf.Config{}
+	cfg.Redaction.Headers = []string{"Authorization", "Set-Cookie"}
+	cfg.Redaction.Params = []string{"cpf"}
+	redactor, err := redact.New(cfg)
+	test.VerifyError(t, err)
+
+	result := domain.Resources{
+		"hero": restql.DoneResource{
+			Status:          200,
+			Success:         true,
+			URL:             "http://hero.io/api?cpf=12345678900&id=1",
+			RequestHeaders:  map[string]string{"Authorization": "Bearer abcdefgh", "X-Tid": "1234567890"},
+			ResponseHeaders: map[string]string{"Set-Cookie": "session=secret"},
+			RequestParams:   map[string]interface{}{"cpf": "12345678900", "id": "1"},
+			ResponseBody:    restql.NewResponseBodyFromValue(test.NoOpLogger, nil),
+		},
+	}
+
+	response, err := MakeQueryResponse(result, Debugging{Enable: true, Redactor: redactor})
+	test.VerifyError(t, err)
+
+	expected := &StatementDebugging{
+		URL:             "http://hero.io/api?cpf=%5BREDACTED%5D&id=1",
+		RequestHeaders:  map[string]string{"Authorization": redact.Mask, "X-Tid": "1234567890"},
+		ResponseHeaders: map[string]string{"Set-Cookie": redact.Mask},
+		Params:          map[string]interface{}{"cpf": redact.Mask, "id": "1"},
+	}
+	test.Equal(t, response.Body["hero"].Details.(StatementDetails).Debug, expected)
+}
@@ -0,0 +1,391 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
+	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web/middleware"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/pkg/errors"
+	"github.com/valyala/fasthttp"
+	"golang.org/x/sync/singleflight"
+)
+
+// graphqlSchemaRetryInterval is the time waited before building
+// the schema again after a failure.
+const graphqlSchemaRetryInterval = 5 * time.Second
+
+var (
+	errNoGraphQLFields      = errors.New("no saved query available to build the graphql schema")
+	errInvalidGraphQLQuery  = errors.New("invalid graphql request : no query provided")
+	errTooManyGraphQLFields = errors.New("invalid graphql request : too many root fields")
+	invalidGraphQLNameChars = regexp.MustCompile(`[^_0-9A-Za-z]`)
+)
+
+// jsonScalar represents any JSON value, being used both for
+// the saved queries arguments and results, since they are not typed.
+var jsonScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "Any JSON value.",
+	Serialize: func(value interface{}) interface{} {
+		return value
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return value
+	},
+	ParseLiteral: parseJSONLiteral,
+})
+
+type savedQueryEvaluator interface {
+	SavedQuery(ctx context.Context, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (domain.Resources, error)
+}
+
+type savedQueryLister interface {
+	ListNamespaces(ctx context.Context) ([]string, error)
+	ListQueriesForNamespace(ctx context.Context, namespace string, archived bool) ([]restql.SavedQuery, error)
+}
+
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+type graphqlExecutionKey struct{}
+
+type graphqlExecution struct {
+	tenant  string
+	headers map[string]string
+}
+
+// graphqlAPI exposes the saved queries as root fields of a GraphQL
+// schema, named after their namespace, id and revision, with their
+// variables as arguments. The schema is rebuilt periodically to
+// include new saved queries. As each root field evaluates a saved
+// query, their number in an operation is limited like a batch.
+type graphqlAPI struct {
+	log             restql.Logger
+	envTenant       string
+	evaluator       savedQueryEvaluator
+	parser          parser.Parser
+	queries         savedQueryLister
+	refreshInterval time.Duration
+	maxRootFields   int
+
+	builds singleflight.Group
+
+	mu        sync.RWMutex
+	schema    *graphql.Schema
+	err       error
+	nextBuild time.Time
+}
+
+func newGraphQLAPI(log restql.Logger, envTenant string, e savedQueryEvaluator, p parser.Parser, queries savedQueryLister, refreshInterval time.Duration, maxRootFields int) *graphqlAPI {
+	return &graphqlAPI{log: log, envTenant: envTenant, evaluator: e, parser: p, queries: queries, refreshInterval: refreshInterval, maxRootFields: maxRootFields}
+}
+
+func (g *graphqlAPI) Query(reqCtx *fasthttp.RequestCtx) error {
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, g.log)
+
+	tenant, err := makeTenant(reqCtx, g.envTenant)
+	if err != nil {
+		g.log.Error("failed to build query options", err)
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	request, err := makeGraphQLRequest(reqCtx, g.maxRootFields)
+	if err != nil {
+		g.log.Error("failed to build graphql request", err)
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	schema, err := g.Schema(ctx)
+	if err != nil {
+		g.log.Error("failed to build graphql schema", err)
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	ctx = context.WithValue(ctx, graphqlExecutionKey{}, graphqlExecution{tenant: tenant, headers: makeRequestHeaders(reqCtx)})
+	result := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  request.Query,
+		VariableValues: request.Variables,
+		OperationName:  request.OperationName,
+		Context:        ctx,
+	})
+
+	return Respond(reqCtx, result, http.StatusOK, nil)
+}
+
+// Schema returns the current schema, building it when it is
+// older than the refresh interval. The build runs outside the lock
+// and is shared by concurrent requests. If it fails the previous
+// schema is kept, or the failure is returned when there is none,
+// and no new build is attempted until the retry interval elapses.
+func (g *graphqlAPI) Schema(ctx context.Context) (graphql.Schema, error) {
+	g.mu.RLock()
+	schema, err, nextBuild := g.schema, g.err, g.nextBuild
+	g.mu.RUnlock()
+
+	if time.Now().Before(nextBuild) {
+		return currentSchema(schema, err)
+	}
+
+	v, err, _ := g.builds.Do("schema", func() (interface{}, error) {
+		return g.rebuild(domain.DetachContext(ctx))
+	})
+	if err != nil {
+		return graphql.Schema{}, err
+	}
+
+	return *v.(*graphql.Schema), nil
+}
+
+func (g *graphqlAPI) rebuild(ctx context.Context) (*graphql.Schema, error) {
+	schema, err := g.buildSchema(ctx)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err != nil {
+		g.nextBuild = time.Now().Add(g.retryInterval())
+		if g.schema != nil {
+			g.log.Error("failed to rebuild graphql schema, keeping the previous one", err)
+			return g.schema, nil
+		}
+
+		g.err = err
+		return nil, err
+	}
+
+	g.schema = &schema
+	g.err = nil
+	g.nextBuild = time.Now().Add(g.refreshInterval)
+
+	return g.schema, nil
+}
+
+func (g *graphqlAPI) retryInterval() time.Duration {
+	if g.refreshInterval < graphqlSchemaRetryInterval {
+		return g.refreshInterval
+	}
+
+	return graphqlSchemaRetryInterval
+}
+
+func currentSchema(schema *graphql.Schema, err error) (graphql.Schema, error) {
+	if schema == nil {
+		return graphql.Schema{}, err
+	}
+
+	return *schema, nil
+}
+
+func (g *graphqlAPI) buildSchema(ctx context.Context) (graphql.Schema, error) {
+	namespaces, err := g.queries.ListNamespaces(ctx)
+	if err != nil {
+		return graphql.Schema{}, err
+	}
+	sort.Strings(namespaces)
+
+	fields := graphql.Fields{}
+	for _, namespace := range namespaces {
+		savedQueries, err := g.queries.ListQueriesForNamespace(ctx, namespace, false)
+		if err != nil {
+			g.log.Error("failed to list queries for graphql schema", err, "namespace", namespace)
+			continue
+		}
+
+		for _, savedQuery := range savedQueries {
+			if savedQuery.Archived {
+				continue
+			}
+
+			for _, revision := range savedQuery.Revisions {
+				if revision.Archived || revision.Text == "" {
+					continue
+				}
+
+				name := graphqlName(fmt.Sprintf("%s_%s_v%d", namespace, savedQuery.Name, revision.Revision))
+				if _, found := fields[name]; found {
+					g.log.Warn("duplicated graphql field name, ignoring query", "field", name, "namespace", namespace, "name", savedQuery.Name, "revision", revision.Revision)
+					continue
+				}
+
+				query, err := g.parser.Parse(revision.Text)
+				if err != nil {
+					g.log.Error("failed to parse query for graphql schema", err, "namespace", namespace, "name", savedQuery.Name, "revision", revision.Revision)
+					continue
+				}
+
+				options := restql.QueryOptions{Namespace: namespace, Id: savedQuery.Name, Revision: revision.Revision}
+				fields[name] = g.makeField(options, eval.QueryVariables(query))
+			}
+		}
+	}
+
+	if len(fields) == 0 {
+		return graphql.Schema{}, errNoGraphQLFields
+	}
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: fields}),
+	})
+}
+
+// makeField creates a root field that evaluates the saved query, returning
+// the same body of the /run-query endpoint. The evaluation starts as soon
+// as the field is resolved, so multiple root fields run concurrently.
+func (g *graphqlAPI) makeField(options restql.QueryOptions, variables []string) *graphql.Field {
+	args := graphql.FieldConfigArgument{}
+	argToVariable := make(map[string]string)
+	for _, variable := range variables {
+		name := graphqlName(variable)
+		if _, found := args[name]; found {
+			continue
+		}
+
+		args[name] = &graphql.ArgumentConfig{Type: jsonScalar}
+		argToVariable[name] = variable
+	}
+
+	return &graphql.Field{
+		Type:        jsonScalar,
+		Description: fmt.Sprintf("Saved query %s/%s/%d", options.Namespace, options.Id, options.Revision),
+		Args:        args,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			execution, _ := p.Context.Value(graphqlExecutionKey{}).(graphqlExecution)
+
+			params := make(map[string]interface{}, len(p.Args))
+			for name, value := range p.Args {
+				params[argToVariable[name]] = value
+			}
+
+			opts := options
+			opts.Tenant = execution.tenant
+			input := restql.QueryInput{Params: params, Headers: execution.headers}
+
+			type fieldResult struct {
+				data interface{}
+				err  error
+			}
+
+			resultCh := make(chan fieldResult, 1)
+			go func() {
+				result, err := g.evaluator.SavedQuery(p.Context, opts, input)
+				if err != nil {
+					resultCh <- fieldResult{err: err}
+					return
+				}
+
+				response, err := MakeQueryResponse(result, Debugging{})
+				resultCh <- fieldResult{data: response.Body, err: err}
+			}()
+
+			return func() (interface{}, error) {
+				r := <-resultCh
+				return r.data, r.err
+			}, nil
+		},
+	}
+}
+
+// makeGraphQLRequest reads the operation from the JSON body on POST
+// requests and from the query parameters on GET requests, rejecting
+// operations with more root fields than the maximum, if greater than
+// zero. Invalid documents are left for the GraphQL execution to report.
+func makeGraphQLRequest(ctx *fasthttp.RequestCtx, maxRootFields int) (graphqlRequest, error) {
+	var request graphqlRequest
+
+	if ctx.IsPost() {
+		err := json.Unmarshal(ctx.PostBody(), &request)
+		if err != nil {
+			return graphqlRequest{}, fmt.Errorf("%w: %s", errFailedToReadRequestBody, err)
+		}
+	} else {
+		args := ctx.QueryArgs()
+		request.Query = string(args.Peek("query"))
+		request.OperationName = string(args.Peek("operationName"))
+
+		if variables := args.Peek("variables"); len(variables) > 0 {
+			err := json.Unmarshal(variables, &request.Variables)
+			if err != nil {
+				return graphqlRequest{}, fmt.Errorf("%w: %s", errFailedToReadRequestBody, err)
+			}
+		}
+	}
+
+	if request.Query == "" {
+		return graphqlRequest{}, errInvalidGraphQLQuery
+	}
+
+	if maxRootFields > 0 {
+		count, err := middleware.GraphQLRootFields(request.Query, request.OperationName)
+		if err == nil && count > maxRootFields {
+			return graphqlRequest{}, fmt.Errorf("%w: %d root fields, the maximum is %d", errTooManyGraphQLFields, count, maxRootFields)
+		}
+	}
+
+	return request, nil
+}
+
+// graphqlName replaces the characters not allowed in GraphQL
+// names, which must match /[_A-Za-z][_0-9A-Za-z]*/.
+func graphqlName(s string) string {
+	name := invalidGraphQLNameChars.ReplaceAllString(s, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+
+	return name
+}
+
+func parseJSONLiteral(value ast.Value) interface{} {
+	switch value := value.(type) {
+	case *ast.StringValue:
+		return value.Value
+	case *ast.EnumValue:
+		return value.Value
+	case *ast.BooleanValue:
+		return value.Value
+	case *ast.IntValue:
+		i, err := strconv.Atoi(value.Value)
+		if err != nil {
+			return value.Value
+		}
+		return i
+	case *ast.FloatValue:
+		f, err := strconv.ParseFloat(value.Value, 64)
+		if err != nil {
+			return value.Value
+		}
+		return f
+	case *ast.ListValue:
+		list := make([]interface{}, len(value.Values))
+		for i, v := range value.Values {
+			list[i] = parseJSONLiteral(v)
+		}
+		return list
+	case *ast.ObjectValue:
+		object := make(map[string]interface{}, len(value.Fields))
+		for _, f := range value.Fields {
+			object[f.Name.Value] = parseJSONLiteral(f.Value)
+		}
+		return object
+	default:
+		return nil
+	}
+}
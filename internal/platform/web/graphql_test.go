@@ -0,0 +1,221 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/graphql-go/graphql"
+	"github.com/valyala/fasthttp"
+)
+
+func TestGraphQLAPI(t *testing.T) {
+	p, err := parser.New()
+	test.VerifyError(t, err)
+
+	queries := stubSavedQueryLister{queries: map[string][]restql.SavedQuery{
+		"hero-catalog": {
+			{Name: "fetch-hero", Revisions: []restql.SavedQueryRevision{
+				{Revision: 1, Text: "from hero with id = $id"},
+				{Revision: 2, Text: "from hero with id = $id, universe = $universe", Archived: true},
+			}},
+		},
+		"sidekicks": {
+			{Name: "all", Revisions: []restql.SavedQueryRevision{{Revision: 1, Text: "from sidekick"}}},
+			{Name: "broken", Revisions: []restql.SavedQueryRevision{{Revision: 1, Text: "form sidekick"}}},
+		},
+	}}
+	evaluator := &stubSavedQueryEvaluator{}
+
+	gql := newGraphQLAPI(test.NoOpLogger, "", evaluator, p, queries, time.Minute, 20)
+
+	schema, err := gql.Schema(context.Background())
+	test.VerifyError(t, err)
+
+	t.Run("should create a field for each valid revision", func(t *testing.T) {
+		fields := schema.QueryType().Fields()
+
+		test.Equal(t, len(fields), 2)
+		test.Equal(t, len(fields["hero_catalog_fetch_hero_v1"].Args), 1)
+		test.Equal(t, fields["hero_catalog_fetch_hero_v1"].Args[0].Name(), "id")
+		test.Equal(t, len(fields["sidekicks_all_v1"].Args), 0)
+	})
+
+	t.Run("should evaluate saved queries with arguments as parameters", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), graphqlExecutionKey{}, graphqlExecution{tenant: "dc"})
+		result := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  `query ($id: JSON) { hero: hero_catalog_fetch_hero_v1(id: $id) sidekicks_all_v1 }`,
+			VariableValues: map[string]interface{}{"id": "1"},
+			Context:        ctx,
+		})
+		if len(result.Errors) > 0 {
+			t.Fatalf("unexpected errors: %v", result.Errors)
+		}
+
+		data, err := json.Marshal(result.Data)
+		test.VerifyError(t, err)
+
+		expected := `{"hero":{"hero":{"details":{"status":200,"success":true,"metadata":{}},"result":{"id":"1"}}},"sidekicks_all_v1":{"sidekick":{"details":{"status":200,"success":true,"metadata":{}},"result":{}}}}`
+		test.Equal(t, string(data), expected)
+		test.Equal(t, evaluator.tenants(), []string{"dc", "dc"})
+	})
+}
+
+func TestGraphQLAPISchemaRebuild(t *testing.T) {
+	p, err := parser.New()
+	test.VerifyError(t, err)
+
+	t.Run("should keep the previous schema when the rebuild fails", func(t *testing.T) {
+		queries := &flakySavedQueryLister{stubSavedQueryLister: stubSavedQueryLister{queries: map[string][]restql.SavedQuery{
+			"sidekicks": {{Name: "all", Revisions: []restql.SavedQueryRevision{{Revision: 1, Text: "from sidekick"}}}},
+		}}}
+		gql := newGraphQLAPI(test.NoOpLogger, "", &stubSavedQueryEvaluator{}, p, queries, 0, 20)
+
+		_, err := gql.Schema(context.Background())
+		test.VerifyError(t, err)
+
+		queries.fail(errors.New("database unavailable"))
+		schema, err := gql.Schema(context.Background())
+		test.VerifyError(t, err)
+
+		test.Equal(t, len(schema.QueryType().Fields()), 1)
+		test.Equal(t, queries.count(), 2)
+	})
+
+	t.Run("should not retry a failed build before the retry interval", func(t *testing.T) {
+		queries := &flakySavedQueryLister{}
+		queries.fail(errors.New("database unavailable"))
+		gql := newGraphQLAPI(test.NoOpLogger, "", &stubSavedQueryEvaluator{}, p, queries, time.Minute, 20)
+
+		for i := 0; i < 3; i++ {
+			_, err := gql.Schema(context.Background())
+			if !errors.Is(err, queries.err) {
+				t.Fatalf("expected error %v, got %v", queries.err, err)
+			}
+		}
+
+		test.Equal(t, queries.count(), 1)
+	})
+}
+
+func TestMakeGraphQLRequest(t *testing.T) {
+	t.Run("should reject operations with more root fields than the maximum", func(t *testing.T) {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.Header.SetMethod(fasthttp.MethodPost)
+		ctx.Request.SetBodyString(`{"query": "{ a: hero_v1 b: hero_v1 c: hero_v1 }"}`)
+
+		_, err := makeGraphQLRequest(ctx, 2)
+		test.Equal(t, errors.Is(err, errTooManyGraphQLFields), true)
+
+		request, err := makeGraphQLRequest(ctx, 3)
+		test.VerifyError(t, err)
+		test.Equal(t, request.Query, "{ a: hero_v1 b: hero_v1 c: hero_v1 }")
+	})
+}
+
+func TestGraphQLName(t *testing.T) {
+	test.Equal(t, graphqlName("hero-catalog_fetch.hero_v1"), "hero_catalog_fetch_hero_v1")
+	test.Equal(t, graphqlName("1st-catalog"), "_1st_catalog")
+}
+
+type stubSavedQueryLister struct {
+	queries map[string][]restql.SavedQuery
+}
+
+func (s stubSavedQueryLister) ListNamespaces(ctx context.Context) ([]string, error) {
+	var namespaces []string
+	for namespace := range s.queries {
+		namespaces = append(namespaces, namespace)
+	}
+
+	return namespaces, nil
+}
+
+func (s stubSavedQueryLister) ListQueriesForNamespace(ctx context.Context, namespace string, archived bool) ([]restql.SavedQuery, error) {
+	return s.queries[namespace], nil
+}
+
+type flakySavedQueryLister struct {
+	stubSavedQueryLister
+
+	mu    sync.Mutex
+	calls int
+	err   error
+}
+
+func (f *flakySavedQueryLister) fail(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.err = err
+}
+
+func (f *flakySavedQueryLister) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.calls
+}
+
+func (f *flakySavedQueryLister) ListNamespaces(ctx context.Context) ([]string, error) {
+	f.mu.Lock()
+	f.calls++
+	err := f.err
+	f.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return f.stubSavedQueryLister.ListNamespaces(ctx)
+}
+
+type stubSavedQueryEvaluator struct {
+	mu      sync.Mutex
+	options []restql.QueryOptions
+}
+
+func (s *stubSavedQueryEvaluator) record(options restql.QueryOptions) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.options = append(s.options, options)
+}
+
+func (s *stubSavedQueryEvaluator) tenants() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tenants := make([]string, len(s.options))
+	for i, o := range s.options {
+		tenants[i] = o.Tenant
+	}
+
+	return tenants
+}
+
+func (s *stubSavedQueryEvaluator) SavedQuery(ctx context.Context, queryOpts restql.QueryOptions, queryInput restql.QueryInput) (domain.Resources, error) {
+	s.record(queryOpts)
+
+	body := map[string]interface{}{}
+	if id, found := queryInput.Params["id"]; found {
+		body["id"] = id
+	}
+
+	resource := "sidekick"
+	if queryOpts.Id == "fetch-hero" {
+		resource = "hero"
+	}
+
+	return domain.Resources{
+		domain.ResourceID(resource): restql.DoneResource{Status: 200, Success: true, ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, body)},
+	}, nil
+}
@@ -1,16 +1,40 @@
 package web
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
 	"github.com/valyala/fasthttp"
 )
 
+// Readiness check statuses
+const (
+	checkUp      = "up"
+	checkDown    = "down"
+	checkSkipped = "skipped"
+)
+
+// errCheckSkipped is returned by readiness checks
+// that cannot be verified in the current setup.
+var errCheckSkipped = errors.New("check skipped")
+
 type check struct {
-	build string
+	log       restql.Logger
+	build     string
+	readiness Readiness
 }
 
-func newCheck(build string) check {
-	return check{build: build}
+func newCheck(log restql.Logger, build string, readiness Readiness) check {
+	return check{log: log, build: build, readiness: readiness}
 }
 
 func (c check) Health(ctx *fasthttp.RequestCtx) error {
@@ -22,3 +46,193 @@ func (c check) ResourceStatus(ctx *fasthttp.RequestCtx) error {
 	ctx.Response.SetBodyString(fmt.Sprintf("RestQL is running with build %s", c.build))
 	return nil
 }
+
+// Live tells whether restQL is running.
+func (c check) Live(ctx *fasthttp.RequestCtx) error {
+	return Respond(ctx, ReadinessReport{Status: checkUp}, http.StatusOK, nil)
+}
+
+// Ready tells whether restQL is able to run queries,
+// responding with 503 when any check fails.
+func (c check) Ready(reqCtx *fasthttp.RequestCtx) error {
+	ctx := restql.WithLogger(context.Background(), c.log)
+	report := c.readiness.Run(ctx)
+
+	status := http.StatusOK
+	if report.Status != checkUp {
+		c.log.Warn("readiness check failed", "checks", report.Checks)
+		status = http.StatusServiceUnavailable
+	}
+
+	return Respond(reqCtx, report, status, map[string]string{"Cache-Control": "no-cache"})
+}
+
+// ReadinessReport is the result of all readiness checks.
+type ReadinessReport struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks,omitempty"`
+}
+
+// CheckResult is the result of a single readiness check.
+type CheckResult struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Latency string `json:"latency"`
+	Error   string `json:"error,omitempty"`
+}
+
+type readinessCheck struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// Readiness verifies the dependencies restQL needs to run queries.
+// All checks run concurrently and must finish within the timeout.
+type Readiness struct {
+	timeout time.Duration
+	checks  []readinessCheck
+}
+
+func newReadiness(timeout time.Duration, checks ...readinessCheck) Readiness {
+	return Readiness{timeout: timeout, checks: checks}
+}
+
+// Run executes all checks, the report status being down if any is down.
+func (r Readiness) Run(ctx context.Context) ReadinessReport {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	results := make([]CheckResult, len(r.checks))
+
+	var wg sync.WaitGroup
+	wg.Add(len(r.checks))
+	for i, c := range r.checks {
+		i, c := i, c
+		go func() {
+			defer wg.Done()
+			results[i] = runCheck(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+
+	report := ReadinessReport{Status: checkUp, Checks: results}
+	for _, result := range results {
+		if result.Status == checkDown {
+			report.Status = checkDown
+		}
+	}
+
+	return report
+}
+
+// runCheck executes the check, considering
+// it down if the context is done first.
+func runCheck(ctx context.Context, c readinessCheck) CheckResult {
+	start := time.Now()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- c.run(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := CheckResult{Name: c.name, Status: checkUp, Latency: time.Since(start).String()}
+	switch {
+	case errors.Is(err, errCheckSkipped):
+		result.Status = checkSkipped
+	case err != nil:
+		result.Status = checkDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+type mappingsLoader interface {
+	FromTenant(ctx context.Context, tenant string) (map[string]restql.Mapping, error)
+}
+
+func databaseCheck(db persistence.Database) readinessCheck {
+	return readinessCheck{
+		name: "database",
+		run: func(ctx context.Context) error {
+			err := persistence.PingDatabase(ctx, db)
+			if errors.Is(err, persistence.ErrPingNotSupported) {
+				return errCheckSkipped
+			}
+
+			return err
+		},
+	}
+}
+
+func mappingsCheck(loader mappingsLoader, tenant string) readinessCheck {
+	return readinessCheck{
+		name: "mappings:" + tenant,
+		run: func(ctx context.Context) error {
+			_, err := loader.FromTenant(ctx, tenant)
+			return err
+		},
+	}
+}
+
+// upstreamCheck calls the upstream through the same client used by
+// queries, so that its TLS, proxy, egress and discovery settings apply.
+func upstreamCheck(client domain.HTTPClient, name string, rawURL string) readinessCheck {
+	return readinessCheck{
+		name: "upstream:" + name,
+		run: func(ctx context.Context) error {
+			u, err := url.Parse(rawURL)
+			if err != nil {
+				return errors.Wrap(err, "invalid upstream url")
+			}
+
+			query := make(map[string]interface{})
+			for key, values := range u.Query() {
+				list := make([]interface{}, len(values))
+				for i, v := range values {
+					list[i] = v
+				}
+				query[key] = list
+			}
+
+			timeout := time.Second
+			if deadline, ok := ctx.Deadline(); ok {
+				timeout = time.Until(deadline)
+			}
+
+			request := restql.HTTPRequest{
+				Method:  http.MethodGet,
+				Schema:  u.Scheme,
+				Host:    u.Host,
+				Path:    u.EscapedPath(),
+				Query:   query,
+				Timeout: timeout,
+			}
+
+			response, err := client.Do(ctx, request)
+			if err != nil {
+				return err
+			}
+
+			if response.StatusCode >= http.StatusInternalServerError {
+				return errors.Errorf("upstream responded with status %d", response.StatusCode)
+			}
+
+			return nil
+		},
+	}
+}
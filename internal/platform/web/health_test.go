@@ -0,0 +1,180 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+func TestReadinessRun(t *testing.T) {
+	up := readinessCheck{name: "up", run: func(ctx context.Context) error { return nil }}
+	down := readinessCheck{name: "down", run: func(ctx context.Context) error { return errors.New("broken") }}
+	skipped := readinessCheck{name: "skipped", run: func(ctx context.Context) error { return errCheckSkipped }}
+	slow := readinessCheck{name: "slow", run: func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}}
+
+	tests := []struct {
+		name           string
+		checks         []readinessCheck
+		expectedStatus string
+		expectedChecks map[string]string
+	}{
+		{
+			name:           "no checks",
+			expectedStatus: checkUp,
+			expectedChecks: map[string]string{},
+		},
+		{
+			name:           "all checks up or skipped",
+			checks:         []readinessCheck{up, skipped},
+			expectedStatus: checkUp,
+			expectedChecks: map[string]string{"up": checkUp, "skipped": checkSkipped},
+		},
+		{
+			name:           "one check down",
+			checks:         []readinessCheck{up, down},
+			expectedStatus: checkDown,
+			expectedChecks: map[string]string{"up": checkUp, "down": checkDown},
+		},
+		{
+			name:           "check exceeding timeout",
+			checks:         []readinessCheck{up, slow},
+			expectedStatus: checkDown,
+			expectedChecks: map[string]string{"up": checkUp, "slow": checkDown},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := newReadiness(50*time.Millisecond, tt.checks...).Run(context.Background())
+
+			checks := make(map[string]string)
+			for _, c := range report.Checks {
+				checks[c.Name] = c.Status
+			}
+
+			test.Equal(t, report.Status, tt.expectedStatus)
+			test.Equal(t, checks, tt.expectedChecks)
+		})
+	}
+}
+
+func TestDatabaseCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		db       persistence.Database
+		expected string
+	}{
+		{name: "database without ping", db: stubDatabase{}, expected: checkSkipped},
+		{name: "database responding ping", db: pingableDatabase{}, expected: checkUp},
+		{name: "database failing ping", db: pingableDatabase{err: restql.ErrDatabaseCommunicationFailed}, expected: checkDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := runCheck(context.Background(), databaseCheck(tt.db))
+
+			test.Equal(t, result.Status, tt.expected)
+		})
+	}
+}
+
+func TestUpstreamCheck(t *testing.T) {
+	client := &stubHTTPClient{responses: map[string]restql.HTTPResponse{
+		"/ok":     {StatusCode: http.StatusOK},
+		"/broken": {StatusCode: http.StatusBadGateway},
+	}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	test.Equal(t, runCheck(ctx, upstreamCheck(client, "ok", "https://hero.api/ok?full=true")).Status, checkUp)
+	test.Equal(t, runCheck(ctx, upstreamCheck(client, "broken", "https://hero.api/broken")).Status, checkDown)
+	test.Equal(t, runCheck(ctx, upstreamCheck(client, "unreachable", "https://hero.api/unreachable")).Status, checkDown)
+
+	request := client.requests[0]
+	test.Equal(t, request.Method, http.MethodGet)
+	test.Equal(t, request.Schema, "https")
+	test.Equal(t, request.Host, "hero.api")
+	test.Equal(t, request.Path, "/ok")
+	test.Equal(t, request.Query, map[string]interface{}{"full": []interface{}{"true"}})
+	test.Equal(t, request.Timeout > 0, true)
+}
+
+func TestCheckReady(t *testing.T) {
+	tests := []struct {
+		name           string
+		checks         []readinessCheck
+		expectedStatus int
+	}{
+		{
+			name:           "ready",
+			checks:         []readinessCheck{{name: "up", run: func(ctx context.Context) error { return nil }}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "not ready",
+			checks:         []readinessCheck{{name: "down", run: func(ctx context.Context) error { return errors.New("broken") }}},
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCheck(test.NoOpLogger, "test", newReadiness(time.Second, tt.checks...))
+			ctx := &fasthttp.RequestCtx{}
+
+			err := c.Ready(ctx)
+			test.VerifyError(t, err)
+
+			var report ReadinessReport
+			test.VerifyError(t, json.Unmarshal(ctx.Response.Body(), &report))
+
+			test.Equal(t, ctx.Response.StatusCode(), tt.expectedStatus)
+			test.Equal(t, len(report.Checks), 1)
+		})
+	}
+}
+
+type stubDatabase struct {
+	persistence.Database
+}
+
+type pingableDatabase struct {
+	persistence.Database
+	err error
+}
+
+func (p pingableDatabase) Ping(ctx context.Context) error {
+	return p.err
+}
+
+type stubHTTPClient struct {
+	mu        sync.Mutex
+	requests  []restql.HTTPRequest
+	responses map[string]restql.HTTPResponse
+}
+
+func (s *stubHTTPClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	s.mu.Lock()
+	s.requests = append(s.requests, request)
+	s.mu.Unlock()
+
+	response, found := s.responses[request.Path]
+	if !found {
+		return restql.HTTPResponse{}, errors.New("connection refused")
+	}
+
+	return response, nil
+}
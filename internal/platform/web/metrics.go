@@ -0,0 +1,20 @@
+package web
+
+import (
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/metrics"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+)
+
+type metricsExporter struct {
+	handler fasthttp.RequestHandler
+}
+
+func newMetricsExporter() metricsExporter {
+	return metricsExporter{handler: fasthttpadaptor.NewFastHTTPHandler(metrics.Handler())}
+}
+
+func (m metricsExporter) Metrics(ctx *fasthttp.RequestCtx) error {
+	m.handler(ctx)
+	return nil
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/accesslog"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+)
+
+type accessLogOptions struct {
+	Enable             bool
+	SlowQueryEnable    bool
+	SlowQueryThreshold time.Duration
+	RequestIDHeader    string
+}
+
+type accessLog struct {
+	log     restql.Logger
+	options accessLogOptions
+}
+
+func newAccessLog(log restql.Logger, options accessLogOptions) Middleware {
+	return accessLog{log: log, options: options}
+}
+
+// Apply logs a single entry for each transaction and, when it takes
+// longer than the threshold, another one with the statements timeline.
+// For streamed responses, the entries are logged once the body is written.
+func (a accessLog) Apply(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		entry := accesslog.NewEntry()
+		WithNativeContext(ctx, accesslog.WithEntry(GetNativeContext(ctx), entry))
+
+		h(ctx)
+
+		// FastHTTP resets the request before writing a streamed
+		// body, hence its information is taken right away.
+		req := a.makeRequestInfo(ctx)
+		afterResponse(ctx, func() {
+			a.write(ctx, entry, req)
+		})
+	}
+}
+
+type requestInfo struct {
+	method    string
+	route     string
+	path      string
+	requestID string
+}
+
+func (a accessLog) makeRequestInfo(ctx *fasthttp.RequestCtx) requestInfo {
+	route, _ := ctx.UserValue(router.MatchedRoutePathParam).(string)
+
+	req := requestInfo{
+		method: string(ctx.Method()),
+		route:  route,
+		path:   string(ctx.Path()),
+	}
+
+	if a.options.RequestIDHeader != "" {
+		req.requestID = string(ctx.Request.Header.Peek(a.options.RequestIDHeader))
+	}
+
+	return req
+}
+
+func (a accessLog) write(ctx *fasthttp.RequestCtx, entry *accesslog.Entry, req requestInfo) {
+	duration := entry.Duration()
+	fields := a.makeFields(ctx, entry, req, duration)
+
+	if a.options.Enable {
+		a.log.Info("access log", fields...)
+	}
+
+	if a.options.SlowQueryEnable && duration >= a.options.SlowQueryThreshold {
+		fields = append(fields, "timeline", makeTimeline(entry.Statements()))
+		a.log.Warn("slow query", fields...)
+	}
+}
+
+func (a accessLog) makeFields(ctx *fasthttp.RequestCtx, entry *accesslog.Entry, req requestInfo, duration time.Duration) []interface{} {
+	fields := []interface{}{
+		"method", req.method,
+		"route", req.route,
+		"path", req.path,
+		"status", ctx.Response.StatusCode(),
+		"duration-ms", duration.Milliseconds(),
+		"statements", len(entry.Statements()),
+		"upstream-failures", entry.UpstreamFailures(),
+	}
+
+	if options, ok := entry.Query(); ok {
+		fields = append(fields,
+			"namespace", options.Namespace,
+			"query", options.Id,
+			"revision", options.Revision,
+			"tenant", options.Tenant,
+		)
+	}
+
+	if a.options.RequestIDHeader != "" {
+		fields = append(fields, "request-id", req.requestID)
+	}
+
+	return fields
+}
+
+func makeTimeline(statements []accesslog.Statement) []map[string]interface{} {
+	timeline := make([]map[string]interface{}, len(statements))
+	for i, s := range statements {
+		timeline[i] = map[string]interface{}{
+			"resource":    s.Resource,
+			"method":      s.Method,
+			"status":      s.Status,
+			"success":     s.Success,
+			"start-ms":    s.Offset.Milliseconds(),
+			"duration-ms": s.Duration.Milliseconds(),
+		}
+	}
+
+	return timeline
+}
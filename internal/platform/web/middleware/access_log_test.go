@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"bufio"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/accesslog"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+func TestAccessLog(t *testing.T) {
+	queryHandler := func(delay time.Duration) fasthttp.RequestHandler {
+		return func(ctx *fasthttp.RequestCtx) {
+			entry := accesslog.FromContext(GetNativeContext(ctx))
+			entry.SetQuery(restql.QueryOptions{Namespace: "ns", Id: "heroes", Revision: 1, Tenant: "DC"})
+			entry.AddStatement("hero", "from", 200, true, time.Now(), time.Millisecond)
+			entry.AddStatement("sidekick", "from", 500, false, time.Now(), time.Millisecond)
+
+			time.Sleep(delay)
+			ctx.SetStatusCode(200)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		options         accessLogOptions
+		delay           time.Duration
+		expectedMessage []string
+	}{
+		{
+			name:            "access log only",
+			options:         accessLogOptions{Enable: true, RequestIDHeader: "X-Request-Id"},
+			expectedMessage: []string{"access log"},
+		},
+		{
+			name:            "slow query log for query slower than threshold",
+			options:         accessLogOptions{SlowQueryEnable: true, SlowQueryThreshold: 5 * time.Millisecond},
+			delay:           10 * time.Millisecond,
+			expectedMessage: []string{"slow query"},
+		},
+		{
+			name:            "no slow query log for query faster than threshold",
+			options:         accessLogOptions{Enable: true, SlowQueryEnable: true, SlowQueryThreshold: time.Minute},
+			expectedMessage: []string{"access log"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := &recordingLogger{}
+
+			ctx := &fasthttp.RequestCtx{}
+			ctx.Request.Header.SetMethod("GET")
+			ctx.Request.SetRequestURI("/run-query/ns/heroes/1")
+			ctx.Request.Header.Set("X-Request-Id", "123")
+			WithNativeContext(ctx, context.Background())
+
+			newAccessLog(log, tt.options).Apply(queryHandler(tt.delay))(ctx)
+
+			test.Equal(t, log.messages, tt.expectedMessage)
+
+			fields := log.fields[0]
+			test.Equal(t, fields["method"], "GET")
+			test.Equal(t, fields["path"], "/run-query/ns/heroes/1")
+			test.Equal(t, fields["status"], 200)
+			test.Equal(t, fields["namespace"], "ns")
+			test.Equal(t, fields["query"], "heroes")
+			test.Equal(t, fields["revision"], 1)
+			test.Equal(t, fields["tenant"], "DC")
+			test.Equal(t, fields["statements"], 2)
+			test.Equal(t, fields["upstream-failures"], 1)
+
+			if tt.options.RequestIDHeader != "" {
+				test.Equal(t, fields["request-id"], "123")
+			}
+
+			if tt.options.SlowQueryEnable && tt.expectedMessage[0] == "slow query" {
+				timeline := fields["timeline"].([]map[string]interface{})
+				test.Equal(t, len(timeline), 2)
+				test.Equal(t, timeline[1]["resource"], "sidekick")
+				test.Equal(t, timeline[1]["success"], false)
+			}
+		})
+	}
+}
+
+func TestAccessLogStreamedResponse(t *testing.T) {
+	log := &recordingLogger{}
+
+	handler := func(ctx *fasthttp.RequestCtx) {
+		entry := accesslog.FromContext(GetNativeContext(ctx))
+		completion := NewStreamCompletion(ctx)
+
+		ctx.SetStatusCode(200)
+		ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+			defer completion.Done()
+
+			entry.AddStatement("hero", "from", 200, true, time.Now(), time.Millisecond)
+			w.WriteString("event: done\n\n")
+		})
+	}
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetMethod("GET")
+	ctx.Request.SetRequestURI("/run-query/ns/heroes/1")
+	WithNativeContext(ctx, context.Background())
+
+	newAccessLog(log, accessLogOptions{Enable: true}).Apply(handler)(ctx)
+
+	test.Equal(t, string(ctx.Response.Body()), "event: done\n\n")
+	test.Equal(t, log.messages, []string{"access log"})
+	test.Equal(t, log.fields[0]["path"], "/run-query/ns/heroes/1")
+	test.Equal(t, log.fields[0]["statements"], 1)
+}
+
+type recordingLogger struct {
+	messages []string
+	fields   []map[string]interface{}
+}
+
+func (r *recordingLogger) record(msg string, fields []interface{}) {
+	m := make(map[string]interface{})
+	for i := 0; i+1 < len(fields); i += 2 {
+		m[fields[i].(string)] = fields[i+1]
+	}
+
+	r.messages = append(r.messages, msg)
+	r.fields = append(r.fields, m)
+}
+
+func (r *recordingLogger) Panic(msg string, fields ...interface{})            {}
+func (r *recordingLogger) Fatal(msg string, fields ...interface{})            {}
+func (r *recordingLogger) Error(msg string, err error, fields ...interface{}) {}
+func (r *recordingLogger) Warn(msg string, fields ...interface{})             { r.record(msg, fields) }
+func (r *recordingLogger) Info(msg string, fields ...interface{})             { r.record(msg, fields) }
+func (r *recordingLogger) Debug(msg string, fields ...interface{})            {}
+func (r *recordingLogger) With(key string, value interface{}) restql.Logger   { return r }
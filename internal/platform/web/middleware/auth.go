@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"bytes"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/auth"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/valyala/fasthttp"
+)
+
+var unauthorizedResponse = []byte(`{"error":"unauthorized"}`)
+
+type authentication struct {
+	log           restql.Logger
+	authenticator *auth.Authenticator
+	apiKeyHeader  string
+}
+
+func newAuthentication(log restql.Logger, authenticator *auth.Authenticator, apiKeyHeader string) Middleware {
+	if apiKeyHeader == "" {
+		apiKeyHeader = defaultAPIKeyHeader
+	}
+
+	return authentication{log: log, authenticator: authenticator, apiKeyHeader: apiKeyHeader}
+}
+
+// Apply authenticates every request, except the administrative
+// ones, which have their own authorization code, storing the
+// client identity in the native context.
+func (a authentication) Apply(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		if bytes.HasPrefix(ctx.Path(), adminPathPrefix) {
+			h(ctx)
+			return
+		}
+
+		identity, err := a.authenticator.Authenticate(auth.Credentials{
+			BearerToken: auth.BearerToken(string(ctx.Request.Header.Peek(fasthttp.HeaderAuthorization))),
+			APIKey:      string(ctx.Request.Header.Peek(a.apiKeyHeader)),
+		})
+		if err != nil {
+			a.log.Debug("request authentication failed", "reason", err.Error())
+			respondUnauthorized(ctx)
+			return
+		}
+
+		WithNativeContext(ctx, auth.WithIdentity(GetNativeContext(ctx), identity))
+
+		h(ctx)
+	}
+}
+
+func respondUnauthorized(ctx *fasthttp.RequestCtx) {
+	ctx.Response.Header.Set(fasthttp.HeaderWWWAuthenticate, "Bearer")
+	ctx.SetContentType("application/json; charset=utf-8")
+	ctx.SetStatusCode(fasthttp.StatusUnauthorized)
+	ctx.SetBody(unauthorizedResponse)
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/auth"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+func TestAuthentication(t *testing.T) {
+	authenticator, err := auth.NewAuthenticator(auth.Options{
+		APIKeys: []auth.APIKey{{Name: "mobile", Key: "mobile-key", Queries: []string{"*"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name              string
+		request           func(ctx *fasthttp.RequestCtx)
+		expectedStatus    int
+		expectedPrincipal string
+	}{
+		{
+			name:              "authenticates api key",
+			request:           withHeader("X-Client-Key", "mobile-key"),
+			expectedStatus:    fasthttp.StatusOK,
+			expectedPrincipal: "mobile",
+		},
+		{
+			name:           "rejects unknown api key",
+			request:        withHeader("X-Client-Key", "other-key"),
+			expectedStatus: fasthttp.StatusUnauthorized,
+		},
+		{
+			name:           "rejects request without credentials",
+			request:        withURI("/run-query/dc/heroes/1"),
+			expectedStatus: fasthttp.StatusUnauthorized,
+		},
+		{
+			name:           "does not authenticate admin endpoints",
+			request:        withURI("/admin/tenant"),
+			expectedStatus: fasthttp.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var principal restql.Principal
+			mw := newAuthentication(test.NoOpLogger, authenticator, "X-Client-Key")
+			handler := mw.Apply(func(ctx *fasthttp.RequestCtx) {
+				principal, _ = restql.GetPrincipal(GetNativeContext(ctx))
+			})
+
+			ctx := &fasthttp.RequestCtx{}
+			ctx.Request.SetRequestURI("/run-query")
+			WithNativeContext(ctx, context.Background())
+			tt.request(ctx)
+
+			handler(ctx)
+
+			test.Equal(t, ctx.Response.StatusCode(), tt.expectedStatus)
+			test.Equal(t, principal.Subject, tt.expectedPrincipal)
+			if tt.expectedStatus == fasthttp.StatusUnauthorized {
+				test.Equal(t, string(ctx.Response.Header.Peek("WWW-Authenticate")), "Bearer")
+				test.Equal(t, string(ctx.Response.Body()), `{"error":"unauthorized"}`)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/valyala/fasthttp"
+)
+
+var graphqlPath = []byte("/graphql")
+
+// GraphQLRootFields returns the number of root fields selected by an
+// operation of the document, each one evaluating a saved query. When no
+// operation name is given the first operation is used. Fields selected
+// through fragments are counted where they are spread, and introspection
+// fields are ignored.
+func GraphQLRootFields(query string, operationName string) (int, error) {
+	document, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return 0, err
+	}
+
+	var operation *ast.OperationDefinition
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			if operation == nil && (operationName == "" || (definition.Name != nil && definition.Name.Value == operationName)) {
+				operation = definition
+			}
+		case *ast.FragmentDefinition:
+			if definition.Name != nil {
+				fragments[definition.Name.Value] = definition
+			}
+		}
+	}
+
+	if operation == nil {
+		return 0, nil
+	}
+
+	return countRootFields(operation.SelectionSet, fragments, make(map[string]bool)), nil
+}
+
+func countRootFields(selectionSet *ast.SelectionSet, fragments map[string]*ast.FragmentDefinition, spread map[string]bool) int {
+	if selectionSet == nil {
+		return 0
+	}
+
+	count := 0
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if selection.Name != nil && !strings.HasPrefix(selection.Name.Value, "__") {
+				count++
+			}
+		case *ast.InlineFragment:
+			count += countRootFields(selection.SelectionSet, fragments, spread)
+		case *ast.FragmentSpread:
+			if selection.Name == nil || spread[selection.Name.Value] {
+				continue
+			}
+
+			fragment, found := fragments[selection.Name.Value]
+			if !found {
+				continue
+			}
+
+			// fragments are counted on every spread, but never
+			// within themselves, which GraphQL does not allow
+			spread[selection.Name.Value] = true
+			count += countRootFields(fragment.SelectionSet, fragments, spread)
+			delete(spread, selection.Name.Value)
+		}
+	}
+
+	return count
+}
+
+// graphqlRequestCost returns the number of root fields of the GraphQL
+// request, read from the JSON body on POST requests and from the query
+// parameters otherwise. Invalid requests take one, since they are
+// rejected without evaluating any query.
+func graphqlRequestCost(ctx *fasthttp.RequestCtx) float64 {
+	var request struct {
+		Query         string `json:"query"`
+		OperationName string `json:"operationName"`
+	}
+
+	if ctx.IsPost() {
+		err := json.Unmarshal(ctx.PostBody(), &request)
+		if err != nil {
+			return 1
+		}
+	} else {
+		args := ctx.QueryArgs()
+		request.Query = string(args.Peek("query"))
+		request.OperationName = string(args.Peek("operationName"))
+	}
+
+	count, err := GraphQLRootFields(request.Query, request.OperationName)
+	if err != nil || count == 0 {
+		return 1
+	}
+
+	return float64(count)
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestGraphQLRootFields(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		operationName string
+		expected      int
+	}{
+		{
+			name:     "counts the root fields and aliases",
+			query:    `{ a: hero_v1 b: hero_v1(id: 1) sidekick_v1 }`,
+			expected: 3,
+		},
+		{
+			name:     "ignores nested and introspection fields",
+			query:    `{ __typename hero_v1 __schema { types { name } } }`,
+			expected: 1,
+		},
+		{
+			name:     "counts the fields of fragments where they are spread",
+			query:    `query { ...heroes ... on Query { c: hero_v1 } } fragment heroes on Query { a: hero_v1 b: hero_v1 }`,
+			expected: 3,
+		},
+		{
+			name:          "counts the fields of the named operation",
+			query:         `query first { hero_v1 } query second { a: hero_v1 b: hero_v1 }`,
+			operationName: "second",
+			expected:      2,
+		},
+		{
+			name:     "does not follow cyclic fragments",
+			query:    `{ ...a } fragment a on Query { hero_v1 ...a }`,
+			expected: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GraphQLRootFields(tt.query, tt.operationName)
+			test.VerifyError(t, err)
+			test.Equal(t, got, tt.expected)
+		})
+	}
+
+	t.Run("fails on invalid document", func(t *testing.T) {
+		_, err := GraphQLRootFields(`{ hero_v1`, "")
+		if err == nil {
+			t.Errorf("expected error on invalid document")
+		}
+	})
+}
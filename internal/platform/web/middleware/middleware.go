@@ -2,9 +2,13 @@ package middleware
 
 import (
 	"fmt"
+	"os"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/auth"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
 	"github.com/valyala/fasthttp"
 )
 
@@ -27,19 +31,95 @@ type Decorator struct {
 	cfg *conf.Config
 	pm  plugins.Lifecycle
 	cm  *ConnManager
+	rl  *RateLimiter
+	au  *auth.Authenticator
 }
 
-// NewDecorator creates a middleware Decorator
-func NewDecorator(log restql.Logger, cfg *conf.Config, pm plugins.Lifecycle) *Decorator {
+// NewDecorator creates a middleware Decorator, failing
+// when the authentication is enabled but misconfigured.
+func NewDecorator(log restql.Logger, cfg *conf.Config, pm plugins.Lifecycle) (*Decorator, error) {
 	cmEnabled := cfg.HTTP.Server.Middlewares.RequestCancellation.Enable
 	cmWatchingInterval := cfg.HTTP.Server.Middlewares.RequestCancellation.WatchInterval
 
-	return &Decorator{
+	d := &Decorator{
 		log: log,
 		cfg: cfg,
 		pm:  pm,
 		cm:  NewConnManager(log, cmEnabled, cmWatchingInterval),
 	}
+
+	rlCfg := cfg.HTTP.Server.Middlewares.RateLimit
+	if rlCfg.Enable {
+		rules := make([]RateLimitOptions, len(rlCfg.Rules))
+		for i, r := range rlCfg.Rules {
+			overrides := make(map[string]RateLimitValue, len(r.Overrides))
+			for value, o := range r.Overrides {
+				overrides[value] = RateLimitValue{Rate: o.Rate, Burst: o.Burst}
+			}
+
+			rules[i] = RateLimitOptions{Name: r.Name, Key: r.Key, Header: r.Header, TrustedProxies: r.TrustedProxies, Rate: r.Rate, Burst: r.Burst, Overrides: overrides}
+		}
+
+		d.rl = NewRateLimiter(log, cfg.Tenant, rlCfg.MaxKeys, rules)
+	}
+
+	if cfg.HTTP.Server.Auth.Enable {
+		au, err := newAuthenticator(cfg)
+		if err != nil {
+			return nil, err
+		}
+		d.au = au
+	}
+
+	return d, nil
+}
+
+var errMissingAuthSecret = errors.New("authentication secret not found")
+
+// newAuthenticator reads the JWT secret and the API keys
+// from the environment variables named in the configuration.
+func newAuthenticator(cfg *conf.Config) (*auth.Authenticator, error) {
+	authCfg := cfg.HTTP.Server.Auth
+
+	options := auth.Options{
+		JWKSFiles: authCfg.JWT.JWKSFiles,
+		Issuer:    authCfg.JWT.Issuer,
+		Audience:  authCfg.JWT.Audience,
+		Leeway:    authCfg.JWT.Leeway,
+	}
+
+	if authCfg.JWT.SecretEnv != "" {
+		secret, err := lookupAuthSecret(authCfg.JWT.SecretEnv)
+		if err != nil {
+			return nil, err
+		}
+		options.Secrets = []string{secret}
+	}
+
+	for _, k := range authCfg.APIKeys.Keys {
+		key, err := lookupAuthSecret(k.Env)
+		if err != nil {
+			return nil, err
+		}
+		options.APIKeys = append(options.APIKeys, auth.APIKey{Name: k.Name, Key: key, Queries: k.Queries, AdHoc: k.AdHoc})
+	}
+
+	return auth.NewAuthenticator(options)
+}
+
+func lookupAuthSecret(name string) (string, error) {
+	value, found := os.LookupEnv(name)
+	if !found || value == "" {
+		return "", errors.Wrapf(errMissingAuthSecret, "variable %s", name)
+	}
+
+	return value, nil
+}
+
+// RateLimiter returns the rate limit middleware,
+// or nil if rate limiting is disabled.
+func (d *Decorator) RateLimiter() *RateLimiter {
+	return d.rl
 }
 
 // Apply takes a base handler and fetches all middlewares enabled in configuration
@@ -58,7 +138,26 @@ func (d *Decorator) Apply(h fasthttp.RequestHandler) fasthttp.RequestHandler {
 }
 
 func (d *Decorator) fetchEnabled() []Middleware {
-	mws := []Middleware{newRecoverer(d.log), newNativeContext(d.cm), newTransaction(d.pm)}
+	mws := []Middleware{newRecoverer(d.log), newNativeContext(d.cm)}
+
+	logCfg := d.cfg.Logging
+	if logCfg.AccessLog.Enable || logCfg.SlowQuery.Enable {
+		al := accessLogOptions{
+			Enable:             logCfg.AccessLog.Enable,
+			SlowQueryEnable:    logCfg.SlowQuery.Enable,
+			SlowQueryThreshold: logCfg.SlowQuery.Threshold,
+		}
+		if d.cfg.HTTP.Server.Middlewares.RequestID.Enable {
+			al.RequestIDHeader = d.cfg.HTTP.Server.Middlewares.RequestID.Header
+		}
+		mws = append(mws, newAccessLog(d.log, al))
+	}
+
+	if d.cfg.Tracing.Enable {
+		mws = append(mws, newTracing())
+	}
+
+	mws = append(mws, newTransaction(d.pm))
 
 	mwCfg := d.cfg.HTTP.Server.Middlewares
 	if mwCfg.Timeout.Enable {
@@ -81,5 +180,13 @@ func (d *Decorator) fetchEnabled() []Middleware {
 		mws = append(mws, cors)
 	}
 
+	if d.au != nil {
+		mws = append(mws, newAuthentication(d.log, d.au, d.cfg.HTTP.Server.Auth.APIKeys.Header))
+	}
+
+	if d.rl != nil {
+		mws = append(mws, d.rl)
+	}
+
 	return mws
 }
@@ -0,0 +1,448 @@
+package middleware
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/metrics"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/valyala/fasthttp"
+)
+
+// Keys by which requests can be rate limited
+const (
+	RateLimitByTenant   = "tenant"
+	RateLimitByQuery    = "query"
+	RateLimitByAPIKey   = "apiKey"
+	RateLimitByClientIP = "clientIp"
+)
+
+const (
+	defaultAPIKeyHeader  = "X-Api-Key"
+	defaultRateLimitKeys = 10000
+)
+
+var (
+	adminPathPrefix     = []byte("/admin")
+	runQueryPathPrefix  = "/run-query/"
+	batchPath           = []byte("/run-queries")
+	streamPathSegment   = "stream"
+	rateLimitedResponse = []byte(`{"error":"rate limit exceeded"}`)
+)
+
+// RateLimitOptions defines a rate limit rule, which applies a token
+// bucket to each value of the key found on requests. Specific values
+// can have their own limits defined in Overrides. When the client IP
+// is read from Header, it is the address appended by the farthest of
+// the TrustedProxies in front of restQL, defaulting to one.
+type RateLimitOptions struct {
+	Name           string
+	Key            string
+	Header         string
+	TrustedProxies int
+	Rate           float64
+	Burst          int
+	Overrides      map[string]RateLimitValue
+}
+
+// RateLimitValue is the number of requests allowed per
+// second and how many can be made at once.
+type RateLimitValue struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimitUsage is the state of the buckets of a rule.
+type RateLimitUsage struct {
+	Rule    string                 `json:"rule"`
+	Key     string                 `json:"key"`
+	Rate    float64                `json:"rate"`
+	Burst   int                    `json:"burst"`
+	Buckets []RateLimitBucketUsage `json:"buckets"`
+}
+
+// RateLimitBucketUsage is the state of the bucket of a key value.
+// API keys are identified by the beginning of their SHA-256 hash.
+type RateLimitBucketUsage struct {
+	Value     string  `json:"value"`
+	Burst     int     `json:"burst"`
+	Available float64 `json:"available"`
+}
+
+// RateLimiter is a middleware that rejects requests to the query
+// endpoints with 429 when any rule has no tokens left for them.
+// Batch requests take one token for each of their queries.
+type RateLimiter struct {
+	log       restql.Logger
+	envTenant string
+	rules     []*rateLimitRule
+}
+
+// NewRateLimiter constructs a RateLimiter instance. Rules with an
+// unknown key are ignored. maxKeys limits the number of buckets
+// kept by each rule, after which the least recently used is removed.
+func NewRateLimiter(log restql.Logger, envTenant string, maxKeys int, options []RateLimitOptions) *RateLimiter {
+	rl := &RateLimiter{log: log, envTenant: envTenant}
+	if maxKeys <= 0 {
+		maxKeys = defaultRateLimitKeys
+	}
+
+	for _, o := range options {
+		switch o.Key {
+		case RateLimitByTenant, RateLimitByQuery, RateLimitByAPIKey, RateLimitByClientIP:
+		default:
+			log.Warn("ignoring rate limit rule with unknown key", "rule", o.Name, "key", o.Key)
+			continue
+		}
+
+		if o.Name == "" {
+			o.Name = o.Key
+		}
+		if o.Key == RateLimitByAPIKey && o.Header == "" {
+			o.Header = defaultAPIKeyHeader
+		}
+		if o.TrustedProxies <= 0 {
+			o.TrustedProxies = 1
+		}
+
+		overrides := make(map[string]bucketLimit, len(o.Overrides))
+		for value, limit := range o.Overrides {
+			overrides[value] = newBucketLimit(limit.Rate, limit.Burst)
+		}
+
+		rl.rules = append(rl.rules, &rateLimitRule{
+			options:   o,
+			limit:     newBucketLimit(o.Rate, o.Burst),
+			overrides: overrides,
+			maxKeys:   maxKeys,
+			buckets:   make(map[string]*list.Element),
+			recent:    list.New(),
+		})
+	}
+
+	return rl
+}
+
+// Apply limits every request, except the administrative ones.
+func (rl *RateLimiter) Apply(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		if bytes.HasPrefix(ctx.Path(), adminPathPrefix) {
+			h(ctx)
+			return
+		}
+
+		now := time.Now()
+		cost := requestCost(ctx)
+		if burst, exceeded := rl.exceedsBurst(ctx, cost); exceeded {
+			rl.log.Debug("request exceeds rate limit burst", "queries", cost, "burst", burst)
+			respondBurstExceeded(ctx, cost, burst)
+			return
+		}
+
+		for _, rule := range rl.rules {
+			value, found := rule.extractValue(ctx, rl.envTenant)
+			if !found {
+				continue
+			}
+
+			allowed, retryAfter := rule.take(value, cost, now)
+			if !allowed {
+				rl.log.Debug("request rate limited", "rule", rule.options.Name)
+				metrics.RateLimitRejected(rule.options.Name)
+				respondRateLimited(ctx, retryAfter)
+				return
+			}
+		}
+
+		h(ctx)
+	}
+}
+
+// exceedsBurst checks whether the cost of the request, as a batch or
+// a GraphQL operation, is greater than the burst of any rule, in which
+// case it could never be allowed, returning the lowest of such bursts.
+func (rl *RateLimiter) exceedsBurst(ctx *fasthttp.RequestCtx, cost float64) (float64, bool) {
+	if cost <= 1 {
+		return 0, false
+	}
+
+	lowest := math.Inf(1)
+	for _, rule := range rl.rules {
+		value, found := rule.extractValue(ctx, rl.envTenant)
+		if !found {
+			continue
+		}
+
+		lowest = math.Min(lowest, rule.limitFor(value).burst)
+	}
+
+	return lowest, cost > lowest
+}
+
+// Usage returns the state of the buckets of every rule.
+func (rl *RateLimiter) Usage() []RateLimitUsage {
+	now := time.Now()
+
+	usage := make([]RateLimitUsage, len(rl.rules))
+	for i, rule := range rl.rules {
+		usage[i] = rule.usage(now)
+	}
+
+	return usage
+}
+
+func respondRateLimited(ctx *fasthttp.RequestCtx, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	ctx.Response.Header.Set("Retry-After", strconv.Itoa(seconds))
+	ctx.SetContentType("application/json; charset=utf-8")
+	ctx.SetStatusCode(fasthttp.StatusTooManyRequests)
+	ctx.SetBody(rateLimitedResponse)
+}
+
+func respondBurstExceeded(ctx *fasthttp.RequestCtx, cost float64, burst float64) {
+	ctx.SetContentType("application/json; charset=utf-8")
+	ctx.SetStatusCode(fasthttp.StatusBadRequest)
+	ctx.SetBodyString(fmt.Sprintf(`{"error":"request with %d queries exceeds the rate limit burst of %d"}`, int(cost), int(burst)))
+}
+
+type bucketLimit struct {
+	rate  float64
+	burst float64
+}
+
+// newBucketLimit uses as burst the number of requests allowed
+// per second when it is not given, allowing at least one.
+func newBucketLimit(rate float64, burst int) bucketLimit {
+	b := float64(burst)
+	if b <= 0 {
+		b = math.Max(1, math.Ceil(rate))
+	}
+
+	return bucketLimit{rate: rate, burst: b}
+}
+
+type tokenBucket struct {
+	value  string
+	limit  bucketLimit
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) available(now time.Time) float64 {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	return math.Min(b.limit.burst, b.tokens+elapsed*b.limit.rate)
+}
+
+func (b *tokenBucket) take(n float64, now time.Time) (bool, time.Duration) {
+	b.tokens = b.available(now)
+	b.last = now
+
+	if b.tokens >= n {
+		b.tokens -= n
+		return true, 0
+	}
+
+	if b.limit.rate <= 0 {
+		return false, time.Hour
+	}
+
+	missing := n - b.tokens
+	return false, time.Duration(missing / b.limit.rate * float64(time.Second))
+}
+
+type rateLimitRule struct {
+	options   RateLimitOptions
+	limit     bucketLimit
+	overrides map[string]bucketLimit
+	maxKeys   int
+
+	// buckets indexes the elements of recent, which holds
+	// the buckets from the most to the least recently used.
+	mu      sync.Mutex
+	buckets map[string]*list.Element
+	recent  *list.List
+}
+
+func (r *rateLimitRule) take(value string, n float64, now time.Time) (bool, time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	element, found := r.buckets[value]
+	if found {
+		r.recent.MoveToFront(element)
+	} else {
+		r.evict()
+
+		limit := r.limitFor(value)
+		element = r.recent.PushFront(&tokenBucket{value: value, limit: limit, tokens: limit.burst, last: now})
+		r.buckets[value] = element
+	}
+
+	return element.Value.(*tokenBucket).take(n, now)
+}
+
+// limitFor returns the limit of the buckets of the given value.
+func (r *rateLimitRule) limitFor(value string) bucketLimit {
+	limit, ok := r.overrides[value]
+	if !ok {
+		return r.limit
+	}
+
+	return limit
+}
+
+// evict makes room for a new bucket by removing the least recently used.
+func (r *rateLimitRule) evict() {
+	if len(r.buckets) < r.maxKeys {
+		return
+	}
+
+	oldest := r.recent.Back()
+	if oldest == nil {
+		return
+	}
+
+	r.recent.Remove(oldest)
+	delete(r.buckets, oldest.Value.(*tokenBucket).value)
+}
+
+func (r *rateLimitRule) usage(now time.Time) RateLimitUsage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	usage := RateLimitUsage{
+		Rule:    r.options.Name,
+		Key:     r.options.Key,
+		Rate:    r.limit.rate,
+		Burst:   int(r.limit.burst),
+		Buckets: make([]RateLimitBucketUsage, 0, len(r.buckets)),
+	}
+
+	for value, element := range r.buckets {
+		bucket := element.Value.(*tokenBucket)
+		if r.options.Key == RateLimitByAPIKey {
+			value = maskAPIKey(value)
+		}
+
+		usage.Buckets = append(usage.Buckets, RateLimitBucketUsage{
+			Value:     value,
+			Burst:     int(bucket.limit.burst),
+			Available: bucket.available(now),
+		})
+	}
+
+	sort.Slice(usage.Buckets, func(i, j int) bool {
+		return usage.Buckets[i].Value < usage.Buckets[j].Value
+	})
+
+	return usage
+}
+
+// extractValue returns the value of the rule key for the request.
+// Ad-hoc queries have no value for the query key.
+func (r *rateLimitRule) extractValue(ctx *fasthttp.RequestCtx, envTenant string) (string, bool) {
+	var value string
+
+	switch r.options.Key {
+	case RateLimitByTenant:
+		value = envTenant
+		if value == "" {
+			value = string(ctx.QueryArgs().Peek("tenant"))
+		}
+	case RateLimitByQuery:
+		value = savedQueryFromPath(string(ctx.Path()))
+	case RateLimitByAPIKey:
+		value = string(ctx.Request.Header.Peek(r.options.Header))
+	case RateLimitByClientIP:
+		if r.options.Header != "" {
+			value = forwardedClientIP(string(ctx.Request.Header.Peek(r.options.Header)), r.options.TrustedProxies)
+		}
+		if value == "" {
+			value = ctx.RemoteIP().String()
+		}
+	}
+
+	return value, value != ""
+}
+
+// forwardedClientIP returns the address appended by the farthest trusted
+// proxy, counting from the right, since the entries on its left are set
+// by the client. Headers with fewer entries than trusted proxies were
+// not set by them and are ignored.
+func forwardedClientIP(forwarded string, trustedProxies int) string {
+	if forwarded == "" {
+		return ""
+	}
+
+	entries := strings.Split(forwarded, ",")
+	if len(entries) < trustedProxies {
+		return ""
+	}
+
+	return strings.TrimSpace(entries[len(entries)-trustedProxies])
+}
+
+// requestCost returns the number of tokens taken by the request, which
+// is the number of queries for batch requests and of root fields for
+// GraphQL requests. Invalid batches take one, since they are rejected
+// without evaluating any query.
+func requestCost(ctx *fasthttp.RequestCtx) float64 {
+	if bytes.Equal(ctx.Path(), graphqlPath) {
+		return graphqlRequestCost(ctx)
+	}
+	if !bytes.Equal(ctx.Path(), batchPath) {
+		return 1
+	}
+
+	var batch struct {
+		Queries []json.RawMessage `json:"queries"`
+	}
+	err := json.Unmarshal(ctx.PostBody(), &batch)
+	if err != nil || len(batch.Queries) == 0 {
+		return 1
+	}
+
+	return float64(len(batch.Queries))
+}
+
+// savedQueryFromPath returns the namespace and query name
+// from the paths of the saved query endpoints.
+func savedQueryFromPath(path string) string {
+	if !strings.HasPrefix(path, runQueryPathPrefix) {
+		return ""
+	}
+
+	parts := strings.Split(strings.TrimPrefix(path, runQueryPathPrefix), "/")
+	if len(parts) == 4 && parts[0] == streamPathSegment {
+		parts = parts[1:]
+	}
+
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return ""
+	}
+
+	return parts[0] + "/" + parts[1]
+}
+
+func maskAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return "sha256:" + hex.EncodeToString(sum[:])[:12]
+}
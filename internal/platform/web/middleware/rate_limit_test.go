@@ -0,0 +1,228 @@
+package middleware
+
+import (
+	"container/list"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+func TestRateLimiter(t *testing.T) {
+	tests := []struct {
+		name           string
+		envTenant      string
+		rule           RateLimitOptions
+		requests       []func(ctx *fasthttp.RequestCtx)
+		expectedStatus []int
+	}{
+		{
+			name: "limits by tenant",
+			rule: RateLimitOptions{Key: RateLimitByTenant, Rate: 1, Burst: 2},
+			requests: []func(ctx *fasthttp.RequestCtx){
+				withURI("/run-query?tenant=DC"),
+				withURI("/run-query?tenant=DC"),
+				withURI("/run-query?tenant=MARVEL"),
+				withURI("/run-query?tenant=DC"),
+			},
+			expectedStatus: []int{200, 200, 200, 429},
+		},
+		{
+			name:      "limits by environment tenant",
+			envTenant: "DC",
+			rule:      RateLimitOptions{Key: RateLimitByTenant, Rate: 1, Burst: 1},
+			requests: []func(ctx *fasthttp.RequestCtx){
+				withURI("/run-query?tenant=MARVEL"),
+				withURI("/run-query?tenant=OTHER"),
+			},
+			expectedStatus: []int{200, 429},
+		},
+		{
+			name: "limits by saved query, ignoring ad-hoc ones",
+			rule: RateLimitOptions{Key: RateLimitByQuery, Rate: 1, Burst: 1},
+			requests: []func(ctx *fasthttp.RequestCtx){
+				withURI("/run-query/dc/heroes/1"),
+				withURI("/run-query/stream/dc/heroes/2"),
+				withURI("/run-query/dc/villains/1"),
+				withURI("/run-query"),
+				withURI("/run-query"),
+			},
+			expectedStatus: []int{200, 429, 200, 200, 200},
+		},
+		{
+			name: "limits by api key with override",
+			rule: RateLimitOptions{Key: RateLimitByAPIKey, Rate: 1, Burst: 1, Overrides: map[string]RateLimitValue{"premium": {Rate: 10, Burst: 2}}},
+			requests: []func(ctx *fasthttp.RequestCtx){
+				withHeader("X-Api-Key", "basic"),
+				withHeader("X-Api-Key", "basic"),
+				withHeader("X-Api-Key", "premium"),
+				withHeader("X-Api-Key", "premium"),
+				withHeader("X-Api-Key", "premium"),
+			},
+			expectedStatus: []int{200, 429, 200, 200, 429},
+		},
+		{
+			name: "limits by client ip from header",
+			rule: RateLimitOptions{Key: RateLimitByClientIP, Header: "X-Forwarded-For", Rate: 1, Burst: 1},
+			requests: []func(ctx *fasthttp.RequestCtx){
+				withHeader("X-Forwarded-For", "10.0.0.1, 10.0.0.2"),
+				withHeader("X-Forwarded-For", "10.0.0.2"),
+				withHeader("X-Forwarded-For", "10.0.0.9, 10.0.0.2"),
+				withHeader("X-Forwarded-For", "10.0.0.1"),
+			},
+			expectedStatus: []int{200, 429, 429, 200},
+		},
+		{
+			name: "limits by client ip behind trusted proxies",
+			rule: RateLimitOptions{Key: RateLimitByClientIP, Header: "X-Forwarded-For", TrustedProxies: 2, Rate: 1, Burst: 1},
+			requests: []func(ctx *fasthttp.RequestCtx){
+				withHeader("X-Forwarded-For", "10.0.0.1, 172.16.0.1"),
+				withHeader("X-Forwarded-For", "10.0.0.9, 10.0.0.1, 172.16.0.2"),
+				withHeader("X-Forwarded-For", "10.0.0.2, 172.16.0.1"),
+			},
+			expectedStatus: []int{200, 429, 200},
+		},
+		{
+			name: "takes one token for each query of a batch",
+			rule: RateLimitOptions{Key: RateLimitByTenant, Rate: 1, Burst: 3},
+			requests: []func(ctx *fasthttp.RequestCtx){
+				withBatch("/run-queries?tenant=DC", `{"queries": [{"text": "from hero"}, {"text": "from sidekick"}]}`),
+				withBatch("/run-queries?tenant=DC", `{"queries": [{"text": "from hero"}, {"text": "from sidekick"}]}`),
+				withURI("/run-query?tenant=DC"),
+				withBatch("/run-queries?tenant=MARVEL", `{"queries": [`),
+			},
+			expectedStatus: []int{200, 429, 200, 200},
+		},
+		{
+			name: "rejects batches with more queries than the burst",
+			rule: RateLimitOptions{Key: RateLimitByTenant, Rate: 1, Burst: 2, Overrides: map[string]RateLimitValue{"MARVEL": {Rate: 1, Burst: 3}}},
+			requests: []func(ctx *fasthttp.RequestCtx){
+				withBatch("/run-queries?tenant=DC", `{"queries": [{"text": "from hero"}, {"text": "from sidekick"}, {"text": "from villain"}]}`),
+				withBatch("/run-queries?tenant=DC", `{"queries": [{"text": "from hero"}, {"text": "from sidekick"}]}`),
+				withBatch("/run-queries?tenant=MARVEL", `{"queries": [{"text": "from hero"}, {"text": "from sidekick"}, {"text": "from villain"}]}`),
+			},
+			expectedStatus: []int{400, 200, 200},
+		},
+		{
+			name: "takes one token for each root field of a graphql operation",
+			rule: RateLimitOptions{Key: RateLimitByTenant, Rate: 1, Burst: 3},
+			requests: []func(ctx *fasthttp.RequestCtx){
+				withBatch("/graphql?tenant=DC", `{"query": "{ a: hero_v1 b: hero_v1 }"}`),
+				withBatch("/graphql?tenant=DC", `{"query": "{ a: hero_v1 b: hero_v1 }"}`),
+				withURI("/graphql?tenant=DC&query={hero_v1}"),
+				withBatch("/graphql?tenant=MARVEL", `{"query": "{ a: hero_v1 b: hero_v1 c: hero_v1 d: hero_v1 }"}`),
+			},
+			expectedStatus: []int{200, 429, 200, 400},
+		},
+		{
+			name: "does not limit admin endpoints",
+			rule: RateLimitOptions{Key: RateLimitByClientIP, Rate: 1, Burst: 1},
+			requests: []func(ctx *fasthttp.RequestCtx){
+				withURI("/admin/tenant"),
+				withURI("/admin/tenant"),
+			},
+			expectedStatus: []int{200, 200},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := NewRateLimiter(test.NoOpLogger, tt.envTenant, 100, []RateLimitOptions{tt.rule})
+			handler := rl.Apply(func(ctx *fasthttp.RequestCtx) {})
+
+			var got []int
+			for _, prepare := range tt.requests {
+				ctx := &fasthttp.RequestCtx{}
+				ctx.Request.SetRequestURI("/run-query")
+				prepare(ctx)
+
+				handler(ctx)
+				got = append(got, ctx.Response.StatusCode())
+			}
+
+			test.Equal(t, got, tt.expectedStatus)
+		})
+	}
+}
+
+func TestRateLimiterRetryAfter(t *testing.T) {
+	rl := NewRateLimiter(test.NoOpLogger, "", 100, []RateLimitOptions{{Key: RateLimitByTenant, Rate: 0.1, Burst: 1}})
+	handler := rl.Apply(func(ctx *fasthttp.RequestCtx) {})
+
+	for i := 0; i < 2; i++ {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.SetRequestURI("/run-query?tenant=DC")
+		handler(ctx)
+
+		if i == 1 {
+			test.Equal(t, ctx.Response.StatusCode(), fasthttp.StatusTooManyRequests)
+			test.Equal(t, string(ctx.Response.Header.Peek("Retry-After")), "10")
+			test.Equal(t, string(ctx.Response.Body()), `{"error":"rate limit exceeded"}`)
+		}
+	}
+}
+
+func TestRateLimiterUsage(t *testing.T) {
+	rl := NewRateLimiter(test.NoOpLogger, "", 100, []RateLimitOptions{
+		{Name: "per-key", Key: RateLimitByAPIKey, Rate: 0.001, Burst: 5},
+	})
+	handler := rl.Apply(func(ctx *fasthttp.RequestCtx) {})
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/run-query")
+	ctx.Request.Header.Set("X-Api-Key", "secret")
+	handler(ctx)
+	handler(ctx)
+
+	usage := rl.Usage()
+
+	test.Equal(t, len(usage), 1)
+	test.Equal(t, usage[0].Rule, "per-key")
+	test.Equal(t, usage[0].Burst, 5)
+	test.Equal(t, len(usage[0].Buckets), 1)
+	test.Equal(t, usage[0].Buckets[0].Value, maskAPIKey("secret"))
+	test.Equal(t, usage[0].Buckets[0].Available < 3.1, true)
+}
+
+func TestRateLimitRuleEviction(t *testing.T) {
+	rule := &rateLimitRule{
+		limit:   newBucketLimit(0, 1),
+		maxKeys: 2,
+		buckets: make(map[string]*list.Element),
+		recent:  list.New(),
+	}
+
+	now := time.Now()
+	rule.take("a", 1, now)
+	rule.take("b", 1, now.Add(time.Second))
+	rule.take("a", 1, now.Add(2*time.Second))
+	rule.take("c", 1, now.Add(3*time.Second))
+
+	_, foundA := rule.buckets["a"]
+	_, foundB := rule.buckets["b"]
+	test.Equal(t, foundA, true)
+	test.Equal(t, foundB, false)
+	test.Equal(t, len(rule.buckets), 2)
+	test.Equal(t, rule.recent.Len(), 2)
+}
+
+func withURI(uri string) func(ctx *fasthttp.RequestCtx) {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.Request.SetRequestURI(uri)
+	}
+}
+
+func withHeader(key, value string) func(ctx *fasthttp.RequestCtx) {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.Request.Header.Set(key, value)
+	}
+}
+
+func withBatch(uri, body string) func(ctx *fasthttp.RequestCtx) {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.Request.SetRequestURI(uri)
+		ctx.Request.Header.SetMethod(fasthttp.MethodPost)
+		ctx.Request.SetBodyString(body)
+	}
+}
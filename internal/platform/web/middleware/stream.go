@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"sync"
+
+	"github.com/valyala/fasthttp"
+)
+
+const streamCompletionKey = "streamCompletion"
+
+// StreamCompletion holds the work that middlewares defer until
+// a streamed response body is written. FastHTTP finishes writing
+// a body stream only after the handlers return, so ending the
+// transaction span or logging the access entry at that point
+// would miss everything that happens while the body is streamed.
+type StreamCompletion struct {
+	mu    sync.Mutex
+	done  bool
+	hooks []func()
+}
+
+// NewStreamCompletion registers a StreamCompletion in the request.
+// It must be called by handlers that respond with a body stream,
+// which in turn must call Done once the body is written.
+func NewStreamCompletion(ctx *fasthttp.RequestCtx) *StreamCompletion {
+	sc := &StreamCompletion{}
+	ctx.SetUserValue(streamCompletionKey, sc)
+	return sc
+}
+
+// Done runs the deferred work in the order it was registered.
+func (sc *StreamCompletion) Done() {
+	sc.mu.Lock()
+	hooks := sc.hooks
+	sc.hooks = nil
+	sc.done = true
+	sc.mu.Unlock()
+
+	for _, h := range hooks {
+		h()
+	}
+}
+
+func (sc *StreamCompletion) add(f func()) {
+	sc.mu.Lock()
+	if sc.done {
+		sc.mu.Unlock()
+		f()
+		return
+	}
+
+	sc.hooks = append(sc.hooks, f)
+	sc.mu.Unlock()
+}
+
+// afterResponse runs f once the response is complete, immediately
+// for regular responses or after the body when it is streamed.
+func afterResponse(ctx *fasthttp.RequestCtx, f func()) {
+	sc, ok := ctx.UserValue(streamCompletionKey).(*StreamCompletion)
+	if !ok {
+		f()
+		return
+	}
+
+	sc.add(f)
+}
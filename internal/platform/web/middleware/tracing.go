@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/tracing"
+	"github.com/valyala/fasthttp"
+)
+
+type tracingMiddleware struct{}
+
+func newTracing() Middleware {
+	return tracingMiddleware{}
+}
+
+// Apply starts the transaction span, continuing the trace
+// of the client when the traceparent header is present.
+// The span ends once the response is complete, which for
+// streamed responses is after the body is written.
+func (t tracingMiddleware) Apply(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		nativeContext := GetNativeContext(ctx)
+
+		if sc, ok := tracing.ParseTraceParent(string(ctx.Request.Header.Peek(tracing.TraceParentHeader))); ok {
+			nativeContext = tracing.WithRemoteParent(nativeContext, sc)
+		}
+
+		spanCtx, span := tracing.Start(nativeContext, "transaction", tracing.SpanKindServer)
+		span.SetAttribute("http.method", string(ctx.Method()))
+		span.SetAttribute("http.target", string(ctx.Path()))
+		WithNativeContext(ctx, spanCtx)
+
+		end := func() {
+			statusCode := ctx.Response.StatusCode()
+			span.SetAttribute("http.status_code", statusCode)
+			if statusCode >= 500 {
+				span.SetError(fmt.Errorf("request failed with status %d", statusCode))
+			}
+			span.End()
+		}
+
+		defer func() {
+			if reason := recover(); reason != nil {
+				end()
+				panic(reason)
+			}
+		}()
+
+		h(ctx)
+
+		afterResponse(ctx, end)
+	}
+}
@@ -0,0 +1,89 @@
+package web
+
+import (
+	"context"
+	"strings"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/pkg/errors"
+)
+
+const persistedQueryParamName = "_sha256"
+
+var (
+	errPersistedQueryHashMismatch = errors.New("invalid persisted query : hash does not match the query text")
+	errQueryNotAllowed            = errors.New("query not allowed : only persisted queries can be executed")
+)
+
+type persistedQueryReader interface {
+	Get(ctx context.Context, hash string) (string, error)
+	Register(ctx context.Context, hash string, text string) error
+}
+
+// persistedQueries resolves the text of ad-hoc queries sent by hash,
+// registering new ones or restricting execution to known ones.
+type persistedQueries struct {
+	reader       persistedQueryReader
+	allowlist    bool
+	autoRegister bool
+}
+
+func newPersistedQueries(reader persistedQueryReader, allowlist bool, autoRegister bool) persistedQueries {
+	return persistedQueries{reader: reader, allowlist: allowlist, autoRegister: autoRegister}
+}
+
+// Resolve returns the query text to be executed. When only the hash is
+// given the text is read from the store, and when both are given the
+// hash must match the text, which is registered if unknown and automatic
+// registration is enabled. With the allowlist enabled, only queries
+// already present in the store are accepted.
+func (pq persistedQueries) Resolve(ctx context.Context, hash string, text string) (string, error) {
+	hash = strings.ToLower(hash)
+
+	if hash == "" && !pq.allowlist {
+		return text, nil
+	}
+
+	if hash == "" {
+		hash = persistence.PersistedQueryHash(text)
+	} else if text != "" && persistence.PersistedQueryHash(text) != hash {
+		return "", errPersistedQueryHashMismatch
+	}
+
+	stored, err := pq.reader.Get(ctx, hash)
+	notFound := errors.Is(err, persistence.ErrPersistedQueryNotFound)
+	switch {
+	case err == nil:
+		return stored, nil
+	case text == "":
+		return "", err
+	case pq.allowlist && notFound:
+		return "", errQueryNotAllowed
+	case pq.allowlist:
+		return "", err
+	}
+
+	if notFound && pq.autoRegister {
+		err := pq.reader.Register(ctx, hash, text)
+		if err != nil {
+			log := restql.GetLogger(ctx)
+			log.Error("failed to register persisted query", err, "hash", hash)
+		}
+	}
+
+	return text, nil
+}
+
+// persistedQueryHash returns the hash sent in the query input,
+// removing it so it is not used to resolve variables.
+func persistedQueryHash(input restql.QueryInput) string {
+	param, found := input.Params[persistedQueryParamName]
+	if !found {
+		return ""
+	}
+	delete(input.Params, persistedQueryParamName)
+
+	hash, _ := param.(string)
+	return hash
+}
@@ -0,0 +1,70 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestPersistedQueriesResolve(t *testing.T) {
+	heroHash := persistence.PersistedQueryHash("from hero")
+	villainHash := persistence.PersistedQueryHash("from villain")
+
+	tests := []struct {
+		name          string
+		allowlist     bool
+		autoRegister  bool
+		hash          string
+		text          string
+		expected      string
+		expectedErr   error
+		registeredLen int
+	}{
+		{"should return text when no hash is given", false, false, "", "from villain", "from villain", nil, 0},
+		{"should return stored text for hash", false, false, heroHash, "", "from hero", nil, 0},
+		{"should fail when hash is unknown and no text is given", false, false, villainHash, "", "", persistence.ErrPersistedQueryNotFound, 0},
+		{"should fail when hash does not match text", false, false, heroHash, "from villain", "", errPersistedQueryHashMismatch, 0},
+		{"should return text without registering it", false, false, villainHash, "from villain", "from villain", nil, 0},
+		{"should register unknown query", false, true, villainHash, "from villain", "from villain", nil, 1},
+		{"should accept known query text when allowlist is enabled", true, false, "", "from hero", "from hero", nil, 0},
+		{"should reject unknown query text when allowlist is enabled", true, true, "", "from villain", "", errQueryNotAllowed, 0},
+		{"should reject unknown query hash when allowlist is enabled", true, true, villainHash, "from villain", "", errQueryNotAllowed, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := &stubPersistedQueryReader{queries: map[string]string{heroHash: "from hero"}}
+			pq := newPersistedQueries(reader, tt.allowlist, tt.autoRegister)
+
+			got, err := pq.Resolve(context.Background(), tt.hash, tt.text)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+
+			test.Equal(t, got, tt.expected)
+			test.Equal(t, len(reader.registered), tt.registeredLen)
+		})
+	}
+}
+
+type stubPersistedQueryReader struct {
+	queries    map[string]string
+	registered []string
+}
+
+func (s *stubPersistedQueryReader) Get(ctx context.Context, hash string) (string, error) {
+	text, found := s.queries[hash]
+	if !found {
+		return "", persistence.ErrPersistedQueryNotFound
+	}
+
+	return text, nil
+}
+
+func (s *stubPersistedQueryReader) Register(ctx context.Context, hash string, text string) error {
+	s.registered = append(s.registered, hash)
+	return nil
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
 	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/auth"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/redact"
 	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"strconv"
@@ -35,6 +37,20 @@ var errToStatusCode = map[error]int{
 	errInvalidTenant:                            fasthttp.StatusBadRequest,
 	errInvalidRevisionType:                      fasthttp.StatusBadRequest,
 	errFailedToReadRequestBody:                  fasthttp.StatusBadRequest,
+	errEmptyBatch:                               fasthttp.StatusBadRequest,
+	errBatchTooLarge:                            fasthttp.StatusBadRequest,
+	errInvalidBatchQuery:                        fasthttp.StatusBadRequest,
+	errPersistedQueryHashMismatch:               fasthttp.StatusBadRequest,
+	errQueryNotAllowed:                          fasthttp.StatusForbidden,
+	persistence.ErrPersistedQueryNotFound:       fasthttp.StatusNotFound,
+	errInvalidGraphQLQuery:                      fasthttp.StatusBadRequest,
+	errTooManyGraphQLFields:                     fasthttp.StatusBadRequest,
+	errNoGraphQLFields:                          fasthttp.StatusNotFound,
+	errPurgeWithoutNamespace:                    fasthttp.StatusBadRequest,
+	errAdminForbidden:                           fasthttp.StatusForbidden,
+	errInvalidAuditSince:                        fasthttp.StatusBadRequest,
+	auth.ErrUnauthenticated:                     fasthttp.StatusUnauthorized,
+	auth.ErrForbidden:                           fasthttp.StatusForbidden,
 }
 
 // ErrorResponse is the form used for API responses from failures in the API.
@@ -117,8 +133,15 @@ type QueryResponse struct {
 	Headers    map[string]string
 }
 
+// Debugging defines whether the debugging information is
+// added to the statement details and how it is redacted.
+type Debugging struct {
+	Enable   bool
+	Redactor *redact.Redactor
+}
+
 // MakeQueryResponse create a query execution response for the client.
-func MakeQueryResponse(queryResult domain.Resources, debug bool) (QueryResponse, error) {
+func MakeQueryResponse(queryResult domain.Resources, debug Debugging) (QueryResponse, error) {
 	m := make(map[string]StatementResult)
 	for key, resource := range queryResult {
 		r, err := parseResource(resource, debug)
@@ -134,7 +157,7 @@ func MakeQueryResponse(queryResult domain.Resources, debug bool) (QueryResponse,
 	return QueryResponse{Body: m, StatusCode: statusCode, Headers: headers}, nil
 }
 
-func parseResource(resource interface{}, debug bool) (StatementResult, error) {
+func parseResource(resource interface{}, debug Debugging) (StatementResult, error) {
 	switch resource := resource.(type) {
 	case restql.DoneResource:
 		body, err := resource.ResponseBody.Marshal()
@@ -177,7 +200,7 @@ func parseResource(resource interface{}, debug bool) (StatementResult, error) {
 	}
 }
 
-func parseDetails(resource restql.DoneResource, debug bool) StatementDetails {
+func parseDetails(resource restql.DoneResource, debug Debugging) StatementDetails {
 	var metadata StatementMetadata
 	if resource.IgnoreErrors {
 		metadata.IgnoreErrors = "ignore"
@@ -189,8 +212,8 @@ func parseDetails(resource restql.DoneResource, debug bool) StatementDetails {
 		Metadata: metadata,
 	}
 
-	if debug {
-		sd.Debug = parseDebug(resource)
+	if debug.Enable {
+		sd.Debug = parseDebug(debug.Redactor.DoneResource(resource))
 	}
 
 	return sd
@@ -290,9 +313,14 @@ func getResponseHeader(resourceID string, response restql.DoneResource) map[stri
 		return nil
 	}
 
+	responseHeaders := response.ResponseHeaders
+	if response.ExposedHeaders != nil {
+		responseHeaders = response.ExposedHeaders
+	}
+
 	var buf bytes.Buffer
 	headers := make(map[string]string)
-	for hn, hv := range response.ResponseHeaders {
+	for hn, hv := range responseHeaders {
 		buf.WriteString(resourceID)
 		buf.WriteRune('-')
 		buf.WriteString(hn)
@@ -0,0 +1,151 @@
+package web
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/cache"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/valyala/fasthttp"
+)
+
+// responseCacheKey holds everything that identifies a query
+// response: the query itself, the tenant, the input values and
+// the request headers that the response may vary upon, as well as
+// the authenticated client, since queries may differ by its claims.
+type responseCacheKey struct {
+	Namespace string                 `json:"namespace,omitempty"`
+	Query     string                 `json:"query,omitempty"`
+	Revision  int                    `json:"revision,omitempty"`
+	TextHash  string                 `json:"text,omitempty"`
+	Tenant    string                 `json:"tenant"`
+	Params    map[string]interface{} `json:"params,omitempty"`
+	Body      interface{}            `json:"body,omitempty"`
+	Headers   map[string]string      `json:"headers,omitempty"`
+	Principal string                 `json:"principal,omitempty"`
+	Claims    map[string]interface{} `json:"claims,omitempty"`
+}
+
+// responses caches whole query responses, as long as every
+// statement allows it through its cache control directives.
+type responses struct {
+	cache       *cache.ResponseCache
+	varyHeaders []string
+}
+
+func newResponses(c *cache.ResponseCache, varyHeaders []string) responses {
+	return responses{cache: c, varyHeaders: varyHeaders}
+}
+
+func (rs responses) enabled() bool {
+	return rs.cache != nil
+}
+
+// Evaluate returns the query response from cache, evaluating the query
+// when needed. Responses served from cache have the Age header set,
+// while responses with debugging information are never cached.
+func (rs responses) Evaluate(ctx context.Context, reqCtx *fasthttp.RequestCtx, options restql.QueryOptions, queryTxt string, input restql.QueryInput, debug Debugging, evaluate queryEvaluation) (QueryResponse, error) {
+	if !rs.enabled() || debug.Enable {
+		result, err := evaluate(ctx)
+		if err != nil {
+			return QueryResponse{}, err
+		}
+
+		return MakeQueryResponse(result, debug)
+	}
+
+	key, err := rs.makeKey(ctx, reqCtx, options, queryTxt, input)
+	if err != nil {
+		return QueryResponse{}, err
+	}
+
+	value, age, err := rs.cache.Get(ctx, key, func(ctx context.Context) (cache.ResponseEntry, error) {
+		result, err := evaluate(ctx)
+		if err != nil {
+			return cache.ResponseEntry{}, err
+		}
+
+		response, err := MakeQueryResponse(result, Debugging{})
+		if err != nil {
+			return cache.ResponseEntry{}, err
+		}
+
+		entry := cache.ResponseEntry{Value: response, Namespace: options.Namespace, Query: options.Id}
+		if response.StatusCode >= 200 && response.StatusCode < 300 {
+			entry.MaxAge = responseMaxAge(result)
+		}
+
+		return entry, nil
+	})
+	if err != nil {
+		return QueryResponse{}, err
+	}
+
+	response := value.(QueryResponse)
+	if age > 0 {
+		response.Headers = appendMap(response.Headers, map[string]string{"Age": strconv.Itoa(int(age.Seconds()))})
+	}
+
+	return response, nil
+}
+
+func (rs responses) makeKey(ctx context.Context, reqCtx *fasthttp.RequestCtx, options restql.QueryOptions, queryTxt string, input restql.QueryInput) (string, error) {
+	key := responseCacheKey{
+		Namespace: options.Namespace,
+		Query:     options.Id,
+		Revision:  options.Revision,
+		Tenant:    options.Tenant,
+		Params:    input.Params,
+		Body:      input.Body,
+		Headers:   make(map[string]string),
+	}
+
+	if queryTxt != "" {
+		key.TextHash = hashString(queryTxt)
+	}
+
+	if p, ok := restql.GetPrincipal(ctx); ok {
+		key.Principal = p.Method + ":" + p.Subject
+		key.Claims = p.Claims
+	}
+
+	for _, header := range rs.varyHeaders {
+		if value := reqCtx.Request.Header.Peek(header); len(value) > 0 {
+			key.Headers[header] = string(value)
+		}
+	}
+
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+
+	return hashString(string(data)), nil
+}
+
+// responseMaxAge returns the time a response can be cached, given
+// by the lowest s-maxage, or max-age when absent, among the statements.
+func responseMaxAge(queryResult domain.Resources) time.Duration {
+	cacheControl := calculateCacheControl(queryResult)
+
+	switch {
+	case cacheControl.NoCache:
+		return 0
+	case cacheControl.SMaxAge.Exist:
+		return time.Duration(cacheControl.SMaxAge.Time) * time.Second
+	case cacheControl.MaxAge.Exist:
+		return time.Duration(cacheControl.MaxAge.Time) * time.Second
+	default:
+		return 0
+	}
+}
+
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,80 @@
+package web
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/cache"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/valyala/fasthttp"
+)
+
+func TestResponsesEvaluate(t *testing.T) {
+	rs := newResponses(cache.NewResponseCache(test.NoOpLogger, 10, 0), []string{"Authorization"})
+	options := restql.QueryOptions{Namespace: "dc", Id: "heroes", Revision: 1, Tenant: "default"}
+
+	evaluations := 0
+	evaluate := func(cacheControl restql.ResourceCacheControl) queryEvaluation {
+		return func(ctx context.Context) (domain.Resources, error) {
+			evaluations++
+			return domain.Resources{
+				"hero": restql.DoneResource{Status: 200, Success: true, CacheControl: cacheControl, ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, nil)},
+			}, nil
+		}
+	}
+	maxAge := restql.ResourceCacheControl{MaxAge: restql.ResourceCacheControlValue{Exist: true, Time: 60}}
+
+	request := func(authorization string, params map[string]interface{}) (*fasthttp.RequestCtx, restql.QueryInput) {
+		reqCtx := &fasthttp.RequestCtx{}
+		if authorization != "" {
+			reqCtx.Request.Header.Set("Authorization", authorization)
+		}
+
+		return reqCtx, restql.QueryInput{Params: params}
+	}
+
+	tests := []struct {
+		name                string
+		authorization       string
+		params              map[string]interface{}
+		cacheControl        restql.ResourceCacheControl
+		expectedEvaluations int
+	}{
+		{"should evaluate query on first request", "", map[string]interface{}{"id": "1"}, maxAge, 1},
+		{"should use cached response on same request", "", map[string]interface{}{"id": "1"}, maxAge, 1},
+		{"should evaluate query with different params", "", map[string]interface{}{"id": "2"}, maxAge, 2},
+		{"should evaluate query with different vary header", "Bearer batman", map[string]interface{}{"id": "1"}, maxAge, 3},
+		{"should bypass cache on debug", "", map[string]interface{}{"id": "1", "_debug": "true"}, maxAge, 4},
+		{"should not cache response without max age", "", map[string]interface{}{"id": "3"}, restql.ResourceCacheControl{}, 5},
+		{"should evaluate query again when response was not cached", "", map[string]interface{}{"id": "3"}, restql.ResourceCacheControl{}, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqCtx, input := request(tt.authorization, tt.params)
+
+			response, err := rs.Evaluate(context.Background(), reqCtx, options, "", input, debugPolicy{}.debugging(options.Tenant, input), evaluate(tt.cacheControl))
+			test.VerifyError(t, err)
+
+			test.Equal(t, response.StatusCode, 200)
+			test.Equal(t, evaluations, tt.expectedEvaluations)
+		})
+	}
+}
+
+func TestResponseMaxAge(t *testing.T) {
+	resource := func(cacheControl restql.ResourceCacheControl) domain.Resources {
+		return domain.Resources{"hero": restql.DoneResource{CacheControl: cacheControl}}
+	}
+	value := func(time int) restql.ResourceCacheControlValue {
+		return restql.ResourceCacheControlValue{Exist: true, Time: time}
+	}
+
+	test.Equal(t, responseMaxAge(resource(restql.ResourceCacheControl{MaxAge: value(60)})), time.Minute)
+	test.Equal(t, responseMaxAge(resource(restql.ResourceCacheControl{MaxAge: value(60), SMaxAge: value(30)})), 30*time.Second)
+	test.Equal(t, responseMaxAge(resource(restql.ResourceCacheControl{NoCache: true, MaxAge: value(60)})), time.Duration(0))
+	test.Equal(t, responseMaxAge(resource(restql.ResourceCacheControl{})), time.Duration(0))
+}
@@ -421,11 +421,41 @@ func TestMakeQueryResponse(t *testing.T) {
 				},
 			},
 		},
+		{
+			"should make response with upstream headers exposed by the header policy",
+			domain.Resources{
+				"hero": restql.DoneResource{
+					Status:       200,
+					Success:      true,
+					ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, test.Unmarshal(`{"id": "12345abcde"}`)),
+					ResponseHeaders: map[string]string{
+						"TransactionId": "abdcefg",
+						"Set-Cookie":    "session=secret",
+					},
+					ExposedHeaders: map[string]string{
+						"TransactionId": "abdcefg",
+					},
+				},
+			},
+			false,
+			web.QueryResponse{
+				StatusCode: 200,
+				Body: map[string]web.StatementResult{
+					"hero": {
+						Details: web.StatementDetails{Status: 200, Success: true},
+						Result:  rawResult(`{"id": "12345abcde"}`),
+					},
+				},
+				Headers: map[string]string{
+					"hero-TransactionId": "abdcefg",
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, _ := web.MakeQueryResponse(tt.queryResult, tt.debug)
+			got, _ := web.MakeQueryResponse(tt.queryResult, web.Debugging{Enable: tt.debug})
 			test.Equal(t, got, tt.expected)
 		})
 	}
@@ -1,14 +1,18 @@
 package web
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
 	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/redact"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web/middleware"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"github.com/pkg/errors"
@@ -28,10 +32,13 @@ type restQl struct {
 	log       restql.Logger
 	evaluator eval.Evaluator
 	parser    parser.Parser
+	persisted persistedQueries
+	responses responses
+	debug     debugPolicy
 }
 
-func newRestQl(l restql.Logger, cfg *conf.Config, e eval.Evaluator, p parser.Parser) restQl {
-	return restQl{config: cfg, log: l, evaluator: e, parser: p}
+func newRestQl(l restql.Logger, cfg *conf.Config, e eval.Evaluator, p parser.Parser, pq persistedQueries, rs responses, dp debugPolicy) restQl {
+	return restQl{config: cfg, log: l, evaluator: e, parser: p, persisted: pq, responses: rs, debug: dp}
 }
 
 func (r restQl) ValidateQuery(ctx *fasthttp.RequestCtx) error {
@@ -48,7 +55,8 @@ func (r restQl) ValidateQuery(ctx *fasthttp.RequestCtx) error {
 }
 
 func (r restQl) RunAdHocQuery(reqCtx *fasthttp.RequestCtx) error {
-	ctx := restql.WithLogger(reqCtx, r.log)
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, r.log)
 
 	tenant, err := makeTenant(reqCtx, r.config.Tenant)
 	if err != nil {
@@ -63,31 +71,98 @@ func (r restQl) RunAdHocQuery(reqCtx *fasthttp.RequestCtx) error {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
-	queryTxt := string(reqCtx.PostBody())
+	// checked before resolving, since it may register the query text
+	err = r.evaluator.Authorize(ctx, options)
+	if err != nil {
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
 
-	result, err := r.evaluator.AdHocQuery(ctx, queryTxt, options, input)
+	queryTxt, err := r.persisted.Resolve(ctx, persistedQueryHash(input), string(reqCtx.PostBody()))
+	if err != nil {
+		r.log.Error("failed to resolve persisted query", err)
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	response, err := r.responses.Evaluate(ctx, reqCtx, options, queryTxt, input, r.debug.debugging(tenant, input), func(ctx context.Context) (domain.Resources, error) {
+		return r.evaluator.AdHocQuery(ctx, queryTxt, options, input)
+	})
 	if err != nil {
 		r.log.Error("failed to evaluated adhoc query", err)
 
-		adhocErrToStatusCode := make(map[error]int)
-		for err, status := range errToStatusCode {
-			adhocErrToStatusCode[err] = status
-		}
-		adhocErrToStatusCode[eval.ErrParser] = http.StatusBadRequest
+		return RespondError(reqCtx, err, adHocErrToStatusCode())
+	}
+
+	return Respond(reqCtx, response.Body, response.StatusCode, response.Headers)
+}
+
+func (r restQl) RunSavedQuery(reqCtx *fasthttp.RequestCtx) error {
+	log := r.log.With("restql-endpoint", string(reqCtx.Request.URI().Path()))
+	log = log.With("request-id", string(reqCtx.Request.Header.Peek("X-TID")))
+
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, log)
 
-		return RespondError(reqCtx, err, adhocErrToStatusCode)
+	options, err := makeQueryOptions(reqCtx, log, r.config.Tenant)
+	if err != nil {
+		log.Error("failed to build query options", err)
+		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
-	debugEnabled := isDebugEnabled(input)
-	response, err := MakeQueryResponse(result, debugEnabled)
+	input, err := makeQueryInput(reqCtx, log)
 	if err != nil {
+		log.Error("failed to build query input", err)
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	response, err := r.responses.Evaluate(ctx, reqCtx, options, "", input, r.debug.debugging(options.Tenant, input), func(ctx context.Context) (domain.Resources, error) {
+		return r.evaluator.SavedQuery(ctx, options, input)
+	})
+	if err != nil {
+		log.Error("failed to evaluated saved query", err)
+
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
 	return Respond(reqCtx, response.Body, response.StatusCode, response.Headers)
 }
 
-func (r restQl) RunSavedQuery(reqCtx *fasthttp.RequestCtx) error {
+func (r restQl) RunAdHocQueryStream(reqCtx *fasthttp.RequestCtx) error {
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, r.log)
+
+	tenant, err := makeTenant(reqCtx, r.config.Tenant)
+	if err != nil {
+		r.log.Error("failed to build query options", err)
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+	options := restql.QueryOptions{Tenant: tenant}
+
+	input, err := makeQueryInput(reqCtx, r.log)
+	if err != nil {
+		r.log.Error("failed to build query input", err)
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	// checked before resolving, since it may register the query text
+	err = r.evaluator.Authorize(ctx, options)
+	if err != nil {
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	queryTxt, err := r.persisted.Resolve(ctx, persistedQueryHash(input), string(reqCtx.PostBody()))
+	if err != nil {
+		r.log.Error("failed to resolve persisted query", err)
+		return RespondError(reqCtx, err, errToStatusCode)
+	}
+
+	RespondStream(reqCtx, ctx, r.debug.debugging(tenant, input), adHocErrToStatusCode(), func(ctx context.Context) (domain.Resources, error) {
+		return r.evaluator.AdHocQuery(ctx, queryTxt, options, input)
+	})
+
+	return nil
+}
+
+func (r restQl) RunSavedQueryStream(reqCtx *fasthttp.RequestCtx) error {
 	log := r.log.With("restql-endpoint", string(reqCtx.Request.URI().Path()))
 	log = log.With("request-id", string(reqCtx.Request.Header.Peek("X-TID")))
 
@@ -106,20 +181,72 @@ func (r restQl) RunSavedQuery(reqCtx *fasthttp.RequestCtx) error {
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
-	result, err := r.evaluator.SavedQuery(ctx, options, input)
-	if err != nil {
-		log.Error("failed to evaluated saved query", err)
+	RespondStream(reqCtx, ctx, r.debug.debugging(options.Tenant, input), errToStatusCode, func(ctx context.Context) (domain.Resources, error) {
+		return r.evaluator.SavedQuery(ctx, options, input)
+	})
+
+	return nil
+}
 
+func (r restQl) RunQueries(reqCtx *fasthttp.RequestCtx) error {
+	log := r.log.With("restql-endpoint", string(reqCtx.Request.URI().Path()))
+	log = log.With("request-id", string(reqCtx.Request.Header.Peek("X-TID")))
+
+	ctx := middleware.GetNativeContext(reqCtx)
+	ctx = restql.WithLogger(ctx, log)
+
+	tenant, err := makeTenant(reqCtx, r.config.Tenant)
+	if err != nil {
+		log.Error("failed to build query options", err)
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
-	debugEnabled := isDebugEnabled(input)
-	response, err := MakeQueryResponse(result, debugEnabled)
+	batch, err := parseBatchRequest(reqCtx.PostBody(), r.config.HTTP.Server.Batch.MaxSize)
 	if err != nil {
+		log.Error("failed to parse batch request", err)
 		return RespondError(reqCtx, err, errToStatusCode)
 	}
 
-	return Respond(reqCtx, response.Body, response.StatusCode, response.Headers)
+	headers := makeRequestHeaders(reqCtx)
+
+	results := runBatch(ctx, batch.Queries, batchConcurrency(r.config), r.debug, tenant, func(ctx context.Context, query BatchQuery) (domain.Resources, error) {
+		input := restql.QueryInput{Params: query.Params, Headers: headers, Body: query.Body}
+		if input.Params == nil {
+			input.Params = make(map[string]interface{})
+		}
+
+		if query.isAdHoc() {
+			options := restql.QueryOptions{Tenant: tenant}
+			err := r.evaluator.Authorize(ctx, options)
+			if err != nil {
+				return nil, err
+			}
+
+			queryTxt, err := r.persisted.Resolve(ctx, query.SHA256, query.Text)
+			if err != nil {
+				return nil, err
+			}
+
+			return r.evaluator.AdHocQuery(ctx, queryTxt, options, input)
+		}
+
+		options := restql.QueryOptions{Namespace: query.Namespace, Id: query.QueryID, Revision: query.Revision, Tenant: tenant}
+		return r.evaluator.SavedQuery(ctx, options, input)
+	})
+
+	return Respond(reqCtx, BatchResponse{Results: results}, http.StatusOK, nil)
+}
+
+// adHocErrToStatusCode considers parsing failures as client errors,
+// since the query text is sent on the request.
+func adHocErrToStatusCode() map[error]int {
+	adhocErrToStatusCode := make(map[error]int)
+	for err, status := range errToStatusCode {
+		adhocErrToStatusCode[err] = status
+	}
+	adhocErrToStatusCode[eval.ErrParser] = http.StatusBadRequest
+
+	return adhocErrToStatusCode
 }
 
 func makeQueryOptions(ctx *fasthttp.RequestCtx, log restql.Logger, envTenant string) (restql.QueryOptions, error) {
@@ -200,14 +327,9 @@ func makeQueryInput(ctx *fasthttp.RequestCtx, log restql.Logger) (restql.QueryIn
 
 	})
 
-	headers := make(map[string]string)
-	ctx.Request.Header.VisitAll(func(key, value []byte) {
-		headers[string(key)] = string(value)
-	})
-
 	input := restql.QueryInput{
 		Params:  params,
-		Headers: headers,
+		Headers: makeRequestHeaders(ctx),
 	}
 
 	contentType := string(ctx.Request.Header.ContentType())
@@ -228,8 +350,45 @@ func makeQueryInput(ctx *fasthttp.RequestCtx, log restql.Logger) (restql.QueryIn
 	return input, nil
 }
 
+func makeRequestHeaders(ctx *fasthttp.RequestCtx) map[string]string {
+	headers := make(map[string]string)
+	ctx.Request.Header.VisitAll(func(key, value []byte) {
+		headers[string(key)] = string(value)
+	})
+
+	return headers
+}
+
 const debugParamName = "_debug"
 
+// debugPolicy decides whether a query response includes the debugging
+// information requested through the _debug parameter, which can be
+// disabled for all tenants or some of them, and how it is redacted.
+type debugPolicy struct {
+	disabled        bool
+	disabledTenants map[string]bool
+	redactor        *redact.Redactor
+}
+
+func newDebugPolicy(cfg *conf.Config, redactor *redact.Redactor) debugPolicy {
+	debugCfg := cfg.HTTP.Server.Debug
+
+	disabledTenants := make(map[string]bool)
+	for _, t := range debugCfg.DisabledTenants {
+		disabledTenants[strings.TrimSpace(t)] = true
+	}
+
+	return debugPolicy{disabled: debugCfg.Disable, disabledTenants: disabledTenants, redactor: redactor}
+}
+
+func (dp debugPolicy) debugging(tenant string, queryInput restql.QueryInput) Debugging {
+	if dp.disabled || dp.disabledTenants[tenant] {
+		return Debugging{}
+	}
+
+	return Debugging{Enable: isDebugEnabled(queryInput), Redactor: dp.redactor}
+}
+
 func isDebugEnabled(queryInput restql.QueryInput) bool {
 	param, found := queryInput.Params[debugParamName]
 	if !found {
@@ -4,43 +4,59 @@ import (
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web/middleware"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"net/http"
+	"os"
 
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
 	"github.com/b2wdigital/restQL-golang/v6/internal/parser"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/cache"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/headers"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/httpclient"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/persistence"
 	"github.com/b2wdigital/restQL-golang/v6/internal/platform/plugins"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/redact"
 	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
+	"github.com/pkg/errors"
 	"github.com/valyala/fasthttp"
 )
 
-// API constructs a handler for the restQL query related endpoints
-func API(log restql.Logger, cfg *conf.Config) (fasthttp.RequestHandler, error) {
+// API constructs a handler for the restQL query related endpoints,
+// along with the readiness checks for the dependencies it uses.
+func API(log restql.Logger, cfg *conf.Config, redactor *redact.Redactor) (fasthttp.RequestHandler, Readiness, error) {
 	log.Debug("starting api")
 	defaultParser, err := parser.New()
 	if err != nil {
 		log.Error("failed to compile parser", err)
-		return nil, err
+		return nil, Readiness{}, err
 	}
-	parserCacheLoader := cache.New(log, cfg.Cache.Parser.MaxSize, cache.ParserCacheLoader(defaultParser))
+	parserCacheLoader := cache.New(log, cfg.Cache.Parser.MaxSize, cache.ParserCacheLoader(defaultParser), cache.WithName("parser"))
 	parserCache := cache.NewParserCache(log, parserCacheLoader)
 
 	databaseDisabled := cfg.Plugins.DisableDatabase
 	db, err := persistence.NewDatabase(log, databaseDisabled)
 	if err != nil {
 		log.Error("failed to establish connection to database", err)
-		return nil, err
+		return nil, Readiness{}, err
 	}
 
-	lifecycle, err := plugins.NewLifecycle(log)
+	lifecycle, err := plugins.NewLifecycle(log, redactor)
 	if err != nil {
 		log.Error("failed to initialize plugins", err)
 	}
 
-	client := httpclient.New(log, lifecycle, cfg)
-	executor := runner.NewExecutor(log, client, cfg.HTTP.QueryResourceTimeout, cfg.HTTP.ForwardPrefix)
+	client, err := httpclient.New(log, lifecycle, cfg)
+	if err != nil {
+		log.Error("failed to initialize http client", err)
+		return nil, Readiness{}, err
+	}
+	headerPolicies, err := headers.New(cfg)
+	if err != nil {
+		log.Error("failed to load header policies", err)
+		return nil, Readiness{}, err
+	}
+
+	executor := runner.NewExecutor(log, client, cfg.HTTP.QueryResourceTimeout, cfg.HTTP.ForwardPrefix, headerPolicies)
 	r := runner.NewRunner(log, executor, runner.Options{
 		GlobalQueryTimeout:      cfg.HTTP.GlobalQueryTimeout,
 		MaxConcurrentQueries:    cfg.HTTP.Client.MaxConcurrentQueries,
@@ -50,6 +66,7 @@ func API(log restql.Logger, cfg *conf.Config) (fasthttp.RequestHandler, error) {
 	mappingReader := persistence.NewMappingReader(log, cfg.Env, cfg.TenantMappings, db)
 	tenantCache := cache.New(log, cfg.Cache.Mappings.MaxSize,
 		cache.TenantCacheLoader(mappingReader),
+		cache.WithName("mappings"),
 		cache.WithExpiration(cfg.Cache.Mappings.Expiration),
 		cache.WithRefreshInterval(cfg.Cache.Mappings.RefreshInterval),
 		cache.WithRefreshQueueLength(cfg.Cache.Mappings.RefreshQueueLength),
@@ -57,30 +74,129 @@ func API(log restql.Logger, cfg *conf.Config) (fasthttp.RequestHandler, error) {
 	cacheMr := cache.NewMappingsReaderCache(log, tenantCache)
 
 	queryReader := persistence.NewQueryReader(log, cfg.Queries, db)
-	queryCache := cache.New(log, cfg.Cache.Query.MaxSize, cache.QueryCacheLoader(queryReader))
+	queryCache := cache.New(log, cfg.Cache.Query.MaxSize, cache.QueryCacheLoader(queryReader), cache.WithName("query"))
 	cacheQr := cache.NewQueryReaderCache(log, queryCache)
 
-	e := eval.NewEvaluator(log, cacheMr, cacheQr, r, parserCache, lifecycle)
+	e := eval.NewEvaluator(log, cacheMr, cacheQr, r, parserCache, lifecycle, cfg.HTTP.Server.Auth.Enable)
+
+	persistedQueryStore := persistence.NewPersistedQueryStore(log, cfg.PersistedQueries.Queries, db)
+	persistedQueryCache := cache.New(log, cfg.Cache.PersistedQuery.MaxSize, cache.PersistedQueryCacheLoader(persistedQueryStore), cache.WithName("persisted-query"))
+	pq := newPersistedQueries(
+		cache.NewPersistedQueryCache(log, persistedQueryCache, persistedQueryStore),
+		cfg.PersistedQueries.Allowlist,
+		cfg.PersistedQueries.AutoRegister,
+	)
+
+	var responseCache *cache.ResponseCache
+	if cfg.Cache.Response.Enable {
+		log.Info("response cache enabled")
+		responseCache = cache.NewResponseCache(log, cfg.Cache.Response.MaxSize, cfg.Cache.Response.StaleWhileRevalidate)
+	}
+	rs := newResponses(responseCache, cfg.Cache.Response.VaryHeaders)
+
+	if cfg.HTTP.Server.Debug.Disable {
+		log.Info("query debugging disabled")
+	}
+	dp := newDebugPolicy(cfg, redactor)
+
+	restQl := newRestQl(log, cfg, e, defaultParser, pq, rs, dp)
 
-	restQl := newRestQl(log, cfg, e, defaultParser)
+	md, err := middleware.NewDecorator(log, cfg, lifecycle)
+	if err != nil {
+		log.Error("failed to initialize middlewares", err)
+		return nil, Readiness{}, err
+	}
 
-	md := middleware.NewDecorator(log, cfg, lifecycle)
 	app := newApp(log, appOptions{MiddlewareDecorator: md})
 	app.Handle(http.MethodPost, "/validate-query", restQl.ValidateQuery)
 	app.Handle(http.MethodPost, "/run-query", restQl.RunAdHocQuery)
 	app.Handle(http.MethodGet, "/run-query/{namespace}/{queryId}/{revision}", restQl.RunSavedQuery)
 	app.Handle(http.MethodPost, "/run-query/{namespace}/{queryId}/{revision}", restQl.RunSavedQuery)
+	app.Handle(http.MethodPost, "/run-queries", restQl.RunQueries)
+	app.Handle(http.MethodPost, "/run-query/stream", restQl.RunAdHocQueryStream)
+	app.Handle(http.MethodGet, "/run-query/stream/{namespace}/{queryId}/{revision}", restQl.RunSavedQueryStream)
+	app.Handle(http.MethodPost, "/run-query/stream/{namespace}/{queryId}/{revision}", restQl.RunSavedQueryStream)
+
+	if cfg.HTTP.Server.GraphQL.Enable {
+		log.Info("graphql api enabled")
+		gqlCfg := cfg.HTTP.Server.GraphQL
+		gql := newGraphQLAPI(log, cfg.Tenant, e, parserCache, queryReader, gqlCfg.SchemaRefreshInterval, gqlCfg.MaxRootFields)
+
+		app.Handle(http.MethodGet, "/graphql", gql.Query)
+		app.Handle(http.MethodPost, "/graphql", gql.Query)
+	}
 
 	if cfg.HTTP.Server.Admin.Enable {
 		log.Info("administration api enabled")
 		mw := persistence.NewMappingWriter(log, cfg.Env, cfg.TenantMappings, db)
 		qw := persistence.NewQueryWriter(log, cfg.Queries, db)
 
-		adm := newAdmin(log, mappingReader, mw, queryReader, qw, cfg.HTTP.Server.Admin.AuthorizationCode)
+		access, err := newAPIAdminAccess(cfg)
+		if err != nil {
+			log.Error("failed to load admin tokens", err)
+			return nil, Readiness{}, err
+		}
+		audit := persistence.NewAuditLog(log, cfg.HTTP.Server.Admin.Audit.File, db)
+
+		adm := newAdmin(log, mappingReader, mw, queryReader, qw, responseCache, md.RateLimiter(), access, audit)
 		app = registerAdminEndpoints(adm, app)
 	}
 
-	return app.RequestHandler(), nil
+	readiness := newAPIReadiness(cfg, db, mappingReader, client)
+
+	return app.RequestHandler(), readiness, nil
+}
+
+// newAPIAdminAccess reads the administrative
+// tokens from the environment variables named in the configuration.
+func newAPIAdminAccess(cfg *conf.Config) (adminAccess, error) {
+	adminCfg := cfg.HTTP.Server.Admin
+
+	tokens := make([]adminToken, len(adminCfg.Tokens))
+	for i, t := range adminCfg.Tokens {
+		for _, role := range t.Roles {
+			switch role {
+			case adminRoleReadOnly, adminRoleQueryAuthor, adminRoleMappingAdmin:
+			default:
+				return adminAccess{}, errors.Wrapf(errUnknownAdminRole, "role %s of token %s", role, t.Name)
+			}
+		}
+
+		code, found := os.LookupEnv(t.Env)
+		if !found || code == "" {
+			return adminAccess{}, errors.Wrapf(errMissingAdminToken, "variable %s of token %s", t.Env, t.Name)
+		}
+
+		tokens[i] = adminToken{
+			code:     []byte(code),
+			identity: adminIdentity{name: t.Name, roles: t.Roles, tenants: t.Tenants, namespaces: t.Namespaces},
+		}
+	}
+
+	return newAdminAccess(adminCfg.AuthorizationCode, tokens), nil
+}
+
+func newAPIReadiness(cfg *conf.Config, db persistence.Database, mr persistence.MappingsReader, client domain.HTTPClient) Readiness {
+	readinessCfg := cfg.Health.Readiness
+
+	var checks []readinessCheck
+	if !cfg.Plugins.DisableDatabase {
+		checks = append(checks, databaseCheck(db))
+	}
+
+	tenants := readinessCfg.Tenants
+	if len(tenants) == 0 && cfg.Tenant != "" {
+		tenants = []string{cfg.Tenant}
+	}
+	for _, tenant := range tenants {
+		checks = append(checks, mappingsCheck(mr, tenant))
+	}
+
+	for name, url := range readinessCfg.Upstreams {
+		checks = append(checks, upstreamCheck(client, name, url))
+	}
+
+	return newReadiness(readinessCfg.Timeout, checks...)
 }
 
 // registerAdminEndpoints adds handlers for administrative operations
@@ -97,17 +213,34 @@ func registerAdminEndpoints(adm *administrator, apiApp app) app {
 	apiApp.Handle(http.MethodPatch, "/admin/namespace/{namespace}/query/{queryId}", adm.UpdateQueryArchiving)
 	apiApp.Handle(http.MethodPost, "/admin/namespace/{namespace}/query/{queryId}", adm.CreateQueryRevision)
 
+	if adm.responses != nil {
+		apiApp.Handle(http.MethodDelete, "/admin/cache/response", adm.PurgeResponseCache)
+	}
+
+	if adm.rateLimiter != nil {
+		apiApp.Handle(http.MethodGet, "/admin/rate-limit", adm.RateLimitUsage)
+	}
+
+	if adm.audit != nil {
+		apiApp.Handle(http.MethodGet, "/admin/audit", adm.AuditLog)
+	}
+
 	return apiApp
 }
 
 // Health constructs a handler for system checks endpoints
-func Health(log restql.Logger, cfg *conf.Config) fasthttp.RequestHandler {
+func Health(log restql.Logger, cfg *conf.Config, readiness Readiness) fasthttp.RequestHandler {
 	app := newApp(log, appOptions{})
-	check := newCheck(cfg.Build)
+	check := newCheck(log, cfg.Build, readiness)
 
 	app.Handle(http.MethodGet, "/health", check.Health)
+	app.Handle(http.MethodGet, "/health/live", check.Live)
+	app.Handle(http.MethodGet, "/health/ready", check.Ready)
 	app.Handle(http.MethodGet, "/resource-status", check.ResourceStatus)
 
+	m := newMetricsExporter()
+	app.Handle(http.MethodGet, "/metrics", m.Metrics)
+
 	return app.RequestHandler()
 }
 
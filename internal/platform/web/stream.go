@@ -0,0 +1,220 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web/middleware"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/valyala/fasthttp"
+)
+
+const eventStreamContentType = "text/event-stream"
+
+// Names of the events sent when streaming a query result.
+const (
+	statementEvent = "statement"
+	doneEvent      = "done"
+	errorEvent     = "error"
+)
+
+// StreamStatementResult represents the client format of
+// a statement result sent as soon as it is done.
+type StreamStatementResult struct {
+	Resource string `json:"resource"`
+	StatementResult
+}
+
+// StreamQueryDone represents the client format of the last
+// event sent when streaming a query result.
+type StreamQueryDone struct {
+	Status       int    `json:"status"`
+	CacheControl string `json:"cache-control,omitempty"`
+}
+
+// StreamError represents the client format of a query
+// failure happening after the stream has started.
+type StreamError struct {
+	Status int    `json:"status"`
+	Error  string `json:"error"`
+}
+
+type streamEvent struct {
+	name string
+	data []byte
+}
+
+type queryEvaluation func(ctx context.Context) (domain.Resources, error)
+
+// RespondStream evaluates the query while sending each statement result
+// to the client as a Server-Sent Event, as soon as it is done, followed
+// by an event with the final status code and cache control.
+//
+// The evaluation happens after the handler returns, when the response
+// body is written, hence it cannot be cancelled by the request context.
+// Its values, like the client identity and the transaction span, and its
+// deadline are preserved, and the middlewares finish the transaction only
+// once the stream is over.
+func RespondStream(reqCtx *fasthttp.RequestCtx, ctx context.Context, debug Debugging, toStatusCode map[error]int, evaluate queryEvaluation) {
+	log := restql.GetLogger(ctx)
+	deadline, hasDeadline := ctx.Deadline()
+
+	reqCtx.Response.Header.SetContentType(eventStreamContentType)
+	reqCtx.Response.Header.Set("Cache-Control", "no-cache")
+	reqCtx.SetStatusCode(fasthttp.StatusOK)
+
+	completion := middleware.NewStreamCompletion(reqCtx)
+
+	reqCtx.SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer completion.Done()
+
+		streamCtx, cancel := newStreamContext(ctx, deadline, hasDeadline)
+		defer cancel()
+
+		queue := newStreamQueue()
+		send := func(name string, data interface{}) {
+			event, err := newStreamEvent(name, data)
+			if err != nil {
+				log.Error("failed to encode stream event", err, "event", name)
+				return
+			}
+
+			queue.push(event)
+		}
+
+		go func() {
+			defer queue.close()
+
+			listener := func(resourceID domain.ResourceID, response interface{}) {
+				result, err := parseResource(response, debug)
+				if err != nil {
+					log.Error("failed to parse statement result", err, "resource", resourceID)
+					return
+				}
+
+				send(statementEvent, StreamStatementResult{Resource: string(resourceID), StatementResult: result})
+			}
+
+			result, err := evaluate(eval.WithStatementListener(streamCtx, listener))
+			if err != nil {
+				log.Error("failed to evaluate streamed query", err)
+				send(errorEvent, StreamError{Status: findStatusCode(toStatusCode, err), Error: err.Error()})
+				return
+			}
+
+			cacheControl := makeCacheControlHeaders(result)["Cache-Control"]
+			send(doneEvent, StreamQueryDone{Status: CalculateStatusCode(result), CacheControl: cacheControl})
+		}()
+
+		failed := false
+		for {
+			events, open := queue.pop()
+			for _, event := range events {
+				if failed {
+					break
+				}
+
+				err := writeStreamEvent(w, event)
+				if err != nil {
+					log.Debug("failed to write stream event, cancelling query", "error", err)
+					failed = true
+					cancel()
+				}
+			}
+
+			if !open {
+				return
+			}
+		}
+	})
+}
+
+// streamQueue holds the events waiting to be written to the client.
+// Pushing never blocks, since the statement listener is called from
+// the query evaluation, which must not wait on a slow client. It is
+// bounded by the number of statements in the query.
+type streamQueue struct {
+	mu     sync.Mutex
+	events []streamEvent
+	closed bool
+	ready  chan struct{}
+}
+
+func newStreamQueue() *streamQueue {
+	return &streamQueue{ready: make(chan struct{}, 1)}
+}
+
+func (q *streamQueue) push(event streamEvent) {
+	q.mu.Lock()
+	q.events = append(q.events, event)
+	q.mu.Unlock()
+
+	q.notify()
+}
+
+func (q *streamQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+
+	q.notify()
+}
+
+func (q *streamQueue) notify() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// pop waits for events and returns all of them, along with
+// false when no more events will be pushed.
+func (q *streamQueue) pop() ([]streamEvent, bool) {
+	for {
+		q.mu.Lock()
+		events, closed := q.events, q.closed
+		q.events = nil
+		q.mu.Unlock()
+
+		if len(events) > 0 || closed {
+			return events, !closed
+		}
+
+		<-q.ready
+	}
+}
+
+func newStreamContext(parent context.Context, deadline time.Time, hasDeadline bool) (context.Context, context.CancelFunc) {
+	ctx := domain.DetachContext(parent)
+	if hasDeadline {
+		return context.WithDeadline(ctx, deadline)
+	}
+
+	return context.WithCancel(ctx)
+}
+
+// newStreamEvent encodes the event data immediately,
+// since the result may be changed once the listener returns.
+func newStreamEvent(name string, data interface{}) (streamEvent, error) {
+	d, err := json.Marshal(data)
+	if err != nil {
+		return streamEvent{}, err
+	}
+
+	return streamEvent{name: name, data: d}, nil
+}
+
+func writeStreamEvent(w *bufio.Writer, event streamEvent) error {
+	w.WriteString("event: ")
+	w.WriteString(event.name)
+	w.WriteString("\ndata: ")
+	w.Write(event.data)
+	w.WriteString("\n\n")
+
+	return w.Flush()
+}
@@ -0,0 +1,76 @@
+package web_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/eval"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/web"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+	"github.com/pkg/errors"
+	"github.com/valyala/fasthttp"
+)
+
+func TestRespondStream(t *testing.T) {
+	toStatusCode := map[error]int{eval.ErrTimeout: 408}
+
+	tests := []struct {
+		name     string
+		result   domain.Resources
+		err      error
+		expected string
+	}{
+		{
+			"should send done event with status and cache control",
+			domain.Resources{
+				"hero": restql.DoneResource{
+					Status:       200,
+					CacheControl: restql.ResourceCacheControl{MaxAge: restql.ResourceCacheControlValue{Exist: true, Time: 60}},
+					ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, map[string]interface{}{"name": "Batman"}),
+				},
+			},
+			nil,
+			"event: done\ndata: {\"status\":200,\"cache-control\":\"max-age=60\"}\n\n",
+		},
+		{
+			"should send error event when evaluation fails",
+			nil,
+			errors.Wrap(eval.ErrTimeout, "query failed"),
+			"event: error\ndata: {\"status\":408,\"error\":\"query failed: timeout\"}\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqCtx := &fasthttp.RequestCtx{}
+			evaluate := func(ctx context.Context) (domain.Resources, error) {
+				return tt.result, tt.err
+			}
+
+			web.RespondStream(reqCtx, context.Background(), web.Debugging{}, toStatusCode, evaluate)
+
+			test.Equal(t, string(reqCtx.Response.Header.ContentType()), "text/event-stream")
+			test.Equal(t, string(reqCtx.Response.Body()), tt.expected)
+		})
+	}
+}
+
+func TestRespondStreamKeepsRequestContextValues(t *testing.T) {
+	principal := restql.Principal{Method: restql.AuthMethodAPIKey, Subject: "mobile"}
+	ctx, cancel := context.WithCancel(restql.WithPrincipal(context.Background(), principal))
+
+	var got restql.Principal
+	evaluate := func(ctx context.Context) (domain.Resources, error) {
+		got, _ = restql.GetPrincipal(ctx)
+		return domain.Resources{}, ctx.Err()
+	}
+
+	reqCtx := &fasthttp.RequestCtx{}
+	web.RespondStream(reqCtx, ctx, web.Debugging{}, nil, evaluate)
+	cancel()
+
+	test.Equal(t, string(reqCtx.Response.Body()), "event: done\ndata: {\"status\":200}\n\n")
+	test.Equal(t, got, principal)
+}
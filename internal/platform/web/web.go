@@ -21,6 +21,7 @@ type app struct {
 
 func newApp(log restql.Logger, o appOptions) app {
 	r := router.New()
+	r.SaveMatchedRoutePath = true
 	r.NotFound = func(ctx *fasthttp.RequestCtx) { ctx.Response.SetBodyString("There is nothing here. =/") }
 
 	return app{router: r, log: log, options: o}
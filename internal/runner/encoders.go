@@ -1,14 +1,26 @@
 package runner
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"net/url"
+	"sort"
+	"strings"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 )
 
+const (
+	formContentType    = "application/x-www-form-urlencoded"
+	xmlContentType     = "application/xml"
+	xmlAttributePrefix = "@"
+	xmlTextKey         = "#text"
+)
+
 // ApplyEncoders transform parameter values with encoder functions applied
 // into a Resource collection with the values processed.
 func ApplyEncoders(resources domain.Resources, log restql.Logger) domain.Resources {
@@ -45,6 +57,20 @@ func applyEncoderToBody(log restql.Logger, body interface{}) interface{} {
 		return applyEncoderToBody(log, body.Target())
 	case domain.Flatten:
 		return applyFlattenEncoder(log, applyEncoderToBody(log, body.Target()))
+	case domain.AsForm:
+		target := body.Target()
+		if _, ok := target.(domain.Chain); ok {
+			return body
+		}
+
+		return applyFormEncoder(log, applyEncoderToBody(log, target))
+	case domain.AsXML:
+		target := body.Target()
+		if _, ok := target.(domain.Chain); ok {
+			return body
+		}
+
+		return applyXMLEncoder(log, applyEncoderToBody(log, target))
 	case domain.Function:
 		return body.Map(func(target interface{}) interface{} {
 			return applyEncoderToBody(log, target)
@@ -77,6 +103,20 @@ func applyEncoderToValue(log restql.Logger, value interface{}) interface{} {
 		}
 
 		return applyFlattenEncoder(log, applyEncoderToValue(log, value.Target()))
+	case domain.AsForm:
+		target := value.Target()
+		if _, ok := target.(domain.Chain); ok {
+			return value
+		}
+
+		return applyFormEncoder(log, applyEncoderToValue(log, value.Target()))
+	case domain.AsXML:
+		target := value.Target()
+		if _, ok := target.(domain.Chain); ok {
+			return value
+		}
+
+		return applyXMLEncoder(log, applyEncoderToValue(log, value.Target()))
 	case domain.Function:
 		return value.Map(func(target interface{}) interface{} {
 			return applyEncoderToValue(log, target)
@@ -121,6 +161,128 @@ func applyFlattenEncoder(log restql.Logger, value interface{}) interface{} {
 	return value
 }
 
+func applyFormEncoder(log restql.Logger, value interface{}) interface{} {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		log.Warn("form encoder used on non object value", "value", value)
+		return value
+	}
+
+	form := url.Values{}
+	for key, v := range m {
+		appendFormValue(form, key, v)
+	}
+
+	return domain.EncodedBody{ContentType: formContentType, Data: form.Encode()}
+}
+
+func appendFormValue(form url.Values, key string, value interface{}) {
+	switch value := value.(type) {
+	case nil:
+		return
+	case []interface{}:
+		for _, v := range value {
+			appendFormValue(form, key, v)
+		}
+	case map[string]interface{}:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return
+		}
+		form.Add(key, string(data))
+	default:
+		form.Add(key, fmt.Sprintf("%v", value))
+	}
+}
+
+func applyXMLEncoder(log restql.Logger, value interface{}) interface{} {
+	m, ok := value.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		log.Warn("xml encoder used on value without a single root element", "value", value)
+		return value
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+
+	enc := xml.NewEncoder(&buf)
+	for name, content := range m {
+		err := encodeXMLElement(enc, name, content)
+		if err != nil {
+			log.Debug("failed to apply xml encoder", "target", value, "error", err)
+			return value
+		}
+	}
+
+	err := enc.Flush()
+	if err != nil {
+		log.Debug("failed to apply xml encoder", "target", value, "error", err)
+		return value
+	}
+
+	return domain.EncodedBody{ContentType: xmlContentType, Data: buf.String()}
+}
+
+// encodeXMLElement writes a value as an XML element named after its key.
+// Object keys prefixed with `@` become attributes, the `#text` key becomes
+// the element text content and lists repeat the element for each item.
+func encodeXMLElement(enc *xml.Encoder, name string, value interface{}) error {
+	if list, ok := value.([]interface{}); ok {
+		for _, item := range list {
+			err := encodeXMLElement(enc, name, item)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		if value == nil {
+			return enc.EncodeElement("", start)
+		}
+		return enc.EncodeElement(fmt.Sprintf("%v", value), start)
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var children []string
+	for _, k := range keys {
+		if strings.HasPrefix(k, xmlAttributePrefix) {
+			attr := xml.Attr{Name: xml.Name{Local: strings.TrimPrefix(k, xmlAttributePrefix)}, Value: fmt.Sprintf("%v", m[k])}
+			start.Attr = append(start.Attr, attr)
+			continue
+		}
+		children = append(children, k)
+	}
+
+	err := enc.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+
+	for _, k := range children {
+		if k == xmlTextKey {
+			err = enc.EncodeToken(xml.CharData(fmt.Sprintf("%v", m[k])))
+		} else {
+			err = encodeXMLElement(enc, k, m[k])
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return enc.EncodeToken(start.End())
+}
+
 func flatten(ii []interface{}) []interface{} {
 	var res []interface{}
 	for _, i := range ii {
@@ -1,6 +1,7 @@
 package runner_test
 
 import (
+	"encoding/xml"
 	"testing"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
@@ -245,6 +246,61 @@ func TestApplyEncoders(t *testing.T) {
 				}},
 			}},
 		},
+		{
+			"should apply form encoder to with body",
+			domain.Resources{"hero": domain.Statement{
+				Method:   "to",
+				Resource: "hero",
+				With:     domain.Params{Body: domain.AsForm{Value: map[string]interface{}{"name": "batman", "weapons": []interface{}{"batarang", "grapnel"}}}},
+			}},
+			domain.Resources{"hero": domain.Statement{
+				Method:   "to",
+				Resource: "hero",
+				With: domain.Params{Body: domain.EncodedBody{
+					ContentType: "application/x-www-form-urlencoded",
+					Data:        "name=batman&weapons=batarang&weapons=grapnel",
+				}},
+			}},
+		},
+		{
+			"should apply xml encoder to with value",
+			domain.Resources{"hero": domain.Statement{
+				Method:   "to",
+				Resource: "hero",
+				With: domain.Params{Values: map[string]interface{}{
+					"hero": domain.AsBody{Value: domain.AsXML{Value: map[string]interface{}{
+						"hero": map[string]interface{}{
+							"@id":     "1",
+							"name":    "batman",
+							"weapons": []interface{}{"batarang", "grapnel"},
+						},
+					}}},
+				}},
+			}},
+			domain.Resources{"hero": domain.Statement{
+				Method:   "to",
+				Resource: "hero",
+				With: domain.Params{Values: map[string]interface{}{
+					"hero": domain.AsBody{Value: domain.EncodedBody{
+						ContentType: "application/xml",
+						Data:        xml.Header + `<hero id="1"><name>batman</name><weapons>batarang</weapons><weapons>grapnel</weapons></hero>`,
+					}},
+				}},
+			}},
+		},
+		{
+			"should not apply xml encoder to chained value",
+			domain.Resources{"hero": domain.Statement{
+				Method:   "to",
+				Resource: "hero",
+				With:     domain.Params{Body: domain.AsXML{Value: domain.Chain{"done-resource", "hero"}}},
+			}},
+			domain.Resources{"hero": domain.Statement{
+				Method:   "to",
+				Resource: "hero",
+				With:     domain.Params{Body: domain.AsXML{Value: domain.Chain{"done-resource", "hero"}}},
+			}},
+		},
 	}
 
 	logger := noOpLogger{}
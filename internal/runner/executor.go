@@ -5,6 +5,10 @@ import (
 	"time"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/accesslog"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/headers"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/metrics"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/tracing"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 )
 
@@ -16,11 +20,12 @@ type Executor struct {
 	log             restql.Logger
 	resourceTimeout time.Duration
 	forwardPrefix   string
+	headers         *headers.Policies
 }
 
 // NewExecutor constructs an instance of Executor.
-func NewExecutor(log restql.Logger, client domain.HTTPClient, resourceTimeout time.Duration, forwardPrefix string) Executor {
-	return Executor{client: client, log: log, resourceTimeout: resourceTimeout, forwardPrefix: forwardPrefix}
+func NewExecutor(log restql.Logger, client domain.HTTPClient, resourceTimeout time.Duration, forwardPrefix string, headerPolicies *headers.Policies) Executor {
+	return Executor{client: client, log: log, resourceTimeout: resourceTimeout, forwardPrefix: forwardPrefix, headers: headerPolicies}
 }
 
 // DoStatement process a single statement into a result by executing the relevant HTTP calls to the upstream dependency.
@@ -46,18 +51,39 @@ func (e Executor) DoStatement(ctx context.Context, statement domain.Statement, q
 		return emptyChainedResponse
 	}
 
-	request := MakeRequest(e.resourceTimeout, e.forwardPrefix, statement, queryCtx)
+	ctx, span := tracing.Start(ctx, "upstream request", tracing.SpanKindClient)
+	defer span.End()
+	span.SetAttribute("restql.resource", statement.Resource)
+
+	headerPolicy := e.headers.Get(queryCtx.Options.Tenant, statement.Resource)
+	request := MakeRequest(ctx, e.resourceTimeout, e.forwardPrefix, statement, queryCtx, headerPolicy)
+	span.SetAttribute("http.method", request.Method)
+	span.SetAttribute("http.url", request.Schema+"://"+request.Host+request.Path)
 
 	log.Debug("executing request for statement", "resource", statement.Resource, "method", statement.Method, "request", request)
 
-	response, err := e.client.Do(ctx, request)
+	upstreamCtx := domain.WithUpstream(ctx, domain.Upstream{Tenant: queryCtx.Options.Tenant, Resource: statement.Resource})
+	start := time.Now()
+	done := metrics.UpstreamRequestStarted()
+	response, err := e.client.Do(upstreamCtx, request)
+	done()
+	duration := time.Since(start)
 	if err != nil {
 		errorResponse := NewErrorResponse(log, err, request, response, drOptions)
+		errorResponse.ExposedHeaders = headerPolicy.Expose(errorResponse.ResponseHeaders)
+		metrics.ObserveStatement(queryCtx.Options.Tenant, statement.Resource, errorResponse.Status, duration)
+		accesslog.FromContext(ctx).AddStatement(statement.Resource, statement.Method, errorResponse.Status, false, start, duration)
+		span.SetAttribute("http.status_code", errorResponse.Status)
+		span.SetError(err)
 		log.Debug("request execution failed", "error", err, "resource", statement.Resource, "method", statement.Method, "response", errorResponse)
 		return errorResponse
 	}
 
 	dr := NewDoneResource(request, response, drOptions)
+	dr.ExposedHeaders = headerPolicy.Expose(dr.ResponseHeaders)
+	metrics.ObserveStatement(queryCtx.Options.Tenant, statement.Resource, dr.Status, duration)
+	accesslog.FromContext(ctx).AddStatement(statement.Resource, statement.Method, dr.Status, dr.Success, start, duration)
+	span.SetAttribute("http.status_code", dr.Status)
 
 	log.Debug("request execution done", "resource", statement.Resource, "method", statement.Method, "response", dr)
 
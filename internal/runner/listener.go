@@ -0,0 +1,26 @@
+package runner
+
+import (
+	"context"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+)
+
+// StatementListener is notified of each statement result
+// as soon as it is done, before the query finishes.
+// It is called synchronously by the Runner, hence it must
+// not retain or modify the response.
+type StatementListener func(resourceID domain.ResourceID, response interface{})
+
+type statementListenerKey struct{}
+
+// WithStatementListener returns a copy of the context that
+// makes the Runner notify the listener of each statement result.
+func WithStatementListener(ctx context.Context, listener StatementListener) context.Context {
+	return context.WithValue(ctx, statementListenerKey{}, listener)
+}
+
+func getStatementListener(ctx context.Context) StatementListener {
+	listener, _ := ctx.Value(statementListenerKey{}).(StatementListener)
+	return listener
+}
@@ -1,23 +1,19 @@
 package runner
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/headers"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/tracing"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 )
 
-var disallowedHeaders = []string{
-	"host",
-	"content-type",
-	"content-length",
-	"connection",
-	"origin",
-	"accept-encoding",
-}
+const defaultContentType = "application/json"
 
 var queryMethodToHTTPMethod = map[string]string{
 	domain.FromMethod:   http.MethodGet,
@@ -28,10 +24,13 @@ var queryMethodToHTTPMethod = map[string]string{
 }
 
 // MakeRequest builds a HTTPRequest from a statement.
-func MakeRequest(defaultResourceTimeout time.Duration, forwardPrefix string, statement domain.Statement, queryCtx restql.QueryContext) restql.HTTPRequest {
+// The client headers are forwarded according to the
+// header policy of the resource and the trace context
+// of the span in ctx, if any, is propagated through
+// the request headers.
+func MakeRequest(ctx context.Context, defaultResourceTimeout time.Duration, forwardPrefix string, statement domain.Statement, queryCtx restql.QueryContext, policy *headers.Policy) restql.HTTPRequest {
 	mapping := queryCtx.Mappings[statement.Resource]
 	method := queryMethodToHTTPMethod[statement.Method]
-	headers := makeHeaders(statement, queryCtx)
 	path := mapping.PathWithParams(statement.With.Values)
 	timeout := parseTimeout(defaultResourceTimeout, statement)
 
@@ -43,14 +42,22 @@ func MakeRequest(defaultResourceTimeout time.Duration, forwardPrefix string, sta
 		Host:    mapping.Host(),
 		Path:    path,
 		Query:   queryParams,
-		Headers: headers,
 		Timeout: timeout,
 	}
 
+	contentType := defaultContentType
 	if statement.Method == domain.ToMethod || statement.Method == domain.UpdateMethod || statement.Method == domain.IntoMethod {
-		req.Body = makeBody(statement, mapping)
+		body := makeBody(statement, mapping)
+		if encoded, ok := body.(domain.EncodedBody); ok {
+			body = encoded.Data
+			contentType = encoded.ContentType
+		}
+
+		req.Body = body
 	}
 
+	req.Headers = makeHeaders(ctx, statement, queryCtx, contentType, policy)
+
 	return req
 }
 
@@ -186,43 +193,29 @@ func getForwardParams(forwardPrefix string, queryCtx restql.QueryContext) map[st
 	return r
 }
 
-func makeHeaders(statement domain.Statement, queryCtx restql.QueryContext) map[string]string {
-	headers := getForwardHeaders(queryCtx)
+func makeHeaders(ctx context.Context, statement domain.Statement, queryCtx restql.QueryContext, contentType string, policy *headers.Policy) map[string]string {
+	h := policy.Forward(queryCtx.Input.Headers)
 	for key, value := range statement.Headers {
 		str, ok := value.(string)
 		if !ok {
 			continue
 		}
 		key = http.CanonicalHeaderKey(key)
-		headers[key] = str
+		h[key] = str
 	}
 
-	_, found := headers["Content-Type"]
+	_, found := h["Content-Type"]
 	if !found {
-		headers["Content-Type"] = "application/json"
+		h["Content-Type"] = contentType
 	}
 
-	return headers
-}
+	policy.Inject(h)
 
-func isDisallowedHeader(header string) bool {
-	for _, disallowedHeader := range disallowedHeaders {
-		if strings.EqualFold(header, disallowedHeader) {
-			return true
-		}
+	if traceParent := tracing.TraceParent(ctx); traceParent != "" {
+		h[http.CanonicalHeaderKey(tracing.TraceParentHeader)] = traceParent
 	}
-	return false
-}
 
-func getForwardHeaders(queryCtx restql.QueryContext) map[string]string {
-	r := make(map[string]string)
-	for k, v := range queryCtx.Input.Headers {
-		if !isDisallowedHeader(k) {
-			k = http.CanonicalHeaderKey(k)
-			r[k] = v
-		}
-	}
-	return r
+	return h
 }
 
 func parseTimeout(defaultResourceTimeout time.Duration, statement domain.Statement) time.Duration {
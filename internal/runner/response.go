@@ -58,6 +58,18 @@ func NewErrorResponse(log restql.Logger, err error, request restql.HTTPRequest,
 	}
 }
 
+// NewTimedOutResponse builds a DoneResource for a statement
+// not completed before the query timed out.
+func NewTimedOutResponse(log restql.Logger, options DoneResourceOptions) restql.DoneResource {
+	rb := restql.NewResponseBodyFromValue(log, "The request was not completed before the query timed out")
+	return restql.DoneResource{
+		Status:       408,
+		Success:      false,
+		IgnoreErrors: options.IgnoreErrors,
+		ResponseBody: rb,
+	}
+}
+
 // NewEmptyChainedResponse builds a DoneResource for a statement
 // with unresolved chain parameters.
 func NewEmptyChainedResponse(log restql.Logger, params []string, options DoneResourceOptions) restql.DoneResource {
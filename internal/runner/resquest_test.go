@@ -1,10 +1,12 @@
 package runner_test
 
 import (
+	"context"
 	"net/http"
 	"testing"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/tracing"
 	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"github.com/b2wdigital/restQL-golang/v6/test"
@@ -110,6 +112,12 @@ func TestMakeRequest(t *testing.T) {
 			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, "http://hero.io/api")}},
 			restql.HTTPRequest{Method: http.MethodPost, Schema: "http", Host: "hero.io", Path: "/api", Query: map[string]interface{}{}, Body: []interface{}{"1", "2", "3"}, Headers: map[string]string{"Content-Type": "application/json"}},
 		},
+		{
+			"should make post request with encoded body and its content type",
+			domain.Statement{Method: domain.ToMethod, Resource: "hero", With: domain.Params{Body: domain.EncodedBody{ContentType: "application/x-www-form-urlencoded", Data: "name=batman"}}},
+			restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": mapping(t, "http://hero.io/api")}},
+			restql.HTTPRequest{Method: http.MethodPost, Schema: "http", Host: "hero.io", Path: "/api", Query: map[string]interface{}{}, Body: "name=batman", Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"}},
+		},
 		{
 			"should make request with case-insensitive merged headers",
 			domain.Statement{Method: domain.FromMethod, Resource: "hero", Headers: map[string]interface{}{"X-TID": "1234567890", "accept": "application/json"}},
@@ -134,13 +142,30 @@ func TestMakeRequest(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := runner.MakeRequest(0, forwardPrefix, tt.statement, tt.queryCtx)
+			got := runner.MakeRequest(context.Background(), 0, forwardPrefix, tt.statement, tt.queryCtx, nil)
 
 			test.Equal(t, got, tt.expected)
 		})
 	}
 }
 
+func TestMakeRequestWithTraceContext(t *testing.T) {
+	tracing.SetTracer(tracing.NewTracer(nil, 1))
+	defer tracing.SetTracer(nil)
+
+	ctx, span := tracing.Start(context.Background(), "upstream request", tracing.SpanKindClient)
+
+	statement := domain.Statement{Method: domain.FromMethod, Resource: "hero"}
+	queryCtx := restql.QueryContext{
+		Mappings: map[string]restql.Mapping{"hero": mapping(t, "http://hero.io/api")},
+		Input:    restql.QueryInput{Headers: map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}},
+	}
+
+	got := runner.MakeRequest(ctx, 0, "", statement, queryCtx, nil)
+
+	test.Equal(t, got.Headers["Traceparent"], span.Context().TraceParent())
+}
+
 func mapping(t *testing.T, url string) restql.Mapping {
 	m, err := restql.NewMapping("test-resource", url)
 	if err != nil {
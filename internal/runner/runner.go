@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/metrics"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/tracing"
 	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 	"github.com/pkg/errors"
 )
@@ -48,16 +50,23 @@ func NewRunner(log restql.Logger, executor Executor, options Options) Runner {
 	return Runner{
 		log:              log,
 		executor:         executor,
-		queryLimiter:     newLimiter(int32(options.MaxConcurrentQueries)),
-		goroutineLimiter: newLimiter(int32(options.MaxConcurrentGoroutines)),
+		queryLimiter:     newLimiter("query", int32(options.MaxConcurrentQueries)),
+		goroutineLimiter: newLimiter("goroutine", int32(options.MaxConcurrentGoroutines)),
 		options:          options,
 	}
 }
 
 // ExecuteQuery process a query into a Resource collection.
-func (r Runner) ExecuteQuery(ctx context.Context, query domain.Query, queryCtx restql.QueryContext) (domain.Resources, error) {
+func (r Runner) ExecuteQuery(ctx context.Context, query domain.Query, queryCtx restql.QueryContext) (resources domain.Resources, err error) {
 	log := restql.GetLogger(ctx)
 
+	ctx, span := tracing.Start(ctx, "execute query", tracing.SpanKindInternal)
+	span.SetAttribute("restql.tenant", queryCtx.Options.Tenant)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
+
 	success := r.queryLimiter.Acquire()
 	if !success {
 		return nil, ErrMaxQueryDenied
@@ -73,7 +82,7 @@ func (r Runner) ExecuteQuery(ctx context.Context, query domain.Query, queryCtx r
 	}
 	defer cancel()
 
-	resources, err := r.initializeResources(query)
+	resources, err = r.initializeResources(query)
 	if err != nil {
 		return nil, err
 	}
@@ -85,12 +94,19 @@ func (r Runner) ExecuteQuery(ctx context.Context, query domain.Query, queryCtx r
 	outputCh := make(chan domain.Resources)
 	errorCh := make(chan error)
 
+	var partialCh chan domain.Resources
+	if r.parsePartialOnTimeout(query) {
+		partialCh = make(chan domain.Resources, 1)
+	}
+
 	stateWorker := &stateWorker{
 		log:              log,
 		requestCh:        requestCh,
 		resultCh:         resultCh,
 		outputCh:         outputCh,
 		errorCh:          errorCh,
+		partialCh:        partialCh,
+		listener:         getStatementListener(ctx),
 		state:            state,
 		ctx:              ctx,
 		goroutineLimiter: r.goroutineLimiter,
@@ -116,6 +132,11 @@ func (r Runner) ExecuteQuery(ctx context.Context, query domain.Query, queryCtx r
 		log.Debug("an error occurred when running the query", "error", err)
 		return nil, err
 	case <-ctx.Done():
+		if partialCh != nil {
+			log.Debug("query timed out, returning partial result")
+			return <-partialCh, nil
+		}
+
 		log.Debug("query timed out")
 		return nil, ErrQueryTimedOut
 	}
@@ -135,6 +156,11 @@ func (r Runner) parseQueryTimeout(query domain.Query) (time.Duration, bool) {
 	return time.Millisecond * time.Duration(duration), true
 }
 
+func (r Runner) parsePartialOnTimeout(query domain.Query) bool {
+	partial, ok := query.Use["partial-on-timeout"].(bool)
+	return ok && partial
+}
+
 func (r Runner) initializeResources(query domain.Query) (domain.Resources, error) {
 	resources := domain.NewResources(query.Statements)
 
@@ -171,12 +197,16 @@ type stateWorker struct {
 	resultCh         chan result
 	outputCh         chan domain.Resources
 	errorCh          chan error
+	partialCh        chan domain.Resources
+	listener         StatementListener
 	state            *State
 	ctx              context.Context
 	goroutineLimiter *limiter
 }
 
 func (sw *stateWorker) Run() {
+	defer sw.writePartialResult()
+
 	for !sw.state.HasFinished() {
 		availableResources := sw.state.Available()
 		for resourceID := range availableResources {
@@ -214,6 +244,9 @@ func (sw *stateWorker) Run() {
 		select {
 		case result := <-sw.resultCh:
 			sw.state.UpdateDone(result.ResourceIdentifier, result.Response)
+			if sw.listener != nil {
+				sw.listener(result.ResourceIdentifier, result.Response)
+			}
 		case <-sw.ctx.Done():
 			return
 		}
@@ -225,6 +258,17 @@ func (sw *stateWorker) Run() {
 	}
 }
 
+// writePartialResult sends the current state, with the pending
+// statements reported as timed out, when the query is interrupted
+// and partial results were requested.
+func (sw *stateWorker) writePartialResult() {
+	if sw.partialCh == nil || sw.ctx.Err() == nil {
+		return
+	}
+
+	sw.partialCh <- sw.state.Partial(sw.log)
+}
+
 type requestWorker struct {
 	requestCh        chan request
 	resultCh         chan result
@@ -341,18 +385,21 @@ func writeResult(ctx context.Context, out chan result, r result) {
 type limiter struct {
 	mu sync.Mutex
 
+	name   string
 	limit  int32
 	bucket int32
 }
 
-func newLimiter(limit int32) *limiter {
-	return &limiter{limit: limit, bucket: limit}
+func newLimiter(name string, limit int32) *limiter {
+	metrics.SetLimiterCapacity(name, int(limit))
+	return &limiter{name: name, limit: limit, bucket: limit}
 }
 
 // Acquire will try to reserve a token on limiter.
 // If the limiter bucket is full (default case on select), it will fail.
 func (l *limiter) Acquire() bool {
 	if l.limit <= 0 {
+		metrics.LimiterAcquired(l.name)
 		return true
 	}
 
@@ -361,16 +408,19 @@ func (l *limiter) Acquire() bool {
 
 	if l.bucket <= 0 {
 		l.bucket = 0
+		metrics.LimiterRejected(l.name)
 		return false
 	}
 
 	l.bucket = l.bucket - 1
+	metrics.LimiterAcquired(l.name)
 	return true
 }
 
 // Release will return a token to the bucket with a non-blocking read.
 func (l *limiter) Release() {
 	if l.limit <= 0 {
+		metrics.LimiterReleased(l.name)
 		return
 	}
 
@@ -383,4 +433,5 @@ func (l *limiter) Release() {
 	}
 
 	l.bucket = l.bucket + 1
+	metrics.LimiterReleased(l.name)
 }
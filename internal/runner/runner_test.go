@@ -0,0 +1,112 @@
+package runner_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/conf"
+	"github.com/b2wdigital/restQL-golang/v6/internal/platform/headers"
+	"github.com/b2wdigital/restQL-golang/v6/internal/runner"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+type slowUpstreamClient struct{}
+
+func (slowUpstreamClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	if request.Host == "villain.api" {
+		<-ctx.Done()
+		return restql.HTTPResponse{}, ctx.Err()
+	}
+
+	return restql.HTTPResponse{StatusCode: 200, Body: restql.NewResponseBodyFromValue(test.NoOpLogger, "ok")}, nil
+}
+
+func TestExecuteQueryOnTimeout(t *testing.T) {
+	heroMapping, err := restql.NewMapping("hero", "http://hero.api/hero")
+	test.VerifyError(t, err)
+	villainMapping, err := restql.NewMapping("villain", "http://villain.api/villain")
+	test.VerifyError(t, err)
+
+	queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": heroMapping, "villain": villainMapping}}
+	executor := runner.NewExecutor(test.NoOpLogger, slowUpstreamClient{}, time.Second, "", nil)
+	r := runner.NewRunner(test.NoOpLogger, executor, runner.Options{GlobalQueryTimeout: time.Second})
+
+	statements := []domain.Statement{
+		{Method: "from", Resource: "hero"},
+		{Method: "from", Resource: "villain"},
+	}
+
+	t.Run("should fail query when partial result is not enabled", func(t *testing.T) {
+		query := domain.Query{Use: domain.Modifiers{"timeout": 50}, Statements: statements}
+
+		_, err := r.ExecuteQuery(context.Background(), query, queryCtx)
+		if err != runner.ErrQueryTimedOut {
+			t.Fatalf("expected query timed out error, got %v", err)
+		}
+	})
+
+	t.Run("should return completed statements when partial result is enabled", func(t *testing.T) {
+		query := domain.Query{Use: domain.Modifiers{"timeout": 50, "partial-on-timeout": true}, Statements: statements}
+
+		got, err := r.ExecuteQuery(context.Background(), query, queryCtx)
+		test.VerifyError(t, err)
+
+		test.Equal(t, got["hero"].(restql.DoneResource).Status, 200)
+		test.Equal(t, got["villain"].(restql.DoneResource).Status, 408)
+	})
+}
+
+func TestExecuteQueryWithStatementListener(t *testing.T) {
+	heroMapping, err := restql.NewMapping("hero", "http://hero.api/hero")
+	test.VerifyError(t, err)
+	sidekickMapping, err := restql.NewMapping("sidekick", "http://sidekick.api/sidekick")
+	test.VerifyError(t, err)
+
+	queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": heroMapping, "sidekick": sidekickMapping}}
+	executor := runner.NewExecutor(test.NoOpLogger, slowUpstreamClient{}, time.Second, "", nil)
+	r := runner.NewRunner(test.NoOpLogger, executor, runner.Options{GlobalQueryTimeout: time.Second})
+
+	query := domain.Query{Statements: []domain.Statement{
+		{Method: "from", Resource: "hero"},
+		{Method: "from", Resource: "sidekick"},
+	}}
+
+	var notified []domain.ResourceID
+	ctx := runner.WithStatementListener(context.Background(), func(resourceID domain.ResourceID, response interface{}) {
+		notified = append(notified, resourceID)
+	})
+
+	_, err = r.ExecuteQuery(ctx, query, queryCtx)
+	test.VerifyError(t, err)
+
+	test.Equal(t, len(notified), 2)
+}
+
+type failingUpstreamClient struct{}
+
+func (failingUpstreamClient) Do(ctx context.Context, request restql.HTTPRequest) (restql.HTTPResponse, error) {
+	headers := map[string]string{"X-Trace": "1", "X-Internal": "secret"}
+	return restql.HTTPResponse{StatusCode: 500, Headers: headers}, errors.New("request execution failed")
+}
+
+func TestDoStatementExposesHeadersOnFailure(t *testing.T) {
+	heroMapping, err := restql.NewMapping("hero", "http://hero.api/hero")
+	test.VerifyError(t, err)
+
+	cfg := &conf.Config{}
+	cfg.HTTP.Headers.Response.Deny = []string{"X-Internal"}
+	policies, err := headers.New(cfg)
+	test.VerifyError(t, err)
+
+	queryCtx := restql.QueryContext{Mappings: map[string]restql.Mapping{"hero": heroMapping}}
+	executor := runner.NewExecutor(test.NoOpLogger, failingUpstreamClient{}, time.Second, "", policies)
+
+	got := executor.DoStatement(context.Background(), domain.Statement{Method: "from", Resource: "hero", DependsOn: domain.DependsOn{Resolved: true}}, queryCtx)
+
+	test.Equal(t, got.Status, 500)
+	test.Equal(t, got.ExposedHeaders, map[string]string{"X-Trace": "1"})
+}
@@ -2,6 +2,7 @@ package runner
 
 import (
 	"github.com/b2wdigital/restQL-golang/v6/internal/domain"
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
 )
 
 // State tracks the status of the statements to be resolved
@@ -119,6 +120,40 @@ func (s *State) Done() domain.Resources {
 	return d
 }
 
+// Partial returns all Resources already resolved along with the
+// ones to be resolved and being resolved, reported as timed out.
+func (s *State) Partial(log restql.Logger) domain.Resources {
+	partial := make(domain.Resources, len(s.done)+len(s.requested)+len(s.todo))
+	for resourceID, response := range s.done {
+		partial[resourceID] = response
+	}
+
+	for resourceID, stmt := range s.requested {
+		partial[resourceID] = newTimedOutResource(log, stmt)
+	}
+
+	for resourceID, stmt := range s.todo {
+		partial[resourceID] = newTimedOutResource(log, stmt)
+	}
+
+	return partial
+}
+
+func newTimedOutResource(log restql.Logger, stmt interface{}) interface{} {
+	switch stmt := stmt.(type) {
+	case domain.Statement:
+		return NewTimedOutResponse(log, DoneResourceOptions{IgnoreErrors: stmt.IgnoreErrors})
+	case []interface{}:
+		responses := make(restql.DoneResources, len(stmt))
+		for i, s := range stmt {
+			responses[i] = newTimedOutResource(log, s)
+		}
+		return responses
+	default:
+		return NewTimedOutResponse(log, DoneResourceOptions{})
+	}
+}
+
 // SetAsRequest define an to be resolved Resource
 // into a being resolved Resource.
 func (s *State) SetAsRequest(resourceID domain.ResourceID) {
@@ -153,3 +153,36 @@ func TestUpdateDone(t *testing.T) {
 		test.Equal(t, gotDoneRequests, expectedDoneRequests)
 	})
 }
+
+func TestPartial(t *testing.T) {
+	input := domain.Resources{
+		"hero":     domain.Statement{Method: "from", Resource: "hero"},
+		"sidekick": domain.Statement{Method: "from", Resource: "sidekick", IgnoreErrors: true},
+		"villain":  []interface{}{domain.Statement{Method: "from", Resource: "villain"}, domain.Statement{Method: "from", Resource: "villain"}},
+		"weapon":   domain.Statement{Method: "from", Resource: "weapon"},
+	}
+
+	timedOut := func(ignoreErrors bool) restql.DoneResource {
+		return restql.DoneResource{
+			Status:       408,
+			IgnoreErrors: ignoreErrors,
+			ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, "The request was not completed before the query timed out"),
+		}
+	}
+
+	expected := domain.Resources{
+		"hero":     restql.DoneResource{Status: 200, Success: true, ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, "ok")},
+		"sidekick": timedOut(true),
+		"villain":  restql.DoneResources{timedOut(false), timedOut(false)},
+		"weapon":   timedOut(false),
+	}
+
+	state := runner.NewState(input)
+	state.SetAsRequest("hero")
+	state.SetAsRequest("sidekick")
+	state.UpdateDone("hero", restql.DoneResource{Status: 200, Success: true, ResponseBody: restql.NewResponseBodyFromValue(test.NoOpLogger, "ok")})
+
+	got := state.Partial(test.NoOpLogger)
+
+	test.Equal(t, got, expected)
+}
@@ -0,0 +1,90 @@
+package restql
+
+import (
+	"strings"
+	"time"
+)
+
+// Actions recorded on the audit log
+const (
+	AuditActionMapResource             = "map-resource"
+	AuditActionCreateQueryRevision     = "create-query-revision"
+	AuditActionUpdateQueryArchiving    = "update-query-archiving"
+	AuditActionUpdateRevisionArchiving = "update-revision-archiving"
+	AuditActionPurgeResponseCache      = "purge-response-cache"
+)
+
+// AuditEntry represents a change made through the administrative API.
+// Actor is the name of the token used, Target is the resource or query
+// changed, and Before and After describe its state around the change.
+type AuditEntry struct {
+	Time      time.Time   `json:"time"`
+	Actor     string      `json:"actor"`
+	Action    string      `json:"action"`
+	Tenant    string      `json:"tenant,omitempty"`
+	Namespace string      `json:"namespace,omitempty"`
+	Target    string      `json:"target,omitempty"`
+	Before    interface{} `json:"before,omitempty"`
+	After     interface{} `json:"after,omitempty"`
+}
+
+// AuditFilter selects audit entries. Empty fields match any entry
+// and Limit is the maximum number of entries returned.
+//
+// TenantScope and NamespaceScope restrict the entries to the ones
+// about the tenants and namespaces the client can see, where `*`
+// matches any of them. Entries about neither are only selected when
+// both scopes are empty. The limit applies to the entries in scope.
+type AuditFilter struct {
+	Actor          string
+	Action         string
+	Tenant         string
+	Namespace      string
+	Since          time.Time
+	TenantScope    []string
+	NamespaceScope []string
+	Limit          int
+}
+
+// Match reports whether the entry is selected by the filter, ignoring the limit.
+func (f AuditFilter) Match(entry AuditEntry) bool {
+	switch {
+	case !f.inScope(entry):
+		return false
+	case f.Actor != "" && entry.Actor != f.Actor:
+		return false
+	case f.Action != "" && entry.Action != f.Action:
+		return false
+	case f.Tenant != "" && entry.Tenant != f.Tenant:
+		return false
+	case f.Namespace != "" && entry.Namespace != f.Namespace:
+		return false
+	case !f.Since.IsZero() && entry.Time.Before(f.Since):
+		return false
+	default:
+		return true
+	}
+}
+
+func (f AuditFilter) inScope(entry AuditEntry) bool {
+	if entry.Tenant == "" && entry.Namespace == "" {
+		return len(f.TenantScope) == 0 && len(f.NamespaceScope) == 0
+	}
+
+	return (entry.Tenant == "" || scopeIncludes(f.TenantScope, entry.Tenant)) &&
+		(entry.Namespace == "" || scopeIncludes(f.NamespaceScope, entry.Namespace))
+}
+
+func scopeIncludes(scope []string, value string) bool {
+	if len(scope) == 0 {
+		return true
+	}
+
+	for _, s := range scope {
+		if s == "*" || strings.EqualFold(s, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,32 @@
+package restql_test
+
+import (
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestAuditFilterScope(t *testing.T) {
+	author := restql.AuditFilter{NamespaceScope: []string{"dc"}}
+	mapper := restql.AuditFilter{TenantScope: []string{"*"}}
+	admin := restql.AuditFilter{}
+
+	entries := []restql.AuditEntry{
+		{Action: restql.AuditActionCreateQueryRevision, Namespace: "dc"},
+		{Action: restql.AuditActionCreateQueryRevision, Namespace: "marvel"},
+		{Action: restql.AuditActionMapResource, Tenant: "DC"},
+		{Action: restql.AuditActionPurgeResponseCache},
+	}
+
+	var authorVisible, mapperVisible, adminVisible []bool
+	for _, e := range entries {
+		authorVisible = append(authorVisible, author.Match(e))
+		mapperVisible = append(mapperVisible, mapper.Match(e))
+		adminVisible = append(adminVisible, admin.Match(e))
+	}
+
+	test.Equal(t, authorVisible, []bool{true, false, true, false})
+	test.Equal(t, mapperVisible, []bool{true, true, true, false})
+	test.Equal(t, adminVisible, []bool{true, true, true, true})
+}
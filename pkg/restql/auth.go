@@ -0,0 +1,34 @@
+package restql
+
+import "context"
+
+// Authentication methods accepted on the query endpoints
+const (
+	AuthMethodJWT    = "jwt"
+	AuthMethodAPIKey = "apiKey"
+)
+
+// Principal is the client authenticated on a query request.
+// Subject is the JWT `sub` claim or the API key name, and Claims
+// holds all verified JWT claims.
+type Principal struct {
+	Method  string
+	Subject string
+	Claims  map[string]interface{}
+}
+
+type principalCtxKey struct{}
+
+// WithPrincipal stores the authenticated client in a child
+// context.Context created from the given context.Context.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalCtxKey{}, p)
+}
+
+// GetPrincipal extracts the authenticated client from the
+// given context.Context, returning false when authentication
+// is disabled.
+func GetPrincipal(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalCtxKey{}).(Principal)
+	return p, ok
+}
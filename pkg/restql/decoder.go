@@ -0,0 +1,179 @@
+package restql
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"mime"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	xmlAttributePrefix = "@"
+	xmlTextKey         = "#text"
+)
+
+var errEmptyXML = errors.New("xml document has no root element")
+
+type bodyDecoder func(data []byte) (interface{}, error)
+
+// decoderFor returns the decoder able to transform a non JSON
+// upstream body into a value restQL can manipulate, based on
+// the response content type.
+func decoderFor(contentType string) (bodyDecoder, bool) {
+	if contentType == "" {
+		return nil, false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+
+	switch {
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		return decodeXML, true
+	case mediaType == "application/x-www-form-urlencoded":
+		return decodeForm, true
+	case mediaType == "text/csv":
+		return decodeCSV, true
+	default:
+		return nil, false
+	}
+}
+
+// decodeXML transforms a XML document into a map keyed by the root element.
+//
+// Elements with neither attributes nor children become strings,
+// attributes are keyed with a `@` prefix, text content mixed with
+// attributes or children is keyed as `#text` and repeated elements
+// become lists.
+func decodeXML(data []byte) (interface{}, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		token, err := dec.Token()
+		if err == io.EOF {
+			return nil, errEmptyXML
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if start, ok := token.(xml.StartElement); ok {
+			value, err := decodeXMLElement(dec, start)
+			if err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{start.Name.Local: value}, nil
+		}
+	}
+}
+
+func decodeXMLElement(dec *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	element := make(map[string]interface{})
+	for _, attr := range start.Attr {
+		element[xmlAttributePrefix+attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(dec, token)
+			if err != nil {
+				return nil, err
+			}
+
+			appendXMLChild(element, token.Name.Local, child)
+		case xml.CharData:
+			text.Write(token)
+		case xml.EndElement:
+			content := strings.TrimSpace(text.String())
+			if len(element) == 0 {
+				return content, nil
+			}
+
+			if content != "" {
+				element[xmlTextKey] = content
+			}
+
+			return element, nil
+		}
+	}
+}
+
+func appendXMLChild(element map[string]interface{}, name string, child interface{}) {
+	current, found := element[name]
+	if !found {
+		element[name] = child
+		return
+	}
+
+	if list, ok := current.([]interface{}); ok {
+		element[name] = append(list, child)
+		return
+	}
+
+	element[name] = []interface{}{current, child}
+}
+
+// decodeForm transforms a form-urlencoded body into a map,
+// keys with multiple values become lists.
+func decodeForm(data []byte) (interface{}, error) {
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{}, len(values))
+	for key, v := range values {
+		if len(v) == 1 {
+			result[key] = v[0]
+			continue
+		}
+
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = item
+		}
+		result[key] = list
+	}
+
+	return result, nil
+}
+
+// decodeCSV transforms a CSV body into a list of maps,
+// using the first record as the header.
+func decodeCSV(data []byte) (interface{}, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return []interface{}{}, nil
+	}
+
+	header := records[0]
+	result := make([]interface{}, len(records)-1)
+	for i, record := range records[1:] {
+		row := make(map[string]interface{}, len(header))
+		for j, column := range header {
+			row[column] = record[j]
+		}
+		result[i] = row
+	}
+
+	return result, nil
+}
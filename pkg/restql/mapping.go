@@ -9,7 +9,8 @@ import (
 )
 
 var pathParamRegex = regexp.MustCompile(":([^/]+)/?")
-var urlRegex = regexp.MustCompile(`(https?)://([^/]+)([^?]*)\??(.*)`)
+var urlRegex = regexp.MustCompile(`(https?|srv|registry|graphqls?|grpcs?)://([^/]+)([^?]*)\??(.*)`)
+var unixURLRegex = regexp.MustCompile(`^(unix)://(/[^:?]+):?([^?]*)\??(.*)`)
 
 // Mapping represents the association of a name to a REST resource url.
 // It support special syntax in the URL to provide dynamic value substitution, like:
@@ -19,6 +20,15 @@ var urlRegex = regexp.MustCompile(`(https?)://([^/]+)([^?]*)\??(.*)`)
 //• QueryRevisions parameters: can be defined by placing a colon (:) before an identifier in the URL query,
 // for example "http://some.api?:page", will replace ":page" by the value of the "page" parameter
 // in the query definition creating the URL "http://some.api?page=<value>".
+//• Service discovery: the host can be resolved at request time by using the "srv" schema,
+// which looks up DNS SRV records, like "srv://_hero._tcp.some.domain/:id", or the "registry"
+// schema, which looks up a service name in the file-based registry, like "registry://hero/:id".
+//• GraphQL: the "graphql" and "graphqls" schemas call a GraphQL endpoint using HTTP and HTTPS,
+// respectively, sending the statement parameters as the operation variables, like "graphql://some.api/graphql".
+//• gRPC: the "grpc" and "grpcs" schemas call an unary gRPC method using plaintext and TLS,
+// respectively, with the service and method as path, like "grpc://some.api/some.package.Service/Method".
+//• Unix domain sockets: the "unix" schema calls an upstream listening on a socket file,
+// using a colon (:) to separate the socket path from the URL path, like "unix:///var/run/hero.sock:/:id".
 type Mapping struct {
 	resourceName  string
 	url           string
@@ -38,7 +48,10 @@ type Mapping struct {
 func NewMapping(resource, url string) (Mapping, error) {
 	mapping := Mapping{resourceName: resource, url: url}
 
-	urlMatches := urlRegex.FindAllStringSubmatch(url, -1)
+	urlMatches := unixURLRegex.FindAllStringSubmatch(url, -1)
+	if len(urlMatches) == 0 {
+		urlMatches = urlRegex.FindAllStringSubmatch(url, -1)
+	}
 	if len(urlMatches) == 0 {
 		return Mapping{}, errors.Errorf("failed to create mapping from %s", url)
 	}
@@ -94,3 +94,113 @@ func TestMappingsQueryWithParams(t *testing.T) {
 		})
 	}
 }
+
+func TestNewMappingWithUnixSchema(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		expectedHost string
+		expectedPath string
+		queryParam   string
+	}{
+		{
+			"should create mapping with socket path and url path",
+			"unix:///var/run/hero.sock:/hero/:id",
+			"/var/run/hero.sock",
+			"/hero/1",
+			"",
+		},
+		{
+			"should create mapping with socket path only",
+			"unix:///var/run/hero.sock",
+			"/var/run/hero.sock",
+			"",
+			"",
+		},
+		{
+			"should create mapping with socket path, url path and query params",
+			"unix:///var/run/hero.sock:/hero/:id?:page",
+			"/var/run/hero.sock",
+			"/hero/1",
+			"page",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping, err := restql.NewMapping("test-resource", tt.url)
+			test.VerifyError(t, err)
+
+			test.Equal(t, mapping.Schema(), "unix")
+			test.Equal(t, mapping.Host(), tt.expectedHost)
+			test.Equal(t, mapping.PathWithParams(map[string]interface{}{"id": "1"}), tt.expectedPath)
+			if tt.queryParam != "" {
+				test.Equal(t, mapping.IsQueryParam(tt.queryParam), true)
+			}
+		})
+	}
+}
+
+func TestNewMappingWithServiceDiscoverySchema(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		expectedSchema string
+		expectedHost   string
+	}{
+		{
+			"should create mapping with srv schema",
+			"srv://_hero._tcp.some.domain/hero/:id",
+			"srv",
+			"_hero._tcp.some.domain",
+		},
+		{
+			"should create mapping with registry schema",
+			"registry://hero/hero/:id",
+			"registry",
+			"hero",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping, err := restql.NewMapping("test-resource", tt.url)
+			test.VerifyError(t, err)
+
+			test.Equal(t, mapping.Schema(), tt.expectedSchema)
+			test.Equal(t, mapping.Host(), tt.expectedHost)
+			test.Equal(t, mapping.PathWithParams(map[string]interface{}{"id": "1"}), "/hero/1")
+		})
+	}
+}
+
+func TestNewMappingWithGraphQLSchema(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		expectedSchema string
+	}{
+		{"should create mapping with graphql schema", "graphql://hero.api/graphql", "graphql"},
+		{"should create mapping with graphqls schema", "graphqls://hero.api/graphql", "graphqls"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping, err := restql.NewMapping("test-resource", tt.url)
+			test.VerifyError(t, err)
+
+			test.Equal(t, mapping.Schema(), tt.expectedSchema)
+			test.Equal(t, mapping.Host(), "hero.api")
+			test.Equal(t, mapping.PathWithParams(nil), "/graphql")
+		})
+	}
+}
+
+func TestNewMappingWithGRPCSchema(t *testing.T) {
+	mapping, err := restql.NewMapping("test-resource", "grpcs://hero.api:443/hero.HeroService/GetHero")
+	test.VerifyError(t, err)
+
+	test.Equal(t, mapping.Schema(), "grpcs")
+	test.Equal(t, mapping.Host(), "hero.api:443")
+	test.Equal(t, mapping.PathWithParams(nil), "/hero.HeroService/GetHero")
+}
@@ -146,6 +146,32 @@ type DatabasePlugin interface {
 	SetMapping(ctx context.Context, tenantID string, mappingsName string, url string) error
 }
 
+// PersistedQueryDatabase is an optional interface a DatabasePlugin
+// can implement to store persisted queries, which are identified
+// by the SHA-256 hash of their text, in hexadecimal form.
+// When the query is not stored, FindPersistedQuery should
+// return ErrQueryNotFoundInDatabase.
+type PersistedQueryDatabase interface {
+	FindPersistedQuery(ctx context.Context, hash string) (string, error)
+	CreatePersistedQuery(ctx context.Context, hash string, text string) error
+}
+
+// PingableDatabase is an optional interface a DatabasePlugin can
+// implement to verify its connection, which is used by the readiness
+// check. Ping should return an error when the database cannot be used.
+type PingableDatabase interface {
+	Ping(ctx context.Context) error
+}
+
+// AuditLogDatabase is an optional interface a DatabasePlugin can
+// implement to store the audit log of the administrative API.
+// Entries are never updated or removed, and FindAuditEntries
+// should return the ones matching the filter, newest first.
+type AuditLogDatabase interface {
+	CreateAuditEntry(ctx context.Context, entry AuditEntry) error
+	FindAuditEntries(ctx context.Context, filter AuditFilter) ([]AuditEntry, error)
+}
+
 // Errors returned by Database plugin
 var (
 	ErrMappingsNotFoundInDatabase  = errors.New("mappings not found in database")
@@ -17,10 +17,22 @@ import (
 // If the byte slice is unmarshalled or a new value is set on the response body
 // with SetValue method, then the Marshal and Unmarshal function will operate
 // using this value rather then the byte slice.
+//
+// When the byte slice is not a valid JSON, the content type set with
+// SetContentType is used to decode XML, form-urlencoded and CSV bodies
+// into a value that can be manipulated like a JSON one.
 type ResponseBody struct {
-	log Logger
-	jsonBytes []byte
-	jsonValue interface{}
+	log         Logger
+	contentType string
+	jsonBytes   []byte
+	jsonValue   interface{}
+
+	// decoding a non JSON byte slice is memoized apart from
+	// the generic value, so that Valid can be followed by
+	// Marshal or Unmarshal without decoding it twice
+	decoded   interface{}
+	decodedOk bool
+	decodeRun bool
 }
 
 // NewResponseBodyFromBytes creates a ResponseBody wrapper from
@@ -56,6 +68,18 @@ func (r *ResponseBody) SetValue(v interface{}) {
 	r.jsonValue = v
 }
 
+// SetContentType defines the media type of the byte slice data,
+// allowing non JSON content to be decoded.
+func (r *ResponseBody) SetContentType(contentType string) {
+	r.contentType = contentType
+	r.resetDecoded()
+}
+
+// ContentType return the media type of the byte slice data.
+func (r *ResponseBody) ContentType() string {
+	return r.contentType
+}
+
 // Marshal returns the content of ResponseBody ready to
 // be sent to downstream.
 //
@@ -63,10 +87,17 @@ func (r *ResponseBody) SetValue(v interface{}) {
 // - If there is a generic data, marshal it using a JSON parser
 //   and return the result as a json.RawMessage.
 // - Else, if the byte slice is empty, return nil.
-// - Else, if the byte slice is not an valid JSON, stringify it.
+// - Else, if the byte slice is not an valid JSON, decode it based on
+//   the content type and marshal the result, or else stringify it.
 // - Finally, if the byte slice is not empty and is a valid json,
 //   return it as a json.RawMessage.
 func (r *ResponseBody) Marshal() (interface{}, error) {
+	if r.jsonValue == nil && len(r.jsonBytes) > 0 && !json.Valid(r.jsonBytes) {
+		if v, ok := r.decode(); ok {
+			r.jsonValue = v
+		}
+	}
+
 	if r.jsonValue != nil {
 		b, err := json.Marshal(r.jsonValue)
 		if err != nil {
@@ -92,8 +123,8 @@ func (r *ResponseBody) Marshal() (interface{}, error) {
 //
 // This method can process the content in 4 ways:
 // - If there is a generic data, return it.
-// - Else, if the byte slice is empty or is not a valid json,
-//   return it as a string.
+// - Else, if the byte slice is empty or is not a valid json
+//   nor a decodable content type, return it as a string.
 // - Finally, if it is valid to be manipulated, then unmarshal it
 //   and return.
 func (r *ResponseBody) Unmarshal() interface{} {
@@ -102,10 +133,20 @@ func (r *ResponseBody) Unmarshal() interface{} {
 	}
 
 	bodyByte := r.jsonBytes
-	if !r.Valid() {
+	if len(bodyByte) == 0 {
 		return string(bodyByte)
 	}
 
+	if !json.Valid(bodyByte) {
+		v, ok := r.decode()
+		if !ok {
+			return string(bodyByte)
+		}
+
+		r.jsonValue = v
+		return v
+	}
+
 	var responseBody interface{}
 	err := json.Unmarshal(bodyByte, &responseBody)
 	if err != nil {
@@ -126,13 +167,52 @@ func (r *ResponseBody) Valid() bool {
 		return true
 	}
 
-	return len(r.jsonBytes) > 0 && json.Valid(r.jsonBytes)
+	if len(r.jsonBytes) == 0 {
+		return false
+	}
+
+	if json.Valid(r.jsonBytes) {
+		return true
+	}
+
+	_, ok := r.decode()
+	return ok
+}
+
+// decode parses a non JSON byte slice using the decoder
+// associated with the content type, only once.
+func (r *ResponseBody) decode() (interface{}, bool) {
+	if r.decodeRun {
+		return r.decoded, r.decodedOk
+	}
+	r.decodeRun = true
+
+	decoder, ok := decoderFor(r.contentType)
+	if !ok {
+		return nil, false
+	}
+
+	v, err := decoder(r.jsonBytes)
+	if err != nil {
+		r.log.Debug("failed to decode response body", "content-type", r.contentType, "error", err)
+		return nil, false
+	}
+
+	r.decoded, r.decodedOk = v, true
+	return v, true
+}
+
+func (r *ResponseBody) resetDecoded() {
+	r.decoded = nil
+	r.decodedOk = false
+	r.decodeRun = false
 }
 
 // Clear removes all internal content.
 func (r *ResponseBody) Clear() {
 	r.jsonBytes = nil
 	r.jsonValue = nil
+	r.resetDecoded()
 }
 
 // ResourceCacheControlValue represents the values a cache control
@@ -166,6 +246,11 @@ type DoneResource struct {
 	ResponseHeaders map[string]string
 	ResponseBody    *ResponseBody
 	ResponseTime    int64
+
+	// ExposedHeaders are the response headers that can be returned
+	// to the client, when the header policy of the resource restricts
+	// them. If nil, every response header is returned.
+	ExposedHeaders map[string]string
 }
 
 // DoneResources represents a multiplexed statement result.
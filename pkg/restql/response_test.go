@@ -0,0 +1,112 @@
+package restql_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/b2wdigital/restQL-golang/v6/pkg/restql"
+	"github.com/b2wdigital/restQL-golang/v6/test"
+)
+
+func TestResponseBodyUnmarshal(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    interface{}
+	}{
+		{
+			"should unmarshal json body",
+			"application/json",
+			`{"id": "1", "name": "batman"}`,
+			map[string]interface{}{"id": "1", "name": "batman"},
+		},
+		{
+			"should unmarshal json body regardless of content type",
+			"text/plain",
+			`{"id": "1"}`,
+			map[string]interface{}{"id": "1"},
+		},
+		{
+			"should stringify unknown content type body",
+			"text/plain",
+			`batman`,
+			"batman",
+		},
+		{
+			"should decode xml body",
+			"application/xml; charset=utf-8",
+			`<?xml version="1.0"?><hero id="1"><name>batman</name><weapon>batarang</weapon><weapon>grapnel</weapon><city country="us">gotham</city></hero>`,
+			map[string]interface{}{
+				"hero": map[string]interface{}{
+					"@id":    "1",
+					"name":   "batman",
+					"weapon": []interface{}{"batarang", "grapnel"},
+					"city":   map[string]interface{}{"@country": "us", "#text": "gotham"},
+				},
+			},
+		},
+		{
+			"should decode xml body with suffixed media type",
+			"application/atom+xml",
+			`<feed><title>heroes</title></feed>`,
+			map[string]interface{}{"feed": map[string]interface{}{"title": "heroes"}},
+		},
+		{
+			"should stringify malformed xml body",
+			"text/xml",
+			`<hero><name>batman</hero>`,
+			`<hero><name>batman</hero>`,
+		},
+		{
+			"should decode form-urlencoded body",
+			"application/x-www-form-urlencoded",
+			`name=batman&weapon=batarang&weapon=grapnel`,
+			map[string]interface{}{"name": "batman", "weapon": []interface{}{"batarang", "grapnel"}},
+		},
+		{
+			"should decode csv body",
+			"text/csv",
+			"id,name\n1,batman\n2,robin\n",
+			[]interface{}{
+				map[string]interface{}{"id": "1", "name": "batman"},
+				map[string]interface{}{"id": "2", "name": "robin"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rb := restql.NewResponseBodyFromBytes(restql.GetLogger(context.Background()), []byte(tt.body))
+			rb.SetContentType(tt.contentType)
+
+			test.Equal(t, rb.Unmarshal(), tt.expected)
+		})
+	}
+}
+
+func TestResponseBodyMarshalDecodedContent(t *testing.T) {
+	rb := restql.NewResponseBodyFromBytes(restql.GetLogger(context.Background()), []byte(`id=1&name=batman`))
+	rb.SetContentType("application/x-www-form-urlencoded")
+
+	test.Equal(t, rb.Valid(), true)
+
+	got, err := rb.Marshal()
+	test.VerifyError(t, err)
+	test.Equal(t, got, json.RawMessage(`{"id":"1","name":"batman"}`))
+}
+
+func TestResponseBodyValidDecodedContent(t *testing.T) {
+	rb := restql.NewResponseBodyFromBytes(restql.GetLogger(context.Background()), []byte("id,name\n1,batman\n"))
+	rb.SetContentType("text/csv")
+
+	test.Equal(t, rb.Valid(), true)
+	test.Equal(t, rb.Value(), nil)
+	test.Equal(t, rb.Unmarshal(), []interface{}{map[string]interface{}{"id": "1", "name": "batman"}})
+
+	rb.SetContentType("text/plain")
+	rb.SetValue(nil)
+
+	test.Equal(t, rb.Valid(), false)
+}